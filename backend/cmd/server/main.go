@@ -1,23 +1,105 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/hridaya14/Web-Tech-Project/internal/alerts"
+	"github.com/hridaya14/Web-Tech-Project/internal/email"
+	"github.com/hridaya14/Web-Tech-Project/internal/privacy"
+	"github.com/hridaya14/Web-Tech-Project/internal/reports"
+	"github.com/hridaya14/Web-Tech-Project/internal/retention"
 	"github.com/hridaya14/Web-Tech-Project/internal/server"
+	handlers "github.com/hridaya14/Web-Tech-Project/internal/server/Handlers"
+	"github.com/hridaya14/Web-Tech-Project/internal/tracking"
+	"github.com/hridaya14/Web-Tech-Project/internal/webhooks"
+	"github.com/hridaya14/Web-Tech-Project/pkg/ai"
+	"github.com/hridaya14/Web-Tech-Project/pkg/bucket"
+	"github.com/hridaya14/Web-Tech-Project/pkg/document"
+	"github.com/hridaya14/Web-Tech-Project/pkg/mail"
 	"github.com/hridaya14/Web-Tech-Project/pkg/orm"
+	"github.com/hridaya14/Web-Tech-Project/pkg/scan"
 )
 
 func main() {
 
 	orm.Init()
 
-	server, err := server.CreateServer()
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	mailer, err := mail.NewFromEnv()
+	if err != nil {
+		log.Fatalf("Unable to configure mail driver: %v", err)
+	}
+	go email.RunSender(ctx, mailer)
+	go webhooks.RunDispatcher(ctx)
+	go alerts.RunScheduler(ctx)
+
+	aiClient, err := ai.NewFromEnv()
+	if err != nil {
+		log.Fatalf("Unable to configure ai client: %v", err)
+	}
+
+	scanner, err := scan.NewFromEnv()
+	if err != nil {
+		log.Fatalf("Unable to configure malware scanner: %v", err)
+	}
+
+	uploadLimits, err := document.LimitsFromEnv()
+	if err != nil {
+		log.Fatalf("Unable to configure upload limits: %v", err)
+	}
+
+	storage, err := bucket.NewFromEnv(ctx)
+	if err != nil {
+		log.Fatalf("Unable to configure storage: %v", err)
+	}
+
+	go privacy.RunWorker(ctx, storage)
+
+	retentionConfig, err := retention.ConfigFromEnv()
+	if err != nil {
+		log.Fatalf("Unable to configure retention rules: %v", err)
+	}
+	go retention.RunScheduler(ctx, storage, retentionConfig)
+
+	reportsInterval, err := reports.IntervalFromEnv()
+	if err != nil {
+		log.Fatalf("Unable to configure report refreshes: %v", err)
+	}
+	go reports.RunScheduler(ctx, reportsInterval)
+
+	server, err := server.CreateServer(handlers.Services{AI: aiClient, Scanner: scanner, UploadLimits: uploadLimits, Storage: storage, Retention: retentionConfig})
 
 	if err != nil {
 		log.Fatal("Unable to start server!")
 	}
 
-	if err := server.Run(":5000"); err != nil {
+	go tracking.Default.Run(ctx)
+
+	httpServer := &http.Server{Addr: ":5000", Handler: server}
+	drained := make(chan struct{})
+	go func() {
+		defer close(drained)
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		httpServer.Shutdown(shutdownCtx)
+	}()
+
+	if err := httpServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
 		log.Fatalf("Server failed to start: %v", err)
 	}
+
+	// Write the views recorded by the requests that were still running
+	<-drained
+	tracking.Default.Flush()
 	
 }
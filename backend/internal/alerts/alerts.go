@@ -0,0 +1,98 @@
+package alerts
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hridaya14/Web-Tech-Project/internal/database"
+	"github.com/hridaya14/Web-Tech-Project/internal/models"
+	"github.com/hridaya14/Web-Tech-Project/internal/notify"
+	"github.com/hridaya14/Web-Tech-Project/pkg/poll"
+)
+
+const (
+	pollInterval = time.Minute
+	batchSize    = 50
+	claimLease   = 5 * time.Minute
+	// maxDigestListings bounds one digest; the rest go out with the next one
+	maxDigestListings = 20
+)
+
+// wake lets a new listing trigger a run without waiting for the next tick
+var wake = make(chan struct{}, 1)
+
+// Wake asks the scheduler to evaluate due alerts now, so instant alerts go out as soon as a
+// listing is posted. It never blocks.
+func Wake() {
+	select {
+	case wake <- struct{}{}:
+	default:
+	}
+}
+
+// NextRun is when an alert with the given frequency is next evaluated after now.
+// Instant alerts are due at every run.
+func NextRun(frequency string, now time.Time) time.Time {
+	switch frequency {
+	case models.AlertDaily:
+		return now.Add(24 * time.Hour)
+	case models.AlertWeekly:
+		return now.Add(7 * 24 * time.Hour)
+	default:
+		return now
+	}
+}
+
+// RunScheduler evaluates due alerts, closes listings past their deadline and warns about
+// saved listings that are about to close, until ctx is cancelled.
+func RunScheduler(ctx context.Context) {
+	log.Println("✅ Job alert scheduler started")
+
+	poll.Run(ctx, pollInterval, wake, func() {
+		closeExpired()
+		remindClosing()
+		runDue(ctx, time.Now())
+	})
+}
+
+// runDue evaluates the alerts due by dueBy. Instant alerts are due again as soon as they run,
+// so only those due when the round started count; otherwise they would keep the round going.
+func runDue(ctx context.Context, dueBy time.Time) {
+	poll.Drain(ctx, batchSize, func(limit int) ([]models.JobAlert, error) {
+		return database.ClaimDueJobAlerts(dueBy, limit, claimLease)
+	}, func(alert models.JobAlert) {
+		if err := evaluate(alert); err != nil {
+			log.Printf("Error evaluating job alert %s: %v", alert.ID, err)
+		}
+	})
+}
+
+// evaluate sends the alert's candidate the listings that matched since its last digest, if any.
+func evaluate(alert models.JobAlert) error {
+	listings, err := database.FindJobAlertMatches(alert, maxDigestListings)
+	if err != nil {
+		return err
+	}
+
+	delivered, userID, err := database.DeliverJobAlert(alert, listings, NextRun(alert.Frequency, time.Now()))
+	if err != nil || len(delivered) == 0 {
+		return err
+	}
+
+	jobIDs := make([]uuid.UUID, 0, len(delivered))
+	for _, listing := range delivered {
+		jobIDs = append(jobIDs, listing.ID)
+	}
+
+	body := fmt.Sprintf("%s matches \"%s\"", delivered[0].Listing_title, alert.Name)
+	if len(delivered) > 1 {
+		body = fmt.Sprintf("%d new jobs match \"%s\"", len(delivered), alert.Name)
+	}
+	notify.Send([]uuid.UUID{userID}, models.NotifyJobAlert, "New jobs for "+alert.Name, body,
+		map[string]any{"alert_id": alert.ID, "job_ids": jobIDs},
+	)
+	return nil
+}
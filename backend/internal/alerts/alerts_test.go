@@ -0,0 +1,50 @@
+package alerts
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hridaya14/Web-Tech-Project/internal/models"
+)
+
+func TestNextRun(t *testing.T) {
+	now := time.Date(2026, 3, 28, 9, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		frequency string
+		want      time.Time
+	}{
+		{models.AlertInstant, now},
+		{models.AlertDaily, now.Add(24 * time.Hour)},
+		{models.AlertWeekly, now.Add(7 * 24 * time.Hour)},
+		{"", now},
+	}
+
+	for _, tt := range tests {
+		if got := NextRun(tt.frequency, now); !got.Equal(tt.want) {
+			t.Errorf("NextRun(%q) = %s, want %s", tt.frequency, got, tt.want)
+		}
+	}
+}
+
+func TestWakeNeverBlocks(t *testing.T) {
+	done := make(chan struct{})
+	go func() {
+		Wake()
+		Wake()
+		Wake()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Wake blocked with nobody listening")
+	}
+
+	select {
+	case <-wake:
+	default:
+		t.Fatal("Wake left no signal for the scheduler")
+	}
+}
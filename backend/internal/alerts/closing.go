@@ -0,0 +1,44 @@
+package alerts
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hridaya14/Web-Tech-Project/internal/database"
+	"github.com/hridaya14/Web-Tech-Project/internal/models"
+	"github.com/hridaya14/Web-Tech-Project/internal/notify"
+)
+
+// ClosingSoonWindow is how close to its deadline a saved listing counts as closing soon
+const ClosingSoonWindow = 72 * time.Hour
+
+// closeExpired closes listings whose deadline passed since the last run.
+func closeExpired() {
+	listings, err := database.CloseExpiredListings()
+	if err != nil {
+		return
+	}
+	if len(listings) > 0 {
+		log.Printf("Closed %d job listings past their deadline", len(listings))
+	}
+}
+
+// remindClosing warns candidates once about each saved listing that is about to close
+// while they have not applied to it.
+func remindClosing() {
+	closing, err := database.ClaimSavedJobsClosingSoon(ClosingSoonWindow)
+	if err != nil {
+		return
+	}
+
+	for _, saved := range closing {
+		notify.Send([]uuid.UUID{saved.CandidateUserID}, models.NotifySavedJobClosing,
+			"Saved job closing soon",
+			fmt.Sprintf("Applications for %s close on %s", saved.JobTitle,
+				saved.ClosesAt.UTC().Format("Mon 2 Jan 2006 15:04 MST")),
+			map[string]any{"job_id": saved.JobID, "closes_at": saved.ClosesAt},
+		)
+	}
+}
@@ -0,0 +1,175 @@
+package audit
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"reflect"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hridaya14/Web-Tech-Project/internal/database"
+	"github.com/hridaya14/Web-Tech-Project/internal/models"
+)
+
+// pendingKey holds the events a handler recorded; Middleware writes them once the response status is known
+const pendingKey = "audit_events"
+
+// Target is what an action was done to
+type Target struct {
+	Type string
+	ID   string
+}
+
+// Record attaches an event to the request in c, with the authenticated user as the actor.
+// before and after are the target's state around the change; only the fields that differ
+// are kept. Either may be nil.
+func Record(c *gin.Context, action string, target Target, before, after any, metadata map[string]any) {
+	RecordAs(c, nil, action, target, before, after, metadata)
+}
+
+// RecordAs is Record for routes without an authenticated user, such as login.
+// A nil actor falls back to the authenticated user, if any.
+func RecordAs(c *gin.Context, actor *models.AuthenticatedUser, action string, target Target, before, after any, metadata map[string]any) {
+	event := models.AuditEvent{
+		Action:     action,
+		TargetType: target.Type,
+		TargetID:   target.ID,
+	}
+	if actor != nil {
+		event.ActorID = &actor.ID
+		event.ActorRole = actor.Role
+	}
+	event.Before, event.After = diff(before, after)
+	event.Metadata = encodeMetadata(metadata)
+
+	pending, _ := c.Get(pendingKey)
+	events, _ := pending.([]models.AuditEvent)
+	c.Set(pendingKey, append(events, event))
+}
+
+// System writes an event for an action taken by the server itself, such as a background job.
+func System(action string, target Target, before any, metadata map[string]any) {
+	event := models.AuditEvent{
+		ActorRole:  "system",
+		Action:     action,
+		TargetType: target.Type,
+		TargetID:   target.ID,
+	}
+	event.Before, _ = diff(before, nil)
+	event.Metadata = encodeMetadata(metadata)
+
+	database.InsertAuditEvent(event)
+}
+
+// Middleware writes the events handlers recorded, and a generic event for every other
+// state changing request. Request bodies are never stored; they may carry passwords.
+// Failures are logged by the database layer; auditing never fails the request itself.
+func Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		request := fromRequest(c)
+
+		pending, _ := c.Get(pendingKey)
+		events, _ := pending.([]models.AuditEvent)
+		for _, event := range events {
+			if event.ActorID == nil {
+				event.ActorID, event.ActorRole = request.ActorID, request.ActorRole
+			}
+			event.IPAddress, event.UserAgent = request.IPAddress, request.UserAgent
+			event.Method, event.Route, event.StatusCode = request.Method, request.Route, request.StatusCode
+			database.InsertAuditEvent(event)
+		}
+		if len(events) > 0 {
+			return
+		}
+
+		switch c.Request.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			return
+		}
+		if c.FullPath() == "" {
+			return
+		}
+
+		request.Action = models.AuditRequest
+		database.InsertAuditEvent(request)
+	}
+}
+
+func fromRequest(c *gin.Context) models.AuditEvent {
+	status := c.Writer.Status()
+	event := models.AuditEvent{
+		IPAddress:  c.ClientIP(),
+		UserAgent:  c.Request.UserAgent(),
+		Method:     c.Request.Method,
+		Route:      c.FullPath(),
+		StatusCode: &status,
+	}
+
+	if value, ok := c.Get("user"); ok {
+		if user, ok := value.(*models.AuthenticatedUser); ok {
+			event.ActorID = &user.ID
+			event.ActorRole = user.Role
+		}
+	}
+	return event
+}
+
+// diff encodes before and after as JSON objects holding only the fields that changed.
+// A side that is nil is left empty and the other is kept whole.
+func diff(before, after any) ([]byte, []byte) {
+	beforeFields, beforeOK := fields(before)
+	afterFields, afterOK := fields(after)
+
+	if beforeOK && afterOK {
+		for key, value := range beforeFields {
+			if other, ok := afterFields[key]; ok && reflect.DeepEqual(value, other) {
+				delete(beforeFields, key)
+				delete(afterFields, key)
+			}
+		}
+	}
+
+	return encode(beforeFields, beforeOK), encode(afterFields, afterOK)
+}
+
+func fields(value any) (map[string]any, bool) {
+	if value == nil {
+		return nil, false
+	}
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		log.Printf("Error encoding audit state: %v", err)
+		return nil, false
+	}
+	var out map[string]any
+	if err := json.Unmarshal(encoded, &out); err != nil {
+		// Not an object, keep it under a single key
+		return map[string]any{"value": value}, true
+	}
+	return out, true
+}
+
+func encodeMetadata(metadata map[string]any) []byte {
+	if metadata == nil {
+		return nil
+	}
+	encoded, err := json.Marshal(metadata)
+	if err != nil {
+		log.Printf("Error encoding audit metadata: %v", err)
+		return nil
+	}
+	return encoded
+}
+
+func encode(value map[string]any, ok bool) []byte {
+	if !ok {
+		return nil
+	}
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return nil
+	}
+	return encoded
+}
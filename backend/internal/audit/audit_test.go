@@ -0,0 +1,88 @@
+package audit
+
+import "testing"
+
+func TestDiff(t *testing.T) {
+	type listing struct {
+		Title  string   `json:"title"`
+		Status string   `json:"status"`
+		Skills []string `json:"skills"`
+	}
+
+	tests := []struct {
+		name       string
+		before     any
+		after      any
+		wantBefore string
+		wantAfter  string
+	}{
+		{
+			"changed fields only",
+			listing{Title: "Engineer", Status: "open", Skills: []string{"go"}},
+			listing{Title: "Engineer", Status: "closed", Skills: []string{"go"}},
+			`{"status":"open"}`,
+			`{"status":"closed"}`,
+		},
+		{
+			"slices compared by value",
+			listing{Skills: []string{"go"}},
+			listing{Skills: []string{"go", "sql"}},
+			`{"skills":["go"]}`,
+			`{"skills":["go","sql"]}`,
+		},
+		{
+			"field added and removed",
+			map[string]any{"a": 1, "b": 2},
+			map[string]any{"b": 2, "c": 3},
+			`{"a":1}`,
+			`{"c":3}`,
+		},
+		{
+			"nothing changed",
+			map[string]string{"role": "viewer"},
+			map[string]string{"role": "viewer"},
+			`{}`,
+			`{}`,
+		},
+		{
+			"created",
+			nil,
+			map[string]string{"role": "viewer"},
+			``,
+			`{"role":"viewer"}`,
+		},
+		{
+			"deleted",
+			listing{Title: "Engineer"},
+			nil,
+			`{"skills":null,"status":"","title":"Engineer"}`,
+			``,
+		},
+		{
+			"scalars",
+			"pending",
+			"accepted",
+			`{"value":"pending"}`,
+			`{"value":"accepted"}`,
+		},
+		{
+			"unencodable",
+			func() {},
+			map[string]int{"a": 1},
+			``,
+			`{"a":1}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before, after := diff(tt.before, tt.after)
+			if string(before) != tt.wantBefore {
+				t.Errorf("before = %s, want %s", before, tt.wantBefore)
+			}
+			if string(after) != tt.wantAfter {
+				t.Errorf("after = %s, want %s", after, tt.wantAfter)
+			}
+		})
+	}
+}
@@ -0,0 +1,269 @@
+package database
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hridaya14/Web-Tech-Project/internal/models"
+	"github.com/hridaya14/Web-Tech-Project/pkg/orm"
+	"github.com/lib/pq"
+)
+
+// MaxJobAlerts is how many alerts one candidate may save
+const MaxJobAlerts = 20
+
+func CreateJobAlert(candidateID uuid.UUID, input models.JobAlertRequest, nextRunAt time.Time) (models.JobAlert, error) {
+	tx, err := orm.DB.Beginx()
+	if err != nil {
+		return models.JobAlert{}, fmt.Errorf("could not start transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Serializes concurrent creates so the limit holds
+	if _, err := tx.Exec(`SELECT pg_advisory_xact_lock(hashtext($1))`, "job_alerts:"+candidateID.String()); err != nil {
+		return models.JobAlert{}, fmt.Errorf("could not lock job alerts: %w", err)
+	}
+
+	var count int
+	if err := tx.Get(&count, `SELECT COUNT(*) FROM job_alerts WHERE candidate_id = $1`, candidateID); err != nil {
+		log.Printf("Error counting job alerts: %v", err)
+		return models.JobAlert{}, fmt.Errorf("could not count job alerts: %w", err)
+	}
+	if count >= MaxJobAlerts {
+		return models.JobAlert{}, fmt.Errorf("job alert limit reached")
+	}
+
+	active := true
+	if input.Active != nil {
+		active = *input.Active
+	}
+
+	var alert models.JobAlert
+	err = tx.Get(&alert, `
+		INSERT INTO job_alerts (candidate_id, name, query, work_type, job_type, experience_level, salary_range,
+		                        required_skills, frequency, active, next_run_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		RETURNING *
+	`, candidateID, input.Name, input.Query, input.WorkType, input.JobType, input.ExperienceLevel, input.SalaryRange,
+		pq.StringArray(skillsOrEmpty(input.RequiredSkills)), input.Frequency, active, nextRunAt)
+	if err != nil {
+		log.Printf("Error creating job alert: %v", err)
+		return models.JobAlert{}, fmt.Errorf("could not create job alert: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return models.JobAlert{}, fmt.Errorf("could not create job alert: %w", err)
+	}
+	return alert, nil
+}
+
+func GetJobAlerts(candidateID uuid.UUID) ([]models.JobAlert, error) {
+	alerts := []models.JobAlert{}
+	err := orm.DB.Select(&alerts, `
+		SELECT * FROM job_alerts WHERE candidate_id = $1 ORDER BY created_at
+	`, candidateID)
+	if err != nil {
+		log.Printf("Error fetching job alerts: %v", err)
+		return nil, fmt.Errorf("could not fetch job alerts: %w", err)
+	}
+	return alerts, nil
+}
+
+func GetJobAlert(alertID, candidateID uuid.UUID) (models.JobAlert, error) {
+	var alert models.JobAlert
+	err := orm.DB.Get(&alert, `
+		SELECT * FROM job_alerts WHERE id = $1 AND candidate_id = $2
+	`, alertID, candidateID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.JobAlert{}, fmt.Errorf("job alert not found")
+		}
+		log.Printf("Error fetching job alert: %v", err)
+		return models.JobAlert{}, fmt.Errorf("could not fetch job alert: %w", err)
+	}
+	return alert, nil
+}
+
+// UpdateJobAlert replaces the alert's search and schedule. A nil active keeps the current value.
+func UpdateJobAlert(alertID, candidateID uuid.UUID, input models.JobAlertRequest, nextRunAt time.Time) (models.JobAlert, error) {
+	var alert models.JobAlert
+	err := orm.DB.Get(&alert, `
+		UPDATE job_alerts SET
+			name = $3,
+			query = $4,
+			work_type = $5,
+			job_type = $6,
+			experience_level = $7,
+			salary_range = $8,
+			required_skills = $9,
+			frequency = $10,
+			active = COALESCE($11, active),
+			next_run_at = $12,
+			updated_at = NOW()
+		WHERE id = $1 AND candidate_id = $2
+		RETURNING *
+	`, alertID, candidateID, input.Name, input.Query, input.WorkType, input.JobType, input.ExperienceLevel,
+		input.SalaryRange, pq.StringArray(skillsOrEmpty(input.RequiredSkills)), input.Frequency, input.Active, nextRunAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.JobAlert{}, fmt.Errorf("job alert not found")
+		}
+		log.Printf("Error updating job alert: %v", err)
+		return models.JobAlert{}, fmt.Errorf("could not update job alert: %w", err)
+	}
+	return alert, nil
+}
+
+func DeleteJobAlert(alertID, candidateID uuid.UUID) error {
+	result, err := orm.DB.Exec(`DELETE FROM job_alerts WHERE id = $1 AND candidate_id = $2`, alertID, candidateID)
+	if err != nil {
+		log.Printf("Error deleting job alert: %v", err)
+		return fmt.Errorf("could not delete job alert: %w", err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return fmt.Errorf("job alert not found")
+	}
+	return nil
+}
+
+// ClaimDueJobAlerts leases active alerts whose next run is at or before dueBy, so only one
+// scheduler evaluates each.
+func ClaimDueJobAlerts(dueBy time.Time, limit int, lease time.Duration) ([]models.JobAlert, error) {
+	alerts := []models.JobAlert{}
+	err := orm.DB.Select(&alerts, `
+		UPDATE job_alerts
+		SET next_run_at = NOW() + $2 * INTERVAL '1 second'
+		WHERE id IN (
+			SELECT id FROM job_alerts
+			WHERE active AND next_run_at <= $3
+			ORDER BY next_run_at
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING *
+	`, limit, lease.Seconds(), dueBy)
+	if err != nil {
+		log.Printf("Error claiming job alerts: %v", err)
+		return nil, fmt.Errorf("could not claim job alerts: %w", err)
+	}
+	return alerts, nil
+}
+
+// FindJobAlertMatches returns open listings posted since the alert was saved that match its
+// filters, leaving out listings it already sent and jobs the candidate applied to.
+func FindJobAlertMatches(alert models.JobAlert, limit int) ([]models.JobListing, error) {
+	query, args := listingFilterClause(`
+		SELECT * FROM job_listings
+		WHERE closed_at IS NULL
+		AND (closes_at IS NULL OR closes_at > NOW())
+		AND created_at > ?
+		AND NOT EXISTS (
+			SELECT 1 FROM job_alert_deliveries d WHERE d.alert_id = ? AND d.job_id = job_listings.id
+		)
+		AND NOT EXISTS (
+			SELECT 1 FROM applications a WHERE a.candidate_id = ? AND a.job_id = job_listings.id
+		)
+	`, []interface{}{alert.CreatedAt, alert.ID, alert.CandidateID}, alert.Filters())
+
+	query += " ORDER BY created_at LIMIT ?"
+	args = append(args, limit)
+
+	listings := []models.JobListing{}
+	err := orm.DB.Select(&listings, orm.DB.Rebind(query), args...)
+	if err != nil {
+		log.Printf("Error matching job alert: %v", err)
+		return nil, fmt.Errorf("could not match job alert: %w", err)
+	}
+	return listings, nil
+}
+
+// DeliverJobAlert records the listings as sent and queues the digest email in one transaction,
+// then schedules the next run. Listings another run already sent are dropped from the digest;
+// the ones actually delivered are returned along with the candidate's user.
+func DeliverJobAlert(alert models.JobAlert, listings []models.JobListing, nextRunAt time.Time) ([]models.JobListing, uuid.UUID, error) {
+	tx, err := orm.DB.Beginx()
+	if err != nil {
+		return nil, uuid.Nil, fmt.Errorf("could not start transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var candidate struct {
+		UserID   *uuid.UUID `db:"user_id"`
+		FullName string     `db:"full_name"`
+	}
+	err = tx.Get(&candidate, `SELECT user_id, full_name FROM candidates WHERE id = $1`, alert.CandidateID)
+	if err != nil {
+		log.Printf("Error fetching job alert candidate: %v", err)
+		return nil, uuid.Nil, fmt.Errorf("could not fetch candidate: %w", err)
+	}
+
+	// Nobody is left to tell once the account is erased
+	if candidate.UserID == nil {
+		if _, err := tx.Exec(`UPDATE job_alerts SET active = FALSE, updated_at = NOW() WHERE id = $1`, alert.ID); err != nil {
+			return nil, uuid.Nil, fmt.Errorf("could not deactivate job alert: %w", err)
+		}
+		return nil, uuid.Nil, tx.Commit()
+	}
+
+	delivered := []models.JobListing{}
+	for _, listing := range listings {
+		result, err := tx.Exec(`
+			INSERT INTO job_alert_deliveries (alert_id, job_id) VALUES ($1, $2)
+			ON CONFLICT DO NOTHING
+		`, alert.ID, listing.ID)
+		if err != nil {
+			log.Printf("Error recording job alert delivery: %v", err)
+			return nil, uuid.Nil, fmt.Errorf("could not record job alert delivery: %w", err)
+		}
+		if rows, _ := result.RowsAffected(); rows > 0 {
+			delivered = append(delivered, listing)
+		}
+	}
+
+	if len(delivered) > 0 {
+		jobs := make([]map[string]any, 0, len(delivered))
+		for _, listing := range delivered {
+			jobs = append(jobs, map[string]any{
+				"Title":    listing.Listing_title,
+				"Location": listing.Location,
+				"WorkType": listing.Work_type,
+			})
+		}
+		err = enqueueUserEmail(tx, *candidate.UserID, models.EmailJobAlert, map[string]any{
+			"CandidateName": candidate.FullName,
+			"AlertName":     alert.Name,
+			"Count":         len(delivered),
+			"Jobs":          jobs,
+		})
+		if err != nil {
+			return nil, uuid.Nil, err
+		}
+	}
+
+	_, err = tx.Exec(`
+		UPDATE job_alerts
+		SET next_run_at = $2, last_sent_at = CASE WHEN $3 THEN NOW() ELSE last_sent_at END
+		WHERE id = $1
+	`, alert.ID, nextRunAt, len(delivered) > 0)
+	if err != nil {
+		log.Printf("Error scheduling job alert: %v", err)
+		return nil, uuid.Nil, fmt.Errorf("could not schedule job alert: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, uuid.Nil, fmt.Errorf("could not deliver job alert: %w", err)
+	}
+	return delivered, *candidate.UserID, nil
+}
+
+// skillsOrEmpty keeps a missing skill list from being stored as NULL
+func skillsOrEmpty(skills []string) []string {
+	if skills == nil {
+		return []string{}
+	}
+	return skills
+}
@@ -0,0 +1,273 @@
+package database
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hridaya14/Web-Tech-Project/internal/models"
+	"github.com/hridaya14/Web-Tech-Project/pkg/orm"
+	"github.com/lib/pq"
+)
+
+// analyticsScope restricts a query over job_listings j to the company, and to one listing if set.
+func analyticsScope(companyID uuid.UUID, r models.AnalyticsRange) (string, []interface{}) {
+	scope := "j.company_id = ?"
+	args := []interface{}{companyID}
+	if r.ListingID != nil {
+		scope += " AND j.id = ?"
+		args = append(args, *r.ListingID)
+	}
+	return scope, args
+}
+
+// GetCompanyAnalytics computes the hiring dashboard for applications and views within r.
+func GetCompanyAnalytics(companyID uuid.UUID, r models.AnalyticsRange) (models.CompanyAnalytics, error) {
+	analytics := models.CompanyAnalytics{Range: r}
+	var err error
+
+	if analytics.Listings, err = getListingAnalytics(companyID, r); err != nil {
+		return analytics, err
+	}
+	if analytics.OverTime, err = getAnalyticsOverTime(companyID, r); err != nil {
+		return analytics, err
+	}
+	if analytics.Funnel, analytics.Rejected, err = getApplicationFunnel(companyID, r); err != nil {
+		return analytics, err
+	}
+	if analytics.TimeToFirstResponse, err = getMedianHoursTo(companyID, r, ""); err != nil {
+		return analytics, err
+	}
+	if analytics.TimeToHire, err = getMedianHoursTo(companyID, r, "accepted"); err != nil {
+		return analytics, err
+	}
+	if analytics.Sources, err = getSourceAnalytics(companyID, r); err != nil {
+		return analytics, err
+	}
+	return analytics, nil
+}
+
+func getListingAnalytics(companyID uuid.UUID, r models.AnalyticsRange) ([]models.ListingAnalytics, error) {
+	scope, scopeArgs := analyticsScope(companyID, r)
+	args := append([]interface{}{r.From, r.To, r.From, r.To}, scopeArgs...)
+
+	listings := []models.ListingAnalytics{}
+	err := orm.DB.Select(&listings, orm.DB.Rebind(`
+		SELECT j.id AS listing_id, j.title, j.closed_at,
+		       COALESCE(v.impressions, 0) AS impressions,
+		       COALESCE(v.views, 0) AS views,
+		       COALESCE(v.unique_viewers, 0) AS unique_viewers,
+		       COALESCE(a.applications, 0) AS applications
+		FROM job_listings j
+		LEFT JOIN (
+			SELECT job_id,
+			       COUNT(*) FILTER (WHERE kind = 'impression') AS impressions,
+			       COUNT(*) FILTER (WHERE kind = 'detail') AS views,
+			       COUNT(DISTINCT viewer_id) FILTER (WHERE kind = 'detail') AS unique_viewers
+			FROM listing_views
+			WHERE viewed_at >= ? AND viewed_at < ?
+			GROUP BY job_id
+		) v ON v.job_id = j.id
+		LEFT JOIN (
+			SELECT job_id, COUNT(*) AS applications
+			FROM applications
+			WHERE applied_at >= ? AND applied_at < ?
+			GROUP BY job_id
+		) a ON a.job_id = j.id
+		WHERE `+scope+`
+		ORDER BY applications DESC, views DESC, j.created_at DESC
+	`), args...)
+	if err != nil {
+		log.Printf("Error fetching listing analytics: %v", err)
+		return nil, fmt.Errorf("could not fetch listing analytics: %w", err)
+	}
+
+	for i, listing := range listings {
+		if listing.UniqueViewers > 0 {
+			rate := float64(listing.Applications) / float64(listing.UniqueViewers)
+			listings[i].ConversionRate = &rate
+		}
+	}
+	return listings, nil
+}
+
+// getAnalyticsOverTime counts views and applications per interval, including empty ones.
+func getAnalyticsOverTime(companyID uuid.UUID, r models.AnalyticsRange) ([]models.AnalyticsBucket, error) {
+	scope, scopeArgs := analyticsScope(companyID, r)
+
+	args := []interface{}{r.Interval, r.From, r.To, r.Interval}
+	args = append(args, r.Interval, r.From, r.To)
+	args = append(args, scopeArgs...)
+	args = append(args, r.Interval, r.From, r.To)
+	args = append(args, scopeArgs...)
+
+	buckets := []models.AnalyticsBucket{}
+	err := orm.DB.Select(&buckets, orm.DB.Rebind(`
+		SELECT p.period, COALESCE(v.impressions, 0) AS impressions, COALESCE(v.views, 0) AS views,
+		       COALESCE(a.applications, 0) AS applications
+		FROM generate_series(date_trunc(?::text, ?::timestamp), ?::timestamp - INTERVAL '1 microsecond', ('1 ' || ?::text)::interval) AS p(period)
+		LEFT JOIN (
+			SELECT date_trunc(?::text, lv.viewed_at) AS period,
+			       COUNT(*) FILTER (WHERE lv.kind = 'impression') AS impressions,
+			       COUNT(*) FILTER (WHERE lv.kind = 'detail') AS views
+			FROM listing_views lv
+			JOIN job_listings j ON lv.job_id = j.id
+			WHERE lv.viewed_at >= ? AND lv.viewed_at < ? AND `+scope+`
+			GROUP BY 1
+		) v ON v.period = p.period
+		LEFT JOIN (
+			SELECT date_trunc(?::text, a.applied_at) AS period, COUNT(*) AS applications
+			FROM applications a
+			JOIN job_listings j ON a.job_id = j.id
+			WHERE a.applied_at >= ? AND a.applied_at < ? AND `+scope+`
+			GROUP BY 1
+		) a ON a.period = p.period
+		ORDER BY p.period
+	`), args...)
+	if err != nil {
+		log.Printf("Error fetching analytics over time: %v", err)
+		return nil, fmt.Errorf("could not fetch analytics over time: %w", err)
+	}
+	return buckets, nil
+}
+
+// getApplicationFunnel counts how far applications got. An application reached every stage up to
+// the furthest status it has held, so one rejected after an interview counts towards reviewing
+// and interview too.
+func getApplicationFunnel(companyID uuid.UUID, r models.AnalyticsRange) ([]models.FunnelStage, int, error) {
+	scope, scopeArgs := analyticsScope(companyID, r)
+	stages := pq.StringArray(models.FunnelStages)
+	args := append([]interface{}{stages, stages, r.From, r.To}, scopeArgs...)
+
+	var rows []struct {
+		Stage        int `db:"stage"`
+		Applications int `db:"applications"`
+		Rejected     int `db:"rejected"`
+	}
+	err := orm.DB.Select(&rows, orm.DB.Rebind(`
+		SELECT stage, COUNT(*) AS applications, COUNT(*) FILTER (WHERE status = 'rejected') AS rejected
+		FROM (
+			SELECT a.status::text AS status,
+			       GREATEST(
+			           COALESCE(array_position(?::text[], a.status::text), 1),
+			           COALESCE(MAX(array_position(?::text[], h.new_status)), 1)
+			       ) AS stage
+			FROM applications a
+			JOIN job_listings j ON a.job_id = j.id
+			LEFT JOIN application_status_history h ON h.application_id = a.application_id
+			WHERE a.applied_at >= ? AND a.applied_at < ? AND `+scope+`
+			GROUP BY a.application_id, a.status
+		) reached
+		GROUP BY stage
+	`), args...)
+	if err != nil {
+		log.Printf("Error fetching application funnel: %v", err)
+		return nil, 0, fmt.Errorf("could not fetch application funnel: %w", err)
+	}
+
+	// Reaching a stage means reaching every one before it
+	reachedAt := make([]int, len(models.FunnelStages))
+	rejected := 0
+	for _, row := range rows {
+		for i := 0; i < row.Stage && i < len(reachedAt); i++ {
+			reachedAt[i] += row.Applications
+		}
+		rejected += row.Rejected
+	}
+
+	funnel := make([]models.FunnelStage, 0, len(models.FunnelStages))
+	for i, status := range models.FunnelStages {
+		stage := models.FunnelStage{Status: status, Count: reachedAt[i]}
+		if i > 0 && reachedAt[i-1] > 0 {
+			rate := float64(reachedAt[i]) / float64(reachedAt[i-1])
+			stage.ConversionRate = &rate
+		}
+		funnel = append(funnel, stage)
+	}
+	return funnel, rejected, nil
+}
+
+// getMedianHoursTo measures from applying to the first status change into status, or to the
+// first status change of any kind when status is empty.
+func getMedianHoursTo(companyID uuid.UUID, r models.AnalyticsRange, status string) (models.DurationStats, error) {
+	scope, scopeArgs := analyticsScope(companyID, r)
+	args := append([]interface{}{status, status, r.From, r.To}, scopeArgs...)
+
+	var stats models.DurationStats
+	err := orm.DB.Get(&stats, orm.DB.Rebind(`
+		SELECT COUNT(*) AS count,
+		       percentile_cont(0.5) WITHIN GROUP (ORDER BY EXTRACT(EPOCH FROM (reached_at - applied_at)) / 3600) AS median_hours
+		FROM (
+			SELECT a.applied_at, MIN(h.changed_at) AS reached_at
+			FROM applications a
+			JOIN job_listings j ON a.job_id = j.id
+			JOIN application_status_history h ON h.application_id = a.application_id
+			WHERE (?::text = '' OR h.new_status = ?)
+			AND a.applied_at >= ? AND a.applied_at < ? AND `+scope+`
+			GROUP BY a.application_id, a.applied_at
+		) reached
+	`), args...)
+	if err != nil {
+		log.Printf("Error fetching median time to %q: %v", status, err)
+		return models.DurationStats{}, fmt.Errorf("could not fetch response times: %w", err)
+	}
+	return stats, nil
+}
+
+func getSourceAnalytics(companyID uuid.UUID, r models.AnalyticsRange) ([]models.SourceAnalytics, error) {
+	scope, scopeArgs := analyticsScope(companyID, r)
+	args := append([]interface{}{r.From, r.To}, scopeArgs...)
+
+	sources := []models.SourceAnalytics{}
+	err := orm.DB.Select(&sources, orm.DB.Rebind(`
+		SELECT a.source, COUNT(*) AS applications, COUNT(*) FILTER (WHERE a.status::text = 'accepted') AS hired
+		FROM applications a
+		JOIN job_listings j ON a.job_id = j.id
+		WHERE a.applied_at >= ? AND a.applied_at < ? AND `+scope+`
+		GROUP BY a.source
+		ORDER BY applications DESC
+	`), args...)
+	if err != nil {
+		log.Printf("Error fetching application sources: %v", err)
+		return nil, fmt.Errorf("could not fetch application sources: %w", err)
+	}
+	return sources, nil
+}
+
+// InsertListingViews writes a batch of views in one statement. Views already recorded for the
+// same viewer, listing, kind and bucket, by this or another API instance, are skipped.
+func InsertListingViews(views []models.ListingView) error {
+	if len(views) == 0 {
+		return nil
+	}
+
+	jobIDs := make(pq.StringArray, len(views))
+	viewerIDs := make(pq.StringArray, len(views))
+	kinds := make(pq.StringArray, len(views))
+	buckets := make(pq.StringArray, len(views))
+	viewedAt := make(pq.StringArray, len(views))
+	for i, view := range views {
+		jobIDs[i] = view.JobID.String()
+		viewerIDs[i] = view.ViewerID.String()
+		kinds[i] = view.Kind
+		buckets[i] = view.Bucket.UTC().Format(time.RFC3339Nano)
+		viewedAt[i] = view.ViewedAt.UTC().Format(time.RFC3339Nano)
+	}
+
+	// Listings deleted since the view are dropped rather than failing the batch
+	_, err := orm.DB.Exec(`
+		INSERT INTO listing_views (job_id, viewer_id, kind, bucket, viewed_at)
+		SELECT v.job_id, v.viewer_id, v.kind, v.bucket, v.viewed_at
+		FROM unnest($1::uuid[], $2::uuid[], $3::text[], $4::timestamp[], $5::timestamp[])
+		     AS v(job_id, viewer_id, kind, bucket, viewed_at)
+		JOIN job_listings j ON j.id = v.job_id
+		JOIN users u ON u.id = v.viewer_id
+		ON CONFLICT (job_id, viewer_id, kind, bucket) DO NOTHING
+	`, jobIDs, viewerIDs, kinds, buckets, viewedAt)
+	if err != nil {
+		log.Printf("Error recording listing views: %v", err)
+		return fmt.Errorf("could not record listing views: %w", err)
+	}
+	return nil
+}
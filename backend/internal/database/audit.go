@@ -0,0 +1,77 @@
+package database
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hridaya14/Web-Tech-Project/internal/models"
+	"github.com/hridaya14/Web-Tech-Project/pkg/orm"
+)
+
+func InsertAuditEvent(event models.AuditEvent) error {
+	if len(event.Metadata) == 0 {
+		event.Metadata = []byte("{}")
+	}
+
+	_, err := orm.DB.Exec(`
+		INSERT INTO audit_events (actor_id, actor_role, action, target_type, target_id, ip_address,
+		                          user_agent, method, route, status_code, before, after, metadata)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+	`, event.ActorID, event.ActorRole, event.Action, event.TargetType, event.TargetID, event.IPAddress,
+		event.UserAgent, event.Method, event.Route, event.StatusCode, nullableJSON(event.Before), nullableJSON(event.After), event.Metadata)
+	if err != nil {
+		log.Printf("Error recording audit event: %v", err)
+		return fmt.Errorf("could not record audit event: %w", err)
+	}
+	return nil
+}
+
+// QueryAuditEvents lists audit events matching filters, newest first
+func QueryAuditEvents(filters models.AuditFilters) ([]models.AuditEvent, error) {
+	query := `SELECT * FROM audit_events WHERE TRUE`
+	args := []interface{}{}
+
+	if filters.ActorID != nil {
+		query += ` AND actor_id = ?`
+		args = append(args, *filters.ActorID)
+	}
+	if filters.Action != "" {
+		query += ` AND action = ?`
+		args = append(args, filters.Action)
+	}
+	if filters.TargetType != "" {
+		query += ` AND target_type = ?`
+		args = append(args, filters.TargetType)
+	}
+	if filters.TargetID != "" {
+		query += ` AND target_id = ?`
+		args = append(args, filters.TargetID)
+	}
+	if filters.From != nil {
+		query += ` AND occurred_at >= ?`
+		args = append(args, *filters.From)
+	}
+	if filters.To != nil {
+		query += ` AND occurred_at < ?`
+		args = append(args, *filters.To)
+	}
+
+	query += ` ORDER BY occurred_at DESC, id LIMIT ? OFFSET ?`
+	args = append(args, filters.Limit, filters.Offset)
+
+	events := []models.AuditEvent{}
+	err := orm.DB.Select(&events, orm.DB.Rebind(query), args...)
+	if err != nil {
+		log.Printf("Error querying audit events: %v", err)
+		return nil, fmt.Errorf("could not query audit events: %w", err)
+	}
+	return events, nil
+}
+
+// nullableJSON stores empty documents as NULL
+func nullableJSON(doc []byte) interface{} {
+	if len(doc) == 0 {
+		return nil
+	}
+	return doc
+}
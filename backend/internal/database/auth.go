@@ -10,26 +10,44 @@ import (
 	"log"
 )
 
-// CreateUser inserts a new user into the database
+// CreateUser inserts a new user into the database and queues their welcome email
 func CreateUser(user *models.User) (uuid.UUID, error) {
 	query := `
         INSERT INTO users (username, email, password_hash, role)
         VALUES ($1, $2, $3, $4)
         RETURNING id
     `
+	tx, err := orm.DB.Beginx()
+	if err != nil {
+		return uuid.Nil, err
+	}
+	defer tx.Rollback()
+
 	var id uuid.UUID
-	err := orm.DB.Get(&id, query, user.Username, user.Email, user.PasswordHash, user.Role)
+	err = tx.Get(&id, query, user.Username, user.Email, user.PasswordHash, user.Role)
 
 	if err != nil {
 		return uuid.Nil, err
 	}
 
+	err = enqueueUserEmail(tx, id, models.EmailWelcome, map[string]any{
+		"Username": user.Username,
+		"Role":     user.Role,
+	})
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return uuid.Nil, err
+	}
+
 	return id, nil
 }
 
 func GetUserByEmail(email string) (*models.User, error) {
 	var user models.User
-	query := `SELECT * FROM users WHERE email = $1`
+	query := `SELECT id, username, email, password_hash, created_at, role, onboarding_status FROM users WHERE email = $1`
 	err := orm.DB.Get(&user, query, email)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -40,6 +58,19 @@ func GetUserByEmail(email string) (*models.User, error) {
 	return &user, nil
 }
 
+func GetUserByID(userID uuid.UUID) (*models.User, error) {
+	var user models.User
+	query := `SELECT id, username, email, password_hash, created_at, role, onboarding_status FROM users WHERE id = $1`
+	err := orm.DB.Get(&user, query, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("user not found")
+		}
+		return nil, err
+	}
+	return &user, nil
+}
+
 // CheckUserExists checks if a user with the given email already exists
 func CheckUserExists(email string) (bool, error) {
 	var exists bool
@@ -102,7 +133,7 @@ func GetUserRelatedID(userID uuid.UUID) (any, error) {
 		return jobSeekerID, nil
 	} else if role == "company" {
 		var companyID uuid.UUID
-		query = "SELECT id FROM companies WHERE user_id = $1"
+		query = "SELECT company_id FROM company_members WHERE user_id = $1"
 		err := orm.DB.Get(&companyID, query, userID)
 		if err != nil {
 			log.Printf("Error fetching company ID: %v", err)
@@ -113,3 +144,13 @@ func GetUserRelatedID(userID uuid.UUID) (any, error) {
 
 	return nil, fmt.Errorf("unknown role: %s", role)
 }
+
+// GetUserRole returns the role of a user
+func GetUserRole(userID uuid.UUID) (string, error) {
+	var role string
+	err := orm.DB.Get(&role, `SELECT role FROM users WHERE id = $1`, userID)
+	if err != nil {
+		return "", fmt.Errorf("could not fetch user role: %w", err)
+	}
+	return role, nil
+}
@@ -1,6 +1,8 @@
 package database
 
 import (
+	"database/sql"
+	"errors"
 	"fmt"
 	"github.com/google/uuid"
 	"github.com/hridaya14/Web-Tech-Project/internal/models"
@@ -10,65 +12,152 @@ import (
 	"log"
 )
 
-func GetJobListings(filters models.JobListingFilters) ([]models.JobListing, error) {
-	var listings []models.JobListing
+// GetJobListings returns the open listings matching filters, flagged with whether the candidate
+// saved or applied to each. Pass uuid.Nil for viewers who are not candidates.
+func GetJobListings(filters models.JobListingFilters, candidateID uuid.UUID) ([]models.CandidateJobListing, error) {
+	listings := []models.CandidateJobListing{}
 
-	baseQuery := `
-		SELECT * FROM job_listings
-		WHERE 1=1
-	`
-	args := []interface{}{}
-	argIndex := 1
+	baseQuery, args := listingFilterClause(`
+		SELECT job_listings.*,
+			EXISTS (
+				SELECT 1 FROM saved_jobs s WHERE s.candidate_id = ? AND s.job_id = job_listings.id
+			) AS saved,
+			EXISTS (
+				SELECT 1 FROM applications a WHERE a.candidate_id = ? AND a.job_id = job_listings.id
+			) AS applied
+		FROM job_listings
+		WHERE closed_at IS NULL AND (closes_at IS NULL OR closes_at > NOW())
+	`, []interface{}{candidateID, candidateID}, filters)
+
+	baseQuery += " ORDER BY created_at DESC"
+
+	err := orm.DB.Select(&listings, orm.DB.Rebind(baseQuery), args...)
+	if err != nil {
+		log.Printf("Error fetching listings: %v", err)
+		return nil, fmt.Errorf("could not fetch listings: %w", err)
+	}
+
+	return listings, nil
+}
+
+// listingFilterClause appends the conditions for filters to a query over job_listings,
+// using ? placeholders. Job alerts share it so they match exactly what the search shows.
+func listingFilterClause(query string, args []interface{}, filters models.JobListingFilters) (string, []interface{}) {
+	if filters.Query != "" {
+		query += " AND (title ILIKE '%' || ? || '%' OR description ILIKE '%' || ? || '%')"
+		args = append(args, filters.Query, filters.Query)
+	}
 
 	if filters.WorkType != "" {
-		baseQuery += fmt.Sprintf(" AND work_type = $%d", argIndex)
+		query += " AND work_type = ?"
 		args = append(args, filters.WorkType)
-		argIndex++
 	}
 
 	if filters.JobType != "" {
-		baseQuery += fmt.Sprintf(" AND job_type = $%d", argIndex)
+		query += " AND job_type = ?"
 		args = append(args, filters.JobType)
-		argIndex++
 	}
 
 	if filters.ExperienceLevel != "" {
-		baseQuery += fmt.Sprintf(" AND experience_level = $%d", argIndex)
+		query += " AND experience_level = ?"
 		args = append(args, filters.ExperienceLevel)
-		argIndex++
 	}
 
 	if filters.SalaryRange != "" {
-		baseQuery += fmt.Sprintf(" AND salary_range = $%d", argIndex)
+		query += " AND salary_range = ?"
 		args = append(args, filters.SalaryRange)
-		argIndex++
 	}
 
 	if len(filters.RequiredSkills) > 0 {
-		baseQuery += fmt.Sprintf(" AND required_skills && $%d", argIndex)
+		query += " AND required_skills && ?"
 		args = append(args, pq.StringArray(filters.RequiredSkills))
-		argIndex++
 	}
 
-	baseQuery += " ORDER BY created_at DESC"
+	return query, args
+}
 
-	err := orm.DB.Select(&listings, baseQuery, args...)
+// GetUnappliedOpenListings returns the open listings the candidate has not applied to yet.
+func GetUnappliedOpenListings(candidateID uuid.UUID) ([]models.JobListing, error) {
+	listings := []models.JobListing{}
+	err := orm.DB.Select(&listings, `
+		SELECT * FROM job_listings j
+		WHERE j.closed_at IS NULL AND (j.closes_at IS NULL OR j.closes_at > NOW())
+		AND NOT EXISTS (
+			SELECT 1 FROM applications a WHERE a.job_id = j.id AND a.candidate_id = $1
+		)
+		ORDER BY j.created_at DESC
+	`, candidateID)
 	if err != nil {
-		log.Printf("Error fetching listings: %v", err)
+		log.Printf("Error fetching open listings: %v", err)
 		return nil, fmt.Errorf("could not fetch listings: %w", err)
 	}
+	return listings, nil
+}
 
+// GetAppliedListings returns the listings the candidate has applied to, most recent application first.
+func GetAppliedListings(candidateID uuid.UUID) ([]models.JobListing, error) {
+	listings := []models.JobListing{}
+	err := orm.DB.Select(&listings, `
+		SELECT j.* FROM job_listings j
+		JOIN applications a ON a.job_id = j.id
+		WHERE a.candidate_id = $1
+		ORDER BY a.applied_at DESC
+	`, candidateID)
+	if err != nil {
+		log.Printf("Error fetching applied listings: %v", err)
+		return nil, fmt.Errorf("could not fetch listings: %w", err)
+	}
 	return listings, nil
 }
 
-func CreateApplication(candidateID uuid.UUID, jobID uuid.UUID) error {
+// CreateApplication stores the application and queues the confirmation and new applicant emails with it.
+// Applying to a listing the company invited the candidate to always counts as an invitation.
+func CreateApplication(candidateID uuid.UUID, jobID uuid.UUID, source string) (uuid.UUID, error) {
 
 	query := `
-        INSERT INTO applications (candidate_id, job_id)
-        VALUES ($1, $2)
+        INSERT INTO applications (candidate_id, job_id, source)
+        SELECT $1, id, CASE
+            WHEN EXISTS (SELECT 1 FROM talent_invitations WHERE candidate_id = $1 AND job_id = $2) THEN 'invitation'
+            ELSE $3
+        END
+        FROM job_listings WHERE id = $2 AND closed_at IS NULL AND (closes_at IS NULL OR closes_at > NOW())
+        RETURNING application_id
     `
-	_, err := orm.DB.Exec(query, candidateID, jobID)
-	return err
+	tx, err := orm.DB.Beginx()
+	if err != nil {
+		return uuid.Nil, err
+	}
+	defer tx.Rollback()
+
+	var applicationID uuid.UUID
+	err = tx.Get(&applicationID, query, candidateID, jobID, source)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return uuid.Nil, fmt.Errorf("job listing is closed")
+		}
+		return uuid.Nil, err
+	}
+
+	data, candidateUserID, companyID, err := applicationEmailData(tx, applicationID)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if err := enqueueUserEmail(tx, candidateUserID, models.EmailApplicationSubmitted, data); err != nil {
+		return uuid.Nil, err
+	}
+	if err := enqueueCompanyEmail(tx, companyID, models.EmailApplicationReceived, data); err != nil {
+		return uuid.Nil, err
+	}
+
+	event, err := applicationWebhookData(tx, applicationID)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if err := enqueueWebhookEvent(tx, companyID, models.EventApplicationCreated, event); err != nil {
+		return uuid.Nil, err
+	}
+
+	return applicationID, tx.Commit()
 
 }
 
@@ -94,7 +183,8 @@ func GetApplicationsByJobID(jobID uuid.UUID) ([]models.Application, error) {
 	return applications, err
 }
 
-func DeleteApplicationByID(applicationID, candidateID uuid.UUID) error {
+// DeleteApplicationByID deletes a candidate's own application and returns it as it was
+func DeleteApplicationByID(applicationID, candidateID uuid.UUID) (models.Application, error) {
 	var exists bool
 	queryCheck := `
         SELECT EXISTS (
@@ -105,32 +195,29 @@ func DeleteApplicationByID(applicationID, candidateID uuid.UUID) error {
 	err := orm.DB.Get(&exists, queryCheck, applicationID, candidateID)
 	if err != nil {
 		log.Printf("Error checking application ownership: %v", err)
-		return fmt.Errorf("could not verify application ownership: %w", err)
+		return models.Application{}, fmt.Errorf("could not verify application ownership: %w", err)
 	}
 	if !exists {
-		return fmt.Errorf("unauthorized: candidate does not own this application or it does not exist")
+		return models.Application{}, fmt.Errorf("unauthorized: candidate does not own this application or it does not exist")
 	}
 
-	queryDelete := `DELETE FROM applications WHERE application_id = $1`
-	result, err := orm.DB.Exec(queryDelete, applicationID)
-	if err != nil {
+	var application models.Application
+	queryDelete := `
+        DELETE FROM applications WHERE application_id = $1
+        RETURNING application_id, candidate_id, job_id, status, applied_at
+    `
+	err = orm.DB.Get(&application, queryDelete, applicationID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.Application{}, fmt.Errorf("application not found or already deleted")
+	} else if err != nil {
 		log.Printf("Error deleting application: %v", err)
-		return fmt.Errorf("could not delete application: %w", err)
+		return models.Application{}, fmt.Errorf("could not delete application: %w", err)
 	}
 
-	rowsAffected, err := result.RowsAffected()
-	if err != nil {
-		log.Printf("Error checking rows affected: %v", err)
-		return fmt.Errorf("could not verify application deletion: %w", err)
-	}
-	if rowsAffected == 0 {
-		return fmt.Errorf("application not found or already deleted")
-	}
-
-	return nil
+	return application, nil
 }
 
-func GetApplicantPoolsByCompanyID(companyID uuid.UUID) ([]models.ApplicantPool, error) {
+func GetApplicantPoolsByCompanyID(companyID uuid.UUID, filters models.ApplicantFilters) ([]models.ApplicantPool, error) {
 	jobIDs := []uuid.UUID{}
 	err := orm.DB.Select(&jobIDs, `
         SELECT id FROM job_listings
@@ -149,7 +236,7 @@ func GetApplicantPoolsByCompanyID(companyID uuid.UUID) ([]models.ApplicantPool,
 		uuidInterfaces[i] = id
 	}
 
-	query, args, err := sqlx.In(`
+	baseQuery := `
 		SELECT 
 			a.application_id,
 			a.candidate_id,
@@ -157,11 +244,44 @@ func GetApplicantPoolsByCompanyID(companyID uuid.UUID) ([]models.ApplicantPool,
 			a.status,
 			a.applied_at,
 			c.full_name AS candidate_name,
-			c.skills AS candidate_skills
+			c.skills AS candidate_skills,
+			s.score AS match_score,
+			s.explanation AS match_explanation
 		FROM applications a
 		JOIN candidates c ON a.candidate_id = c.id
+		LEFT JOIN application_scores s ON s.application_id = a.application_id
 		WHERE a.job_id IN (?)
-	`, uuidInterfaces)
+	`
+	args := []interface{}{uuidInterfaces}
+
+	if filters.Status != "" {
+		baseQuery += " AND a.status = ?"
+		args = append(args, filters.Status)
+	}
+
+	for _, tag := range filters.Tags {
+		baseQuery += ` AND EXISTS (
+			SELECT 1 FROM application_tags t
+			WHERE t.application_id = a.application_id AND t.company_id = ? AND t.tag = ?
+		)`
+		args = append(args, companyID, tag)
+	}
+
+	if filters.MinRating > 0 {
+		baseQuery += ` AND (
+			SELECT AVG(r.rating) FROM application_ratings r
+			WHERE r.application_id = a.application_id AND r.company_id = ?
+		) >= ?`
+		args = append(args, companyID, filters.MinRating)
+	}
+
+	if filters.Sort == "score" {
+		baseQuery += " ORDER BY s.score DESC NULLS LAST, a.applied_at"
+	} else {
+		baseQuery += " ORDER BY a.applied_at"
+	}
+
+	query, args, err := sqlx.In(baseQuery, args...)
 	if err != nil {
 		return nil, fmt.Errorf("error building query: %w", err)
 	}
@@ -173,9 +293,9 @@ func GetApplicantPoolsByCompanyID(companyID uuid.UUID) ([]models.ApplicantPool,
 		return nil, fmt.Errorf("error fetching applications with candidate info: %w", err)
 	}
 
-	poolMap := make(map[uuid.UUID][]models.AppWithCandidate)
+	apps := make([]models.AppWithCandidate, 0, len(rawApps))
 	for _, extApp := range rawApps {
-		app := models.AppWithCandidate{
+		apps = append(apps, models.AppWithCandidate{
 			ApplicationID:   extApp.ApplicationID,
 			CandidateID:     extApp.CandidateID,
 			JobID:           extApp.JobID,
@@ -183,8 +303,19 @@ func GetApplicantPoolsByCompanyID(companyID uuid.UUID) ([]models.ApplicantPool,
 			AppliedAt:       extApp.AppliedAt,
 			CandidateName:   extApp.CandidateName,
 			CandidateSkills: extApp.CandidateSkills,
-		}
-		poolMap[extApp.JobID] = append(poolMap[extApp.JobID], app)
+
+			MatchScore:       extApp.MatchScore,
+			MatchExplanation: extApp.MatchExplanation,
+		})
+	}
+
+	if err := attachReviews(apps, companyID); err != nil {
+		return nil, err
+	}
+
+	poolMap := make(map[uuid.UUID][]models.AppWithCandidate)
+	for _, app := range apps {
+		poolMap[app.JobID] = append(poolMap[app.JobID], app)
 	}
 
 	var pools []models.ApplicantPool
@@ -197,3 +328,71 @@ func GetApplicantPoolsByCompanyID(companyID uuid.UUID) ([]models.ApplicantPool,
 
 	return pools, nil
 }
+
+// UpdateApplicationStatus moves an application of the company to a new status and records the change.
+// It returns the previous status.
+func UpdateApplicationStatus(applicationID, companyID, changedBy uuid.UUID, status string) (string, error) {
+	tx, err := orm.DB.Beginx()
+	if err != nil {
+		return "", fmt.Errorf("could not start transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var oldStatus string
+	err = tx.Get(&oldStatus, `
+		SELECT a.status FROM applications a
+		JOIN job_listings j ON a.job_id = j.id
+		WHERE a.application_id = $1 AND j.company_id = $2
+		FOR UPDATE OF a
+	`, applicationID, companyID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return "", fmt.Errorf("application not found")
+		}
+		log.Printf("Error fetching application status: %v", err)
+		return "", fmt.Errorf("could not fetch application: %w", err)
+	}
+
+	if oldStatus == status {
+		return oldStatus, nil
+	}
+
+	_, err = tx.Exec(`UPDATE applications SET status = $1 WHERE application_id = $2`, status, applicationID)
+	if err != nil {
+		log.Printf("Error updating application status: %v", err)
+		return "", fmt.Errorf("could not update application status: %w", err)
+	}
+
+	_, err = tx.Exec(`
+		INSERT INTO application_status_history (application_id, old_status, new_status, changed_by)
+		VALUES ($1, $2, $3, $4)
+	`, applicationID, oldStatus, status, changedBy)
+	if err != nil {
+		log.Printf("Error recording status history: %v", err)
+		return "", fmt.Errorf("could not record status change: %w", err)
+	}
+
+	data, candidateUserID, _, err := applicationEmailData(tx, applicationID)
+	if err != nil {
+		return "", err
+	}
+	data["OldStatus"] = oldStatus
+	data["Status"] = status
+	if err := enqueueUserEmail(tx, candidateUserID, models.EmailApplicationStatusChanged, data); err != nil {
+		return "", err
+	}
+
+	event, err := applicationWebhookData(tx, applicationID)
+	if err != nil {
+		return "", err
+	}
+	event["old_status"] = oldStatus
+	if err := enqueueWebhookEvent(tx, companyID, models.EventApplicationStatusChanged, event); err != nil {
+		return "", err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return "", fmt.Errorf("could not update application status: %w", err)
+	}
+	return oldStatus, nil
+}
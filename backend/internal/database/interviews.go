@@ -0,0 +1,379 @@
+package database
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hridaya14/Web-Tech-Project/internal/models"
+	"github.com/hridaya14/Web-Tech-Project/pkg/orm"
+	"github.com/jmoiron/sqlx"
+)
+
+// CreateInterview stores an interview proposal with its candidate slots and interviewers.
+// Slots that overlap a scheduled interview of any interviewer are reported as conflicts and nothing is stored.
+func CreateInterview(interview models.Interview, interviewerIDs []uuid.UUID, slots []time.Time) (models.Interview, []models.InterviewConflict, error) {
+	tx, err := orm.DB.Beginx()
+	if err != nil {
+		return models.Interview{}, nil, fmt.Errorf("could not start transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var status string
+	err = tx.Get(&status, `
+		SELECT a.status FROM applications a
+		JOIN job_listings j ON a.job_id = j.id
+		WHERE a.application_id = $1 AND j.company_id = $2
+	`, interview.ApplicationID, interview.CompanyID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.Interview{}, nil, fmt.Errorf("application not found")
+		}
+		return models.Interview{}, nil, fmt.Errorf("could not fetch application: %w", err)
+	}
+	if status != models.StatusInterview {
+		return models.Interview{}, nil, fmt.Errorf("application is not in interview stage")
+	}
+
+	query, args, err := sqlx.In(`
+		SELECT COUNT(*) FROM company_members
+		WHERE company_id = ? AND user_id IN (?)
+	`, interview.CompanyID, interviewerIDs)
+	if err != nil {
+		return models.Interview{}, nil, fmt.Errorf("error building query: %w", err)
+	}
+	var memberCount int
+	if err := tx.Get(&memberCount, tx.Rebind(query), args...); err != nil {
+		return models.Interview{}, nil, fmt.Errorf("could not verify interviewers: %w", err)
+	}
+	if memberCount != len(interviewerIDs) {
+		return models.Interview{}, nil, fmt.Errorf("interviewer is not a company member")
+	}
+
+	duration := time.Duration(interview.DurationMinutes) * time.Minute
+	var conflicts []models.InterviewConflict
+	for _, start := range slots {
+		found, err := findInterviewerConflicts(tx, interviewerIDs, start, start.Add(duration), uuid.Nil)
+		if err != nil {
+			return models.Interview{}, nil, err
+		}
+		conflicts = append(conflicts, found...)
+	}
+	if len(conflicts) > 0 {
+		return models.Interview{}, conflicts, fmt.Errorf("interviewer conflict")
+	}
+
+	var created models.Interview
+	err = tx.Get(&created, `
+		INSERT INTO interviews (application_id, company_id, title, location, video_link, duration_minutes, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING *
+	`, interview.ApplicationID, interview.CompanyID, interview.Title, interview.Location,
+		interview.VideoLink, interview.DurationMinutes, interview.CreatedBy)
+	if err != nil {
+		log.Printf("Error creating interview: %v", err)
+		return models.Interview{}, nil, fmt.Errorf("could not create interview: %w", err)
+	}
+
+	for _, start := range slots {
+		_, err := tx.Exec(`
+			INSERT INTO interview_slots (interview_id, starts_at, ends_at)
+			VALUES ($1, $2, $3)
+			ON CONFLICT (interview_id, starts_at) DO NOTHING
+		`, created.ID, start, start.Add(duration))
+		if err != nil {
+			log.Printf("Error creating interview slot: %v", err)
+			return models.Interview{}, nil, fmt.Errorf("could not create interview slot: %w", err)
+		}
+	}
+
+	for _, userID := range interviewerIDs {
+		_, err := tx.Exec(`
+			INSERT INTO interview_interviewers (interview_id, user_id) VALUES ($1, $2)
+			ON CONFLICT DO NOTHING
+		`, created.ID, userID)
+		if err != nil {
+			log.Printf("Error adding interviewer: %v", err)
+			return models.Interview{}, nil, fmt.Errorf("could not add interviewer: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return models.Interview{}, nil, fmt.Errorf("could not create interview: %w", err)
+	}
+
+	full, err := GetInterviewByID(created.ID)
+	return full, nil, err
+}
+
+// findInterviewerConflicts returns scheduled interviews of the given users overlapping [start, end).
+func findInterviewerConflicts(tx *sqlx.Tx, userIDs []uuid.UUID, start, end time.Time, excludeID uuid.UUID) ([]models.InterviewConflict, error) {
+	query, args, err := sqlx.In(`
+		SELECT ii.user_id, i.id AS interview_id, i.starts_at, i.ends_at
+		FROM interviews i
+		JOIN interview_interviewers ii ON ii.interview_id = i.id
+		WHERE i.status = 'scheduled'
+		  AND ii.user_id IN (?)
+		  AND i.starts_at < ? AND i.ends_at > ?
+		  AND i.id <> ?
+	`, userIDs, end, start, excludeID)
+	if err != nil {
+		return nil, fmt.Errorf("error building query: %w", err)
+	}
+
+	var conflicts []models.InterviewConflict
+	if err := tx.Select(&conflicts, tx.Rebind(query), args...); err != nil {
+		log.Printf("Error checking interviewer conflicts: %v", err)
+		return nil, fmt.Errorf("could not check interviewer availability: %w", err)
+	}
+	return conflicts, nil
+}
+
+// SelectInterviewSlot books the slot the candidate picked, provided every interviewer is still free.
+func SelectInterviewSlot(interviewID, candidateID, slotID uuid.UUID) (models.Interview, []models.InterviewConflict, error) {
+	tx, err := orm.DB.Beginx()
+	if err != nil {
+		return models.Interview{}, nil, fmt.Errorf("could not start transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var interview models.Interview
+	err = tx.Get(&interview, `
+		SELECT i.* FROM interviews i
+		JOIN applications a ON i.application_id = a.application_id
+		WHERE i.id = $1 AND a.candidate_id = $2
+		FOR UPDATE OF i
+	`, interviewID, candidateID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.Interview{}, nil, fmt.Errorf("interview not found")
+		}
+		return models.Interview{}, nil, fmt.Errorf("could not fetch interview: %w", err)
+	}
+	if interview.Status != models.InterviewProposed {
+		return models.Interview{}, nil, fmt.Errorf("interview is not awaiting a slot")
+	}
+
+	var slot models.InterviewSlot
+	err = tx.Get(&slot, `SELECT * FROM interview_slots WHERE id = $1 AND interview_id = $2`, slotID, interviewID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.Interview{}, nil, fmt.Errorf("slot not found")
+		}
+		return models.Interview{}, nil, fmt.Errorf("could not fetch slot: %w", err)
+	}
+	if slot.StartsAt.Before(time.Now()) {
+		return models.Interview{}, nil, fmt.Errorf("slot is in the past")
+	}
+
+	var interviewerIDs []uuid.UUID
+	err = tx.Select(&interviewerIDs, `SELECT user_id FROM interview_interviewers WHERE interview_id = $1`, interviewID)
+	if err != nil {
+		return models.Interview{}, nil, fmt.Errorf("could not fetch interviewers: %w", err)
+	}
+
+	if len(interviewerIDs) > 0 {
+		// Serialize bookings per interviewer so two candidates cannot grab overlapping times
+		sort.Slice(interviewerIDs, func(i, j int) bool { return interviewerIDs[i].String() < interviewerIDs[j].String() })
+		for _, id := range interviewerIDs {
+			if _, err := tx.Exec(`SELECT pg_advisory_xact_lock(hashtext($1))`, id.String()); err != nil {
+				return models.Interview{}, nil, fmt.Errorf("could not lock interviewer calendar: %w", err)
+			}
+		}
+
+		conflicts, err := findInterviewerConflicts(tx, interviewerIDs, slot.StartsAt, slot.EndsAt, interviewID)
+		if err != nil {
+			return models.Interview{}, nil, err
+		}
+		if len(conflicts) > 0 {
+			return models.Interview{}, conflicts, fmt.Errorf("interviewer conflict")
+		}
+	}
+
+	_, err = tx.Exec(`
+		UPDATE interviews
+		SET status = 'scheduled', starts_at = $1, ends_at = $2, sequence = sequence + 1, updated_at = NOW()
+		WHERE id = $3
+	`, slot.StartsAt, slot.EndsAt, interviewID)
+	if err != nil {
+		log.Printf("Error scheduling interview: %v", err)
+		return models.Interview{}, nil, fmt.Errorf("could not schedule interview: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return models.Interview{}, nil, fmt.Errorf("could not schedule interview: %w", err)
+	}
+
+	scheduled, err := GetInterviewByID(interviewID)
+	return scheduled, nil, err
+}
+
+func CancelInterview(interviewID, companyID uuid.UUID) (models.Interview, error) {
+	result, err := orm.DB.Exec(`
+		UPDATE interviews
+		SET status = 'cancelled', sequence = sequence + 1, updated_at = NOW()
+		WHERE id = $1 AND company_id = $2 AND status IN ('proposed', 'scheduled')
+	`, interviewID, companyID)
+	if err != nil {
+		log.Printf("Error cancelling interview: %v", err)
+		return models.Interview{}, fmt.Errorf("could not cancel interview: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return models.Interview{}, fmt.Errorf("could not verify interview cancellation: %w", err)
+	}
+	if rowsAffected == 0 {
+		return models.Interview{}, fmt.Errorf("interview not found")
+	}
+
+	return GetInterviewByID(interviewID)
+}
+
+func GetInterviewByID(interviewID uuid.UUID) (models.Interview, error) {
+	var interview models.Interview
+	err := orm.DB.Get(&interview, `SELECT * FROM interviews WHERE id = $1`, interviewID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.Interview{}, fmt.Errorf("interview not found")
+		}
+		log.Printf("Error fetching interview: %v", err)
+		return models.Interview{}, fmt.Errorf("could not fetch interview: %w", err)
+	}
+
+	interviews := []models.Interview{interview}
+	if err := attachInterviewDetails(interviews); err != nil {
+		return models.Interview{}, err
+	}
+	return interviews[0], nil
+}
+
+func GetInterviewsByCompanyID(companyID uuid.UUID) ([]models.Interview, error) {
+	return selectInterviews(`
+		SELECT * FROM interviews WHERE company_id = $1
+		ORDER BY COALESCE(starts_at, created_at) DESC
+	`, companyID)
+}
+
+func GetInterviewsByCandidateID(candidateID uuid.UUID) ([]models.Interview, error) {
+	return selectInterviews(`
+		SELECT i.* FROM interviews i
+		JOIN applications a ON i.application_id = a.application_id
+		WHERE a.candidate_id = $1
+		ORDER BY COALESCE(i.starts_at, i.created_at) DESC
+	`, candidateID)
+}
+
+// GetInterviewsByInterviewer returns every interview the company user sits on.
+func GetInterviewsByInterviewer(userID uuid.UUID) ([]models.Interview, error) {
+	return selectInterviews(`
+		SELECT i.* FROM interviews i
+		JOIN interview_interviewers ii ON ii.interview_id = i.id
+		WHERE ii.user_id = $1
+		ORDER BY COALESCE(i.starts_at, i.created_at) DESC
+	`, userID)
+}
+
+func selectInterviews(query string, args ...interface{}) ([]models.Interview, error) {
+	interviews := []models.Interview{}
+	if err := orm.DB.Select(&interviews, query, args...); err != nil {
+		log.Printf("Error fetching interviews: %v", err)
+		return nil, fmt.Errorf("could not fetch interviews: %w", err)
+	}
+	if err := attachInterviewDetails(interviews); err != nil {
+		return nil, err
+	}
+	return interviews, nil
+}
+
+// attachInterviewDetails loads slots and interviewers for the given interviews.
+func attachInterviewDetails(interviews []models.Interview) error {
+	if len(interviews) == 0 {
+		return nil
+	}
+
+	ids := make([]interface{}, len(interviews))
+	index := make(map[uuid.UUID]int, len(interviews))
+	for i, interview := range interviews {
+		ids[i] = interview.ID
+		index[interview.ID] = i
+		interviews[i].Slots = []models.InterviewSlot{}
+		interviews[i].Interviewers = []models.InterviewParticipant{}
+	}
+
+	var slots []models.InterviewSlot
+	if err := selectIn(&slots, `
+		SELECT * FROM interview_slots WHERE interview_id IN (?) ORDER BY starts_at
+	`, ids); err != nil {
+		return fmt.Errorf("error fetching interview slots: %w", err)
+	}
+	for _, s := range slots {
+		i := index[s.InterviewID]
+		interviews[i].Slots = append(interviews[i].Slots, s)
+	}
+
+	var interviewers []models.InterviewParticipant
+	if err := selectIn(&interviewers, `
+		SELECT ii.interview_id, u.id AS user_id, u.username AS name, u.email
+		FROM interview_interviewers ii
+		JOIN users u ON ii.user_id = u.id
+		WHERE ii.interview_id IN (?)
+	`, ids); err != nil {
+		return fmt.Errorf("error fetching interviewers: %w", err)
+	}
+	for _, p := range interviewers {
+		i := index[p.InterviewID]
+		interviews[i].Interviewers = append(interviews[i].Interviewers, p)
+	}
+
+	return nil
+}
+
+func GetInterviewParties(interviewID uuid.UUID) (models.InterviewParties, error) {
+	var parties models.InterviewParties
+	err := orm.DB.Get(&parties, `
+		SELECT c.id AS candidate_id, u.id AS candidate_user_id, c.full_name AS candidate_name,
+		       u.email AS candidate_email, co.company_name, j.title AS job_title
+		FROM interviews i
+		JOIN applications a ON i.application_id = a.application_id
+		JOIN candidates c ON a.candidate_id = c.id
+		JOIN users u ON c.user_id = u.id
+		JOIN job_listings j ON a.job_id = j.id
+		JOIN companies co ON i.company_id = co.id
+		WHERE i.id = $1
+	`, interviewID)
+	if err != nil {
+		log.Printf("Error fetching interview parties: %v", err)
+		return models.InterviewParties{}, fmt.Errorf("could not fetch interview parties: %w", err)
+	}
+	return parties, nil
+}
+
+// SetCalendarFeedToken replaces the user's calendar feed token hash.
+func SetCalendarFeedToken(userID uuid.UUID, tokenHash string) error {
+	_, err := orm.DB.Exec(`
+		INSERT INTO calendar_feed_tokens (user_id, token_hash) VALUES ($1, $2)
+		ON CONFLICT (user_id) DO UPDATE SET token_hash = EXCLUDED.token_hash, created_at = NOW()
+	`, userID, tokenHash)
+	if err != nil {
+		log.Printf("Error saving calendar feed token: %v", err)
+		return fmt.Errorf("could not save calendar feed token: %w", err)
+	}
+	return nil
+}
+
+func GetUserIDByCalendarFeedToken(tokenHash string) (uuid.UUID, error) {
+	var userID uuid.UUID
+	err := orm.DB.Get(&userID, `SELECT user_id FROM calendar_feed_tokens WHERE token_hash = $1`, tokenHash)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return uuid.Nil, fmt.Errorf("calendar feed not found")
+		}
+		return uuid.Nil, fmt.Errorf("could not fetch calendar feed: %w", err)
+	}
+	return userID, nil
+}
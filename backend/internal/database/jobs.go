@@ -8,6 +8,7 @@ import (
 	"github.com/hridaya14/Web-Tech-Project/pkg/orm"
 	"github.com/lib/pq"
 	"log"
+	"time"
 )
 
 type JobListingFilters struct {
@@ -21,8 +22,8 @@ type JobListingFilters struct {
 func CreateJobListing(Listing models.JobListingRequest, company_id uuid.UUID) (models.JobListing, error) {
 
 	query := `
-		INSERT INTO job_listings (company_id, title, description, location, work_type, job_type, experience_level, experience_months, salary_range, required_skills)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		INSERT INTO job_listings (company_id, title, description, location, work_type, job_type, experience_level, experience_months, salary_range, required_skills, closes_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
 		RETURNING *
 	`
 
@@ -44,6 +45,7 @@ func CreateJobListing(Listing models.JobListingRequest, company_id uuid.UUID) (m
 		Listing.Experience_months,
 		Listing.Salary_range,
 		pq.StringArray(skillsArray),
+		Listing.Closes_at,
 	)
 
 	if err != nil {
@@ -86,32 +88,116 @@ func GetJobListingsByCompanyID(companyID uuid.UUID) ([]models.JobListing, error)
 	return listings, nil
 }
 
-func DeleteJobListingByID(listingID, companyID uuid.UUID) error {
+// CloseJobListingByID stops the listing from taking applications and notifies the company's webhooks
+func CloseJobListingByID(listingID, companyID uuid.UUID) (models.JobListing, error) {
+	tx, err := orm.DB.Beginx()
+	if err != nil {
+		return models.JobListing{}, fmt.Errorf("could not start transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var listing models.JobListing
+	err = tx.Get(&listing, `
+		UPDATE job_listings SET closed_at = NOW(), updated_at = NOW()
+		WHERE id = $1 AND company_id = $2 AND closed_at IS NULL
+		RETURNING *
+	`, listingID, companyID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return models.JobListing{}, fmt.Errorf("job listing not found")
+		}
+		log.Printf("Error closing job listing: %v", err)
+		return models.JobListing{}, fmt.Errorf("could not close job listing: %w", err)
+	}
+
+	if err := enqueueWebhookEvent(tx, companyID, models.EventListingClosed, listingWebhookData(listing)); err != nil {
+		return models.JobListing{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return models.JobListing{}, fmt.Errorf("could not close job listing: %w", err)
+	}
+	return listing, nil
+}
+
+// CloseExpiredListings closes the open listings whose deadline has passed and notifies their
+// companies' webhooks, as if each had been closed by hand at its deadline.
+func CloseExpiredListings() ([]models.JobListing, error) {
+	tx, err := orm.DB.Beginx()
+	if err != nil {
+		return nil, fmt.Errorf("could not start transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	listings := []models.JobListing{}
+	err = tx.Select(&listings, `
+		UPDATE job_listings SET closed_at = closes_at, updated_at = NOW()
+		WHERE closed_at IS NULL AND closes_at <= NOW()
+		RETURNING *
+	`)
+	if err != nil {
+		log.Printf("Error closing expired job listings: %v", err)
+		return nil, fmt.Errorf("could not close expired job listings: %w", err)
+	}
+
+	for _, listing := range listings {
+		if err := enqueueWebhookEvent(tx, listing.Company_id, models.EventListingClosed, listingWebhookData(listing)); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("could not close expired job listings: %w", err)
+	}
+	return listings, nil
+}
+
+// DeleteJobListingByID deletes a company's own listing and returns it as it was
+func DeleteJobListingByID(listingID, companyID uuid.UUID) (models.JobListing, error) {
 	listing, err := GetJobListingByID(listingID)
 	if err != nil {
-		return err
+		return models.JobListing{}, err
 	}
 
 	if listing.Company_id != companyID {
-		return fmt.Errorf("unauthorized: this company does not own the job listing")
+		return models.JobListing{}, fmt.Errorf("unauthorized: this company does not own the job listing")
 	}
 
+	tx, err := orm.DB.Beginx()
+	if err != nil {
+		return models.JobListing{}, fmt.Errorf("could not start transaction: %w", err)
+	}
+	defer tx.Rollback()
+
 	query := `DELETE FROM job_listings WHERE id = $1`
-	result, err := orm.DB.Exec(query, listingID)
+	result, err := tx.Exec(query, listingID)
 	if err != nil {
 		log.Printf("Error deleting job listing: %v", err)
-		return fmt.Errorf("could not delete job listing: %w", err)
+		return models.JobListing{}, fmt.Errorf("could not delete job listing: %w", err)
 	}
 
 	rowsAffected, err := result.RowsAffected()
 	if err != nil {
 		log.Printf("Error fetching rows affected: %v", err)
-		return fmt.Errorf("could not verify job listing deletion: %w", err)
+		return models.JobListing{}, fmt.Errorf("could not verify job listing deletion: %w", err)
 	}
 
 	if rowsAffected == 0 {
-		return fmt.Errorf("job listing could not be deleted (already removed?)")
+		return models.JobListing{}, fmt.Errorf("job listing could not be deleted (already removed?)")
 	}
 
-	return nil
+	// A deleted listing that was still open is closed as far as subscribers are concerned
+	if listing.ClosedAt == nil {
+		closed := listing
+		now := time.Now()
+		closed.ClosedAt = &now
+		if err := enqueueWebhookEvent(tx, companyID, models.EventListingClosed, listingWebhookData(closed)); err != nil {
+			return models.JobListing{}, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return models.JobListing{}, fmt.Errorf("could not delete job listing: %w", err)
+	}
+	return listing, nil
 }
@@ -0,0 +1,242 @@
+package database
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hridaya14/Web-Tech-Project/internal/models"
+	"github.com/hridaya14/Web-Tech-Project/pkg/auth"
+	"github.com/hridaya14/Web-Tech-Project/pkg/orm"
+)
+
+// IsCompanyMember reports whether the user owns or has joined any company.
+func IsCompanyMember(userID uuid.UUID) (bool, error) {
+	var member bool
+	err := orm.DB.Get(&member, `SELECT EXISTS (SELECT 1 FROM company_members WHERE user_id = $1)`, userID)
+	if err != nil {
+		log.Printf("Error checking company membership: %v", err)
+		return false, fmt.Errorf("could not check company membership: %w", err)
+	}
+	return member, nil
+}
+
+// GetCompanyMemberRole returns the user's role within the company, or an empty string if they are not a member.
+func GetCompanyMemberRole(companyID, userID uuid.UUID) (string, error) {
+	var role string
+	query := `SELECT role FROM company_members WHERE company_id = $1 AND user_id = $2`
+	err := orm.DB.Get(&role, query, companyID, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", nil
+		}
+		log.Printf("Error fetching member role: %v", err)
+		return "", fmt.Errorf("could not fetch member role: %w", err)
+	}
+	return role, nil
+}
+
+func GetCompanyMembers(companyID uuid.UUID) ([]models.CompanyMember, error) {
+	query := `
+		SELECT m.company_id, m.user_id, u.username, u.email, m.role, m.invited_by, m.created_at
+		FROM company_members m
+		JOIN users u ON m.user_id = u.id
+		WHERE m.company_id = $1
+		ORDER BY m.created_at
+	`
+	members := []models.CompanyMember{}
+	err := orm.DB.Select(&members, query, companyID)
+	if err != nil {
+		log.Printf("Error fetching company members: %v", err)
+		return nil, fmt.Errorf("could not fetch company members: %w", err)
+	}
+	return members, nil
+}
+
+// GetCompanyMemberUserIDs lists the users who belong to a company
+func GetCompanyMemberUserIDs(companyID uuid.UUID) ([]uuid.UUID, error) {
+	ids := []uuid.UUID{}
+	err := orm.DB.Select(&ids, `SELECT user_id FROM company_members WHERE company_id = $1`, companyID)
+	if err != nil {
+		return nil, fmt.Errorf("could not fetch company members: %w", err)
+	}
+	return ids, nil
+}
+
+func UpdateCompanyMemberRole(companyID, userID uuid.UUID, role string) error {
+	query := `
+		UPDATE company_members SET role = $1
+		WHERE company_id = $2 AND user_id = $3 AND role <> 'owner'
+	`
+	result, err := orm.DB.Exec(query, role, companyID, userID)
+	if err != nil {
+		log.Printf("Error updating member role: %v", err)
+		return fmt.Errorf("could not update member role: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("could not verify member update: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("member not found")
+	}
+	return nil
+}
+
+func RemoveCompanyMember(companyID, userID uuid.UUID) error {
+	query := `DELETE FROM company_members WHERE company_id = $1 AND user_id = $2 AND role <> 'owner'`
+	result, err := orm.DB.Exec(query, companyID, userID)
+	if err != nil {
+		log.Printf("Error removing company member: %v", err)
+		return fmt.Errorf("could not remove member: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("could not verify member removal: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("member not found")
+	}
+	return nil
+}
+
+// CreateCompanyInvitation stores the invitation and queues the email carrying its token.
+// Only the hash of the token is kept on the invitation itself.
+func CreateCompanyInvitation(companyID, invitedBy uuid.UUID, email, role, token string, expiresAt time.Time) (models.CompanyInvitation, error) {
+	query := `
+		INSERT INTO company_invitations (company_id, email, role, token_hash, invited_by, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING *
+	`
+	tx, err := orm.DB.Beginx()
+	if err != nil {
+		return models.CompanyInvitation{}, fmt.Errorf("could not start transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var inv models.CompanyInvitation
+	err = tx.Get(&inv, query, companyID, strings.ToLower(email), role, auth.HashToken(token), invitedBy, expiresAt)
+	if err != nil {
+		log.Printf("Error creating invitation: %v", err)
+		return models.CompanyInvitation{}, fmt.Errorf("could not create invitation: %w", err)
+	}
+
+	var companyName string
+	if err := tx.Get(&companyName, `SELECT company_name FROM companies WHERE id = $1`, companyID); err != nil {
+		return models.CompanyInvitation{}, fmt.Errorf("could not fetch company: %w", err)
+	}
+
+	err = enqueueAddressEmail(tx, inv.Email, models.EmailCompanyInvitation, map[string]any{
+		"CompanyName": companyName,
+		"Role":        role,
+		"Token":       token,
+		"ExpiresAt":   expiresAt.Format("2 Jan 2006"),
+	})
+	if err != nil {
+		return models.CompanyInvitation{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return models.CompanyInvitation{}, fmt.Errorf("could not create invitation: %w", err)
+	}
+	return inv, nil
+}
+
+func GetPendingCompanyInvitations(companyID uuid.UUID) ([]models.CompanyInvitation, error) {
+	query := `
+		SELECT * FROM company_invitations
+		WHERE company_id = $1 AND accepted_at IS NULL AND expires_at > NOW()
+		ORDER BY created_at DESC
+	`
+	invitations := []models.CompanyInvitation{}
+	err := orm.DB.Select(&invitations, query, companyID)
+	if err != nil {
+		log.Printf("Error fetching invitations: %v", err)
+		return nil, fmt.Errorf("could not fetch invitations: %w", err)
+	}
+	return invitations, nil
+}
+
+func RevokeCompanyInvitation(invitationID, companyID uuid.UUID) error {
+	query := `DELETE FROM company_invitations WHERE id = $1 AND company_id = $2 AND accepted_at IS NULL`
+	result, err := orm.DB.Exec(query, invitationID, companyID)
+	if err != nil {
+		log.Printf("Error revoking invitation: %v", err)
+		return fmt.Errorf("could not revoke invitation: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("could not verify invitation revocation: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("invitation not found")
+	}
+	return nil
+}
+
+// AcceptCompanyInvitation adds the user to the inviting company and marks the invitation used.
+func AcceptCompanyInvitation(tokenHash string, userID uuid.UUID, email string) (models.CompanyInvitation, error) {
+	tx, err := orm.DB.Beginx()
+	if err != nil {
+		return models.CompanyInvitation{}, fmt.Errorf("could not start transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var inv models.CompanyInvitation
+	err = tx.Get(&inv, `
+		SELECT * FROM company_invitations
+		WHERE token_hash = $1 AND accepted_at IS NULL AND expires_at > NOW()
+		FOR UPDATE
+	`, tokenHash)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.CompanyInvitation{}, fmt.Errorf("invitation not found")
+		}
+		log.Printf("Error fetching invitation: %v", err)
+		return models.CompanyInvitation{}, fmt.Errorf("could not fetch invitation: %w", err)
+	}
+
+	if !strings.EqualFold(inv.Email, email) {
+		return models.CompanyInvitation{}, fmt.Errorf("invitation email mismatch")
+	}
+
+	var isMember bool
+	err = tx.Get(&isMember, `SELECT EXISTS (SELECT 1 FROM company_members WHERE user_id = $1)`, userID)
+	if err != nil {
+		return models.CompanyInvitation{}, fmt.Errorf("could not check membership: %w", err)
+	}
+	if isMember {
+		return models.CompanyInvitation{}, fmt.Errorf("user already belongs to a company")
+	}
+
+	_, err = tx.Exec(`
+		INSERT INTO company_members (company_id, user_id, role, invited_by)
+		VALUES ($1, $2, $3, $4)
+	`, inv.CompanyID, userID, inv.Role, inv.InvitedBy)
+	if err != nil {
+		log.Printf("Error adding company member: %v", err)
+		return models.CompanyInvitation{}, fmt.Errorf("could not add member: %w", err)
+	}
+
+	_, err = tx.Exec(`UPDATE company_invitations SET accepted_at = NOW() WHERE id = $1`, inv.ID)
+	if err != nil {
+		return models.CompanyInvitation{}, fmt.Errorf("could not mark invitation accepted: %w", err)
+	}
+
+	_, err = tx.Exec(`UPDATE users SET onboarding_status = 'COMPLETED' WHERE id = $1`, userID)
+	if err != nil {
+		return models.CompanyInvitation{}, fmt.Errorf("could not update onboarding status: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return models.CompanyInvitation{}, fmt.Errorf("could not accept invitation: %w", err)
+	}
+	return inv, nil
+}
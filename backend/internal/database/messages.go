@@ -0,0 +1,271 @@
+package database
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/google/uuid"
+	"github.com/hridaya14/Web-Tech-Project/internal/models"
+	"github.com/hridaya14/Web-Tech-Project/pkg/orm"
+	"github.com/jmoiron/sqlx"
+)
+
+// threadColumns selects a thread with the number of messages from the other side the viewer has not read.
+// $1 is the viewing user and $2 their role.
+const threadColumns = `
+	t.id, t.application_id, t.subject, t.created_by, t.created_at, t.last_message_at,
+	(
+		SELECT COUNT(*) FROM messages m
+		WHERE m.thread_id = t.id
+		  AND m.sender_role <> $2
+		  AND m.created_at > COALESCE(
+			(SELECT r.last_read_at FROM message_reads r WHERE r.thread_id = t.id AND r.user_id = $1),
+			'epoch'::timestamp
+		  )
+	) AS unread_count
+`
+
+func GetApplicationParties(applicationID uuid.UUID) (models.ApplicationParties, error) {
+	var parties models.ApplicationParties
+	err := orm.DB.Get(&parties, `
+		SELECT a.application_id, a.candidate_id, COALESCE(c.user_id, '00000000-0000-0000-0000-000000000000') AS candidate_user_id,
+		       j.company_id, j.id AS job_id, j.title AS job_title
+		FROM applications a
+		JOIN candidates c ON a.candidate_id = c.id
+		JOIN job_listings j ON a.job_id = j.id
+		WHERE a.application_id = $1
+	`, applicationID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.ApplicationParties{}, fmt.Errorf("application not found")
+		}
+		log.Printf("Error fetching application parties: %v", err)
+		return models.ApplicationParties{}, fmt.Errorf("could not fetch application: %w", err)
+	}
+	return parties, nil
+}
+
+// CreateMessageThread opens a thread on an application with its first message.
+func CreateMessageThread(applicationID uuid.UUID, subject string, senderID uuid.UUID, senderRole, body string, attachments []models.MessageAttachment) (models.MessageThread, models.Message, error) {
+	tx, err := orm.DB.Beginx()
+	if err != nil {
+		return models.MessageThread{}, models.Message{}, fmt.Errorf("could not start transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var thread models.MessageThread
+	err = tx.Get(&thread, `
+		INSERT INTO message_threads (application_id, subject, created_by)
+		VALUES ($1, $2, $3)
+		RETURNING id, application_id, subject, created_by, created_at, last_message_at
+	`, applicationID, subject, senderID)
+	if err != nil {
+		log.Printf("Error creating message thread: %v", err)
+		return models.MessageThread{}, models.Message{}, fmt.Errorf("could not create thread: %w", err)
+	}
+
+	message, err := insertMessage(tx, thread.ID, senderID, senderRole, body, attachments)
+	if err != nil {
+		return models.MessageThread{}, models.Message{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return models.MessageThread{}, models.Message{}, fmt.Errorf("could not create thread: %w", err)
+	}
+	return thread, message, nil
+}
+
+// CreateMessage appends a reply to an existing thread.
+func CreateMessage(threadID, senderID uuid.UUID, senderRole, body string, attachments []models.MessageAttachment) (models.Message, error) {
+	tx, err := orm.DB.Beginx()
+	if err != nil {
+		return models.Message{}, fmt.Errorf("could not start transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	message, err := insertMessage(tx, threadID, senderID, senderRole, body, attachments)
+	if err != nil {
+		return models.Message{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return models.Message{}, fmt.Errorf("could not send message: %w", err)
+	}
+	return message, nil
+}
+
+func insertMessage(tx *sqlx.Tx, threadID, senderID uuid.UUID, senderRole, body string, attachments []models.MessageAttachment) (models.Message, error) {
+	var message models.Message
+	err := tx.Get(&message, `
+		INSERT INTO messages (thread_id, sender_id, sender_role, body)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, thread_id, sender_id, sender_role, body, created_at
+	`, threadID, senderID, senderRole, body)
+	if err != nil {
+		log.Printf("Error creating message: %v", err)
+		return models.Message{}, fmt.Errorf("could not send message: %w", err)
+	}
+
+	message.Attachments = []models.MessageAttachment{}
+	for _, a := range attachments {
+		var saved models.MessageAttachment
+		err := tx.Get(&saved, `
+			INSERT INTO message_attachments (message_id, file_name, file_key, content_type, size_bytes)
+			VALUES ($1, $2, $3, $4, $5)
+			RETURNING *
+		`, message.ID, a.FileName, a.FileKey, a.ContentType, a.SizeBytes)
+		if err != nil {
+			log.Printf("Error saving message attachment: %v", err)
+			return models.Message{}, fmt.Errorf("could not save attachment: %w", err)
+		}
+		message.Attachments = append(message.Attachments, saved)
+	}
+
+	_, err = tx.Exec(`UPDATE message_threads SET last_message_at = $1 WHERE id = $2`, message.CreatedAt, threadID)
+	if err != nil {
+		return models.Message{}, fmt.Errorf("could not update thread: %w", err)
+	}
+
+	// Senders have obviously read their own thread
+	_, err = tx.Exec(`
+		INSERT INTO message_reads (thread_id, user_id, last_read_at) VALUES ($1, $2, $3)
+		ON CONFLICT (thread_id, user_id) DO UPDATE SET last_read_at = EXCLUDED.last_read_at
+	`, threadID, senderID, message.CreatedAt)
+	if err != nil {
+		return models.Message{}, fmt.Errorf("could not update read marker: %w", err)
+	}
+
+	return message, nil
+}
+
+func GetMessageThreadByID(threadID, viewerID uuid.UUID, viewerRole string) (models.MessageThread, error) {
+	var thread models.MessageThread
+	err := orm.DB.Get(&thread, `SELECT `+threadColumns+` FROM message_threads t WHERE t.id = $3`, viewerID, viewerRole, threadID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.MessageThread{}, fmt.Errorf("thread not found")
+		}
+		log.Printf("Error fetching message thread: %v", err)
+		return models.MessageThread{}, fmt.Errorf("could not fetch thread: %w", err)
+	}
+	return thread, nil
+}
+
+// GetMessageThreadsByCandidateID lists threads on the candidate's applications.
+// A non-nil applicationID narrows the list to that application.
+func GetMessageThreadsByCandidateID(candidateID, viewerID, applicationID uuid.UUID) ([]models.MessageThread, error) {
+	query := `
+		SELECT ` + threadColumns + `
+		FROM message_threads t
+		JOIN applications a ON t.application_id = a.application_id
+		WHERE a.candidate_id = $3 AND ($4::uuid IS NULL OR a.application_id = $4)
+		ORDER BY t.last_message_at DESC
+	`
+	threads := []models.MessageThread{}
+	err := orm.DB.Select(&threads, query, viewerID, "candidate", candidateID, nullableUUID(applicationID))
+	if err != nil {
+		log.Printf("Error fetching candidate threads: %v", err)
+		return nil, fmt.Errorf("could not fetch threads: %w", err)
+	}
+	return threads, nil
+}
+
+// GetMessageThreadsByCompanyID lists threads on applications to the company's listings.
+// A non-nil applicationID narrows the list to that application.
+func GetMessageThreadsByCompanyID(companyID, viewerID, applicationID uuid.UUID) ([]models.MessageThread, error) {
+	query := `
+		SELECT ` + threadColumns + `
+		FROM message_threads t
+		JOIN applications a ON t.application_id = a.application_id
+		JOIN job_listings j ON a.job_id = j.id
+		WHERE j.company_id = $3 AND ($4::uuid IS NULL OR a.application_id = $4)
+		ORDER BY t.last_message_at DESC
+	`
+	threads := []models.MessageThread{}
+	err := orm.DB.Select(&threads, query, viewerID, "company", companyID, nullableUUID(applicationID))
+	if err != nil {
+		log.Printf("Error fetching company threads: %v", err)
+		return nil, fmt.Errorf("could not fetch threads: %w", err)
+	}
+	return threads, nil
+}
+
+func GetThreadMessages(threadID uuid.UUID) ([]models.Message, error) {
+	messages := []models.Message{}
+	err := orm.DB.Select(&messages, `
+		SELECT m.id, m.thread_id, m.sender_id, COALESCE(u.username, '') AS sender_name,
+		       m.sender_role, m.body, m.created_at
+		FROM messages m
+		LEFT JOIN users u ON m.sender_id = u.id
+		WHERE m.thread_id = $1
+		ORDER BY m.created_at
+	`, threadID)
+	if err != nil {
+		log.Printf("Error fetching messages: %v", err)
+		return nil, fmt.Errorf("could not fetch messages: %w", err)
+	}
+	if len(messages) == 0 {
+		return messages, nil
+	}
+
+	ids := make([]interface{}, len(messages))
+	index := make(map[uuid.UUID]int, len(messages))
+	for i, m := range messages {
+		ids[i] = m.ID
+		index[m.ID] = i
+		messages[i].Attachments = []models.MessageAttachment{}
+	}
+
+	var attachments []models.MessageAttachment
+	if err := selectIn(&attachments, `SELECT * FROM message_attachments WHERE message_id IN (?) ORDER BY created_at`, ids); err != nil {
+		return nil, fmt.Errorf("error fetching attachments: %w", err)
+	}
+	for _, a := range attachments {
+		i := index[a.MessageID]
+		messages[i].Attachments = append(messages[i].Attachments, a)
+	}
+
+	return messages, nil
+}
+
+func MarkThreadRead(threadID, userID uuid.UUID) error {
+	_, err := orm.DB.Exec(`
+		INSERT INTO message_reads (thread_id, user_id, last_read_at) VALUES ($1, $2, NOW())
+		ON CONFLICT (thread_id, user_id) DO UPDATE SET last_read_at = EXCLUDED.last_read_at
+	`, threadID, userID)
+	if err != nil {
+		log.Printf("Error marking thread read: %v", err)
+		return fmt.Errorf("could not mark thread read: %w", err)
+	}
+	return nil
+}
+
+// GetUnreadMessageCount totals unread messages across every thread the user can see.
+func GetUnreadMessageCount(userID uuid.UUID, role string, relatedID uuid.UUID) (int, error) {
+	var threads []models.MessageThread
+	var err error
+	if role == "company" {
+		threads, err = GetMessageThreadsByCompanyID(relatedID, userID, uuid.Nil)
+	} else {
+		threads, err = GetMessageThreadsByCandidateID(relatedID, userID, uuid.Nil)
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	total := 0
+	for _, t := range threads {
+		total += t.UnreadCount
+	}
+	return total, nil
+}
+
+// nullableUUID maps uuid.Nil to SQL NULL
+func nullableUUID(id uuid.UUID) interface{} {
+	if id == uuid.Nil {
+		return nil
+	}
+	return id
+}
@@ -0,0 +1,141 @@
+package database
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/google/uuid"
+	"github.com/hridaya14/Web-Tech-Project/internal/models"
+	"github.com/hridaya14/Web-Tech-Project/pkg/orm"
+	"github.com/jmoiron/sqlx/types"
+)
+
+func CreateNotification(userID uuid.UUID, notificationType, title, body string, data types.JSONText) (models.Notification, error) {
+	if len(data) == 0 {
+		data = types.JSONText("{}")
+	}
+
+	var n models.Notification
+	err := orm.DB.Get(&n, `
+		INSERT INTO notifications (user_id, type, title, body, data)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING *
+	`, userID, notificationType, title, body, data)
+	if err != nil {
+		log.Printf("Error creating notification: %v", err)
+		return models.Notification{}, fmt.Errorf("could not create notification: %w", err)
+	}
+	return n, nil
+}
+
+func GetNotifications(userID uuid.UUID, unreadOnly bool, limit int) ([]models.Notification, error) {
+	query := `SELECT * FROM notifications WHERE user_id = $1`
+	if unreadOnly {
+		query += ` AND read_at IS NULL`
+	}
+	query += ` ORDER BY created_at DESC LIMIT $2`
+
+	notifications := []models.Notification{}
+	err := orm.DB.Select(&notifications, query, userID, limit)
+	if err != nil {
+		log.Printf("Error fetching notifications: %v", err)
+		return nil, fmt.Errorf("could not fetch notifications: %w", err)
+	}
+	return notifications, nil
+}
+
+func CountUnreadNotifications(userID uuid.UUID) (int, error) {
+	var count int
+	err := orm.DB.Get(&count, `SELECT COUNT(*) FROM notifications WHERE user_id = $1 AND read_at IS NULL`, userID)
+	if err != nil {
+		return 0, fmt.Errorf("could not count notifications: %w", err)
+	}
+	return count, nil
+}
+
+func MarkNotificationRead(notificationID, userID uuid.UUID) error {
+	result, err := orm.DB.Exec(`
+		UPDATE notifications SET read_at = COALESCE(read_at, NOW())
+		WHERE id = $1 AND user_id = $2
+	`, notificationID, userID)
+	if err != nil {
+		log.Printf("Error marking notification read: %v", err)
+		return fmt.Errorf("could not mark notification read: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("could not verify notification update: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("notification not found")
+	}
+	return nil
+}
+
+func MarkAllNotificationsRead(userID uuid.UUID) error {
+	_, err := orm.DB.Exec(`UPDATE notifications SET read_at = NOW() WHERE user_id = $1 AND read_at IS NULL`, userID)
+	if err != nil {
+		log.Printf("Error marking notifications read: %v", err)
+		return fmt.Errorf("could not mark notifications read: %w", err)
+	}
+	return nil
+}
+
+// GetNotificationPreferences returns the user's stored overrides; types without a row are enabled.
+func GetNotificationPreferences(userID uuid.UUID) ([]models.NotificationPreference, error) {
+	prefs := []models.NotificationPreference{}
+	err := orm.DB.Select(&prefs, `SELECT type, in_app, email FROM notification_preferences WHERE user_id = $1`, userID)
+	if err != nil {
+		log.Printf("Error fetching notification preferences: %v", err)
+		return nil, fmt.Errorf("could not fetch notification preferences: %w", err)
+	}
+	return prefs, nil
+}
+
+func IsInAppNotificationEnabled(userID uuid.UUID, notificationType string) (bool, error) {
+	var enabled bool
+	err := orm.DB.Get(&enabled, `
+		SELECT in_app FROM notification_preferences WHERE user_id = $1 AND type = $2
+	`, userID, notificationType)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return true, nil
+		}
+		return false, fmt.Errorf("could not fetch notification preference: %w", err)
+	}
+	return enabled, nil
+}
+
+func IsEmailNotificationEnabled(userID uuid.UUID, notificationType string) (bool, error) {
+	var enabled bool
+	err := orm.DB.Get(&enabled, `
+		SELECT email FROM notification_preferences WHERE user_id = $1 AND type = $2
+	`, userID, notificationType)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return true, nil
+		}
+		return false, fmt.Errorf("could not fetch notification preference: %w", err)
+	}
+	return enabled, nil
+}
+
+// SetNotificationPreference updates the given channels of a notification type; nil leaves a channel unchanged.
+func SetNotificationPreference(userID uuid.UUID, notificationType string, inApp, email *bool) error {
+	_, err := orm.DB.Exec(`
+		INSERT INTO notification_preferences (user_id, type, in_app, email)
+		VALUES ($1, $2, COALESCE($3, TRUE), COALESCE($4, TRUE))
+		ON CONFLICT (user_id, type) DO UPDATE SET
+			in_app = COALESCE($3, notification_preferences.in_app),
+			email = COALESCE($4, notification_preferences.email),
+			updated_at = NOW()
+	`, userID, notificationType, inApp, email)
+	if err != nil {
+		log.Printf("Error saving notification preference: %v", err)
+		return fmt.Errorf("could not save notification preference: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,162 @@
+package database
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hridaya14/Web-Tech-Project/internal/models"
+	"github.com/hridaya14/Web-Tech-Project/pkg/orm"
+	"github.com/jmoiron/sqlx"
+)
+
+// enqueueUserEmail queues an email to a user inside the caller's transaction.
+func enqueueUserEmail(tx *sqlx.Tx, userID uuid.UUID, template string, data map[string]any) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("could not encode email data: %w", err)
+	}
+
+	_, err = tx.Exec(`
+		INSERT INTO email_outbox (user_id, to_email, template, data)
+		SELECT id, email, $2, $3 FROM users WHERE id = $1
+	`, userID, template, payload)
+	if err != nil {
+		log.Printf("Error queueing email: %v", err)
+		return fmt.Errorf("could not queue email: %w", err)
+	}
+	return nil
+}
+
+// enqueueCompanyEmail queues one email per company member inside the caller's transaction.
+func enqueueCompanyEmail(tx *sqlx.Tx, companyID uuid.UUID, template string, data map[string]any) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("could not encode email data: %w", err)
+	}
+
+	_, err = tx.Exec(`
+		INSERT INTO email_outbox (user_id, to_email, template, data)
+		SELECT u.id, u.email, $2, $3
+		FROM company_members m
+		JOIN users u ON m.user_id = u.id
+		WHERE m.company_id = $1
+	`, companyID, template, payload)
+	if err != nil {
+		log.Printf("Error queueing company email: %v", err)
+		return fmt.Errorf("could not queue email: %w", err)
+	}
+	return nil
+}
+
+// enqueueAddressEmail queues an email to an address that may not belong to a user yet.
+func enqueueAddressEmail(tx *sqlx.Tx, email, template string, data map[string]any) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("could not encode email data: %w", err)
+	}
+
+	_, err = tx.Exec(`
+		INSERT INTO email_outbox (to_email, template, data) VALUES ($1, $2, $3)
+	`, email, template, payload)
+	if err != nil {
+		log.Printf("Error queueing email: %v", err)
+		return fmt.Errorf("could not queue email: %w", err)
+	}
+	return nil
+}
+
+// applicationEmailData collects the fields application templates refer to.
+func applicationEmailData(tx *sqlx.Tx, applicationID uuid.UUID) (map[string]any, uuid.UUID, uuid.UUID, error) {
+	var row struct {
+		CandidateUserID uuid.UUID `db:"candidate_user_id"`
+		CompanyID       uuid.UUID `db:"company_id"`
+		CandidateName   string    `db:"candidate_name"`
+		CompanyName     string    `db:"company_name"`
+		JobTitle        string    `db:"job_title"`
+		JobID           uuid.UUID `db:"job_id"`
+	}
+	err := tx.Get(&row, `
+		SELECT COALESCE(c.user_id, '00000000-0000-0000-0000-000000000000') AS candidate_user_id, j.company_id, c.full_name AS candidate_name,
+		       co.company_name, j.title AS job_title, j.id AS job_id
+		FROM applications a
+		JOIN candidates c ON a.candidate_id = c.id
+		JOIN job_listings j ON a.job_id = j.id
+		JOIN companies co ON j.company_id = co.id
+		WHERE a.application_id = $1
+	`, applicationID)
+	if err != nil {
+		return nil, uuid.Nil, uuid.Nil, fmt.Errorf("could not fetch application details: %w", err)
+	}
+
+	data := map[string]any{
+		"ApplicationID": applicationID,
+		"CandidateName": row.CandidateName,
+		"CompanyName":   row.CompanyName,
+		"JobTitle":      row.JobTitle,
+		"JobID":         row.JobID,
+	}
+	return data, row.CandidateUserID, row.CompanyID, nil
+}
+
+// ClaimDueEmails leases up to limit due emails to the caller. A claimed email is
+// hidden from other senders for lease; if it is not marked sent or failed in
+// that time it becomes due again.
+func ClaimDueEmails(limit int, lease time.Duration) ([]models.OutboxEmail, error) {
+	emails := []models.OutboxEmail{}
+	err := orm.DB.Select(&emails, `
+		UPDATE email_outbox
+		SET attempts = attempts + 1, next_attempt_at = NOW() + $2 * INTERVAL '1 second'
+		WHERE id IN (
+			SELECT id FROM email_outbox
+			WHERE status = 'pending' AND next_attempt_at <= NOW()
+			ORDER BY next_attempt_at
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING *
+	`, limit, lease.Seconds())
+	if err != nil {
+		log.Printf("Error claiming outbox emails: %v", err)
+		return nil, fmt.Errorf("could not claim emails: %w", err)
+	}
+	return emails, nil
+}
+
+// MarkEmailSent completes an email and drops any one-time token from its stored data.
+func MarkEmailSent(id uuid.UUID) error {
+	_, err := orm.DB.Exec(`
+		UPDATE email_outbox SET status = 'sent', sent_at = NOW(), last_error = NULL, data = data - 'Token' WHERE id = $1
+	`, id)
+	if err != nil {
+		return fmt.Errorf("could not mark email sent: %w", err)
+	}
+	return nil
+}
+
+func MarkEmailSkipped(id uuid.UUID) error {
+	_, err := orm.DB.Exec(`UPDATE email_outbox SET status = 'skipped' WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("could not mark email skipped: %w", err)
+	}
+	return nil
+}
+
+// MarkEmailFailed records a delivery error. The email is retried at nextAttempt
+// unless final is set, in which case it is given up on.
+func MarkEmailFailed(id uuid.UUID, sendErr string, nextAttempt time.Time, final bool) error {
+	status := models.OutboxPending
+	if final {
+		status = models.OutboxFailed
+	}
+
+	_, err := orm.DB.Exec(`
+		UPDATE email_outbox SET status = $1, last_error = $2, next_attempt_at = $3 WHERE id = $4
+	`, status, sendErr, nextAttempt, id)
+	if err != nil {
+		return fmt.Errorf("could not record email failure: %w", err)
+	}
+	return nil
+}
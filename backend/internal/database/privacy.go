@@ -0,0 +1,393 @@
+package database
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hridaya14/Web-Tech-Project/internal/models"
+	"github.com/hridaya14/Web-Tech-Project/pkg/orm"
+)
+
+// CreateDataExport queues an export of the user's data, or returns the one already waiting.
+func CreateDataExport(userID uuid.UUID) (models.DataExport, error) {
+	tx, err := orm.DB.Beginx()
+	if err != nil {
+		return models.DataExport{}, fmt.Errorf("could not begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Serialize requests of the same user so only one export is ever pending
+	if _, err := tx.Exec(`SELECT pg_advisory_xact_lock(hashtext($1))`, "data_export:"+userID.String()); err != nil {
+		return models.DataExport{}, fmt.Errorf("could not lock data exports: %w", err)
+	}
+
+	var export models.DataExport
+	err = tx.Get(&export, `SELECT * FROM data_exports WHERE user_id = $1 AND status = 'pending'`, userID)
+	if err == nil {
+		return export, tx.Commit()
+	} else if !errors.Is(err, sql.ErrNoRows) {
+		log.Printf("Error fetching pending data export: %v", err)
+		return models.DataExport{}, fmt.Errorf("could not create data export: %w", err)
+	}
+
+	err = tx.Get(&export, `INSERT INTO data_exports (user_id) VALUES ($1) RETURNING *`, userID)
+	if err != nil {
+		log.Printf("Error creating data export: %v", err)
+		return models.DataExport{}, fmt.Errorf("could not create data export: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return models.DataExport{}, fmt.Errorf("could not commit transaction: %w", err)
+	}
+	return export, nil
+}
+
+func GetLatestDataExport(userID uuid.UUID) (models.DataExport, error) {
+	var export models.DataExport
+	err := orm.DB.Get(&export, `
+		SELECT * FROM data_exports WHERE user_id = $1 ORDER BY created_at DESC LIMIT 1
+	`, userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.DataExport{}, fmt.Errorf("data export not found")
+	} else if err != nil {
+		log.Printf("Error fetching data export: %v", err)
+		return models.DataExport{}, fmt.Errorf("could not fetch data export: %w", err)
+	}
+	return export, nil
+}
+
+// ClaimDueDataExports leases up to limit pending exports to the caller, like ClaimDueEmails.
+func ClaimDueDataExports(limit int, lease time.Duration) ([]models.DataExport, error) {
+	exports := []models.DataExport{}
+	err := orm.DB.Select(&exports, `
+		UPDATE data_exports
+		SET attempts = attempts + 1, next_attempt_at = NOW() + $2 * INTERVAL '1 second'
+		WHERE id IN (
+			SELECT id FROM data_exports
+			WHERE status = 'pending' AND next_attempt_at <= NOW()
+			ORDER BY next_attempt_at
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING *
+	`, limit, lease.Seconds())
+	if err != nil {
+		log.Printf("Error claiming data exports: %v", err)
+		return nil, fmt.Errorf("could not claim data exports: %w", err)
+	}
+	return exports, nil
+}
+
+func CompleteDataExport(id uuid.UUID, objectKey string, size int64, expiresAt time.Time) error {
+	_, err := orm.DB.Exec(`
+		UPDATE data_exports
+		SET status = 'ready', object_key = $2, size_bytes = $3, completed_at = NOW(), expires_at = $4, last_error = NULL
+		WHERE id = $1
+	`, id, objectKey, size, expiresAt)
+	if err != nil {
+		log.Printf("Error completing data export: %v", err)
+		return fmt.Errorf("could not complete data export: %w", err)
+	}
+	return nil
+}
+
+// FailDataExport records a failed attempt, retrying at nextAttempt unless final.
+func FailDataExport(id uuid.UUID, reason string, nextAttempt time.Time, final bool) error {
+	status := models.DataExportPending
+	if final {
+		status = models.DataExportFailed
+	}
+	_, err := orm.DB.Exec(`
+		UPDATE data_exports SET status = $2, last_error = $3, next_attempt_at = $4 WHERE id = $1
+	`, id, status, reason, nextAttempt)
+	if err != nil {
+		log.Printf("Error recording data export failure: %v", err)
+		return fmt.Errorf("could not record data export failure: %w", err)
+	}
+	return nil
+}
+
+// GetExpiredDataExports lists ready exports past their expiry, whose archives should be removed.
+func GetExpiredDataExports(limit int) ([]models.DataExport, error) {
+	exports := []models.DataExport{}
+	err := orm.DB.Select(&exports, `
+		SELECT * FROM data_exports WHERE status = 'ready' AND expires_at <= NOW() ORDER BY expires_at LIMIT $1
+	`, limit)
+	if err != nil {
+		log.Printf("Error fetching expired data exports: %v", err)
+		return nil, fmt.Errorf("could not fetch expired data exports: %w", err)
+	}
+	return exports, nil
+}
+
+func MarkDataExportExpired(id uuid.UUID) error {
+	_, err := orm.DB.Exec(`UPDATE data_exports SET status = 'expired' WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("could not expire data export: %w", err)
+	}
+	return nil
+}
+
+// GetPersonalData collects everything stored about a user, for their export.
+func GetPersonalData(userID uuid.UUID) (models.PersonalData, error) {
+	var data models.PersonalData
+
+	err := orm.DB.Get(&data.User, `
+		SELECT id, username, email, role, onboarding_status, created_at FROM users WHERE id = $1
+	`, userID)
+	if err != nil {
+		log.Printf("Error fetching user for export: %v", err)
+		return data, fmt.Errorf("could not fetch user: %w", err)
+	}
+
+	var candidateID uuid.UUID
+	err = orm.DB.Get(&candidateID, `SELECT id FROM candidates WHERE user_id = $1`, userID)
+	if err == nil {
+		candidate, err := GetCandidateByID(candidateID)
+		if err != nil {
+			return data, err
+		}
+		data.Candidate = &candidate
+	} else if !errors.Is(err, sql.ErrNoRows) {
+		log.Printf("Error fetching candidate for export: %v", err)
+		return data, fmt.Errorf("could not fetch candidate profile: %w", err)
+	}
+
+	var companyID uuid.UUID
+	err = orm.DB.Get(&companyID, `SELECT company_id FROM company_members WHERE user_id = $1`, userID)
+	if err == nil {
+		company, err := GetCompanyByID(companyID)
+		if err != nil {
+			return data, err
+		}
+		data.Company = &company
+	} else if !errors.Is(err, sql.ErrNoRows) {
+		log.Printf("Error fetching company for export: %v", err)
+		return data, fmt.Errorf("could not fetch company: %w", err)
+	}
+
+	data.Applications = []models.ExportedApplication{}
+	err = orm.DB.Select(&data.Applications, `
+		SELECT a.application_id, a.job_id, j.title AS job_title, co.company_name, a.status::text AS status, a.applied_at
+		FROM applications a
+		JOIN candidates c ON a.candidate_id = c.id
+		JOIN job_listings j ON a.job_id = j.id
+		JOIN companies co ON j.company_id = co.id
+		WHERE c.user_id = $1
+		ORDER BY a.applied_at
+	`, userID)
+	if err != nil {
+		log.Printf("Error fetching applications for export: %v", err)
+		return data, fmt.Errorf("could not fetch applications: %w", err)
+	}
+
+	// Candidates get whole conversations about their applications, company members what they wrote
+	data.Messages = []models.ExportedMessage{}
+	err = orm.DB.Select(&data.Messages, `
+		SELECT t.id AS thread_id, t.subject, m.id AS message_id, m.sender_role,
+		       COALESCE(m.sender_id = $1, FALSE) AS sent_by_me, m.body, m.created_at
+		FROM messages m
+		JOIN message_threads t ON m.thread_id = t.id
+		WHERE m.sender_id = $1
+		   OR t.application_id IN (
+				SELECT a.application_id FROM applications a
+				JOIN candidates c ON a.candidate_id = c.id
+				WHERE c.user_id = $1
+		   )
+		ORDER BY t.id, m.created_at
+	`, userID)
+	if err != nil {
+		log.Printf("Error fetching messages for export: %v", err)
+		return data, fmt.Errorf("could not fetch messages: %w", err)
+	}
+
+	data.Notifications = []models.Notification{}
+	err = orm.DB.Select(&data.Notifications, `SELECT * FROM notifications WHERE user_id = $1 ORDER BY created_at`, userID)
+	if err != nil {
+		log.Printf("Error fetching notifications for export: %v", err)
+		return data, fmt.Errorf("could not fetch notifications: %w", err)
+	}
+
+	data.SavedJobs = []models.ExportedSavedJob{}
+	data.JobAlerts = []models.JobAlert{}
+	if data.Candidate != nil {
+		err = orm.DB.Select(&data.SavedJobs, `
+			SELECT s.job_id, j.title AS job_title, s.note, s.created_at AS saved_at
+			FROM saved_jobs s
+			JOIN job_listings j ON s.job_id = j.id
+			WHERE s.candidate_id = $1
+			ORDER BY s.created_at
+		`, data.Candidate.ID)
+		if err != nil {
+			log.Printf("Error fetching saved jobs for export: %v", err)
+			return data, fmt.Errorf("could not fetch saved jobs: %w", err)
+		}
+
+		data.JobAlerts, err = GetJobAlerts(data.Candidate.ID)
+		if err != nil {
+			return data, err
+		}
+	}
+
+	return data, nil
+}
+
+// ScheduleAccountDeletion marks the account for erasure once purgeAfter has passed and
+// takes the candidate out of the talent pool right away.
+func ScheduleAccountDeletion(userID uuid.UUID, purgeAfter time.Time) (models.AccountDeletion, error) {
+	tx, err := orm.DB.Beginx()
+	if err != nil {
+		return models.AccountDeletion{}, fmt.Errorf("could not begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var deletion models.AccountDeletion
+	err = tx.Get(&deletion, `
+		INSERT INTO account_deletions (user_id, purge_after)
+		VALUES ($1, $2)
+		ON CONFLICT (user_id) DO UPDATE
+		SET status = 'pending', requested_at = NOW(), purge_after = EXCLUDED.purge_after, completed_at = NULL
+		WHERE account_deletions.status NOT IN ('pending', 'erasing')
+		RETURNING *
+	`, userID, purgeAfter)
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.AccountDeletion{}, fmt.Errorf("account deletion already scheduled")
+	} else if err != nil {
+		log.Printf("Error scheduling account deletion: %v", err)
+		return models.AccountDeletion{}, fmt.Errorf("could not schedule account deletion: %w", err)
+	}
+
+	if _, err := tx.Exec(`UPDATE candidates SET open_to_opportunities = FALSE WHERE user_id = $1`, userID); err != nil {
+		log.Printf("Error leaving talent pool: %v", err)
+		return models.AccountDeletion{}, fmt.Errorf("could not schedule account deletion: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return models.AccountDeletion{}, fmt.Errorf("could not commit transaction: %w", err)
+	}
+	return deletion, nil
+}
+
+func CancelAccountDeletion(userID uuid.UUID) error {
+	result, err := orm.DB.Exec(`
+		UPDATE account_deletions SET status = 'cancelled' WHERE user_id = $1 AND status = 'pending'
+	`, userID)
+	if err != nil {
+		log.Printf("Error cancelling account deletion: %v", err)
+		return fmt.Errorf("could not cancel account deletion: %w", err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return fmt.Errorf("account deletion not found")
+	}
+	return nil
+}
+
+func GetAccountDeletion(userID uuid.UUID) (models.AccountDeletion, error) {
+	var deletion models.AccountDeletion
+	err := orm.DB.Get(&deletion, `SELECT * FROM account_deletions WHERE user_id = $1`, userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.AccountDeletion{}, fmt.Errorf("account deletion not found")
+	} else if err != nil {
+		log.Printf("Error fetching account deletion: %v", err)
+		return models.AccountDeletion{}, fmt.Errorf("could not fetch account deletion: %w", err)
+	}
+	return deletion, nil
+}
+
+// GetDueAccountDeletions lists erasures whose grace period is over, including claimed ones
+// that were interrupted.
+func GetDueAccountDeletions(limit int) ([]models.AccountDeletion, error) {
+	deletions := []models.AccountDeletion{}
+	err := orm.DB.Select(&deletions, `
+		SELECT * FROM account_deletions
+		WHERE status IN ('pending', 'erasing') AND purge_after <= NOW()
+		ORDER BY purge_after LIMIT $1
+	`, limit)
+	if err != nil {
+		log.Printf("Error fetching due account deletions: %v", err)
+		return nil, fmt.Errorf("could not fetch due account deletions: %w", err)
+	}
+	return deletions, nil
+}
+
+// ClaimAccountDeletion moves a due erasure to erasing so it can no longer be cancelled, and
+// reports whether it is erasing now. Cancelled requests are left alone.
+func ClaimAccountDeletion(userID uuid.UUID) (bool, error) {
+	var status string
+	err := orm.DB.Get(&status, `
+		UPDATE account_deletions SET status = 'erasing'
+		WHERE user_id = $1 AND status IN ('pending', 'erasing') AND purge_after <= NOW()
+		RETURNING status
+	`, userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	} else if err != nil {
+		log.Printf("Error claiming account deletion: %v", err)
+		return false, fmt.Errorf("could not claim account deletion: %w", err)
+	}
+	return true, nil
+}
+
+// GetUserStorageKeys lists the stored objects that belong to a user: their resume and data exports.
+func GetUserStorageKeys(userID uuid.UUID) ([]string, error) {
+	keys := []string{}
+	err := orm.DB.Select(&keys, `
+		SELECT resume_key FROM candidates WHERE user_id = $1 AND COALESCE(resume_key, '') <> ''
+		UNION ALL
+		SELECT object_key FROM data_exports WHERE user_id = $1 AND object_key IS NOT NULL AND status = 'ready'
+	`, userID)
+	if err != nil {
+		log.Printf("Error fetching user storage keys: %v", err)
+		return nil, fmt.Errorf("could not fetch user storage keys: %w", err)
+	}
+	return keys, nil
+}
+
+// EraseAccount anonymizes what must stay behind for companies' records and deletes the user.
+// Applications remain, attached to a profile without any personal details.
+func EraseAccount(userID uuid.UUID) error {
+	tx, err := orm.DB.Beginx()
+	if err != nil {
+		return fmt.Errorf("could not begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var status string
+	err = tx.Get(&status, `SELECT status FROM account_deletions WHERE user_id = $1 FOR UPDATE`, userID)
+	if err != nil {
+		return fmt.Errorf("could not lock account deletion: %w", err)
+	}
+	if status != models.AccountDeletionErasing {
+		return fmt.Errorf("account deletion is %s, not claimed", status)
+	}
+
+	statements := []string{
+		`DELETE FROM message_attachments WHERE message_id IN (SELECT id FROM messages WHERE sender_id = $1)`,
+		`UPDATE messages SET body = '' WHERE sender_id = $1`,
+		`DELETE FROM talent_invitations WHERE candidate_id IN (SELECT id FROM candidates WHERE user_id = $1)`,
+		`DELETE FROM saved_jobs WHERE candidate_id IN (SELECT id FROM candidates WHERE user_id = $1)`,
+		`DELETE FROM job_alerts WHERE candidate_id IN (SELECT id FROM candidates WHERE user_id = $1)`,
+		`UPDATE candidates
+		 SET full_name = 'Deleted user', phone = '', location = '', linkedin_url = NULL, portfolio_url = NULL,
+		     resume_key = '', resume_text = NULL, open_to_opportunities = FALSE, user_id = NULL
+		 WHERE user_id = $1`,
+		`UPDATE companies SET user_id = NULL WHERE user_id = $1`,
+		`DELETE FROM users WHERE id = $1`,
+		`UPDATE account_deletions SET status = 'completed', completed_at = NOW() WHERE user_id = $1`,
+	}
+	for _, statement := range statements {
+		if _, err := tx.Exec(statement, userID); err != nil {
+			log.Printf("Error erasing account: %v", err)
+			return fmt.Errorf("could not erase account: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("could not commit transaction: %w", err)
+	}
+	return nil
+}
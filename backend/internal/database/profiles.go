@@ -13,9 +13,9 @@ import (
 func CreateCandidate(candidate models.CandidateRequest, userID uuid.UUID) (models.Candidate, error) {
 
 	query := `
-		INSERT INTO candidates (user_id, full_name, phone, location, linkedin_url, portfolio_url, resume_url, skills, experience_years, expected_role, current_status, created_at)
+		INSERT INTO candidates (user_id, full_name, phone, location, linkedin_url, portfolio_url, resume_key, skills, experience_years, expected_role, current_status, created_at)
 		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
-		RETURNING id, user_id, full_name, location, phone, linkedin_url, portfolio_url, resume_url, skills, experience_years, expected_role, current_status, created_at;
+		RETURNING id, user_id, full_name, location, phone, linkedin_url, portfolio_url, resume_key, skills, experience_years, expected_role, current_status, created_at;
 	`
 
 	// Preparing the data to be inserted
@@ -37,13 +37,13 @@ func CreateCandidate(candidate models.CandidateRequest, userID uuid.UUID) (model
 		candidate.Location,          // Location
 		candidate.LinkedInURL,       // LinkedIn URL
 		candidate.PortfolioURL,      // Portfolio URL
-		candidate.ResumeURL,         // Resume URL
+		candidate.ResumeKey,         // Resume object key
 		pq.StringArray(skillsArray), // Skills
 		candidate.ExperienceYears,   // Experience years
 		expectedRole,                // Expected roles (array)
 		candidate.CurrentStatus,     // Current status
 		createdAt,                   // CreatedAt timestamp
-	).Scan(&c.ID, &c.UserID, &c.FullName, &c.Location, &c.PhoneNumber, &c.LinkedInURL, &c.PortfolioURL, &c.ResumeURL, &skills, &c.Experience, &expectedRole, &c.CurrentStatus, &c.CreatedAt)
+	).Scan(&c.ID, &c.UserID, &c.FullName, &c.Location, &c.PhoneNumber, &c.LinkedInURL, &c.PortfolioURL, &c.ResumeKey, &skills, &c.Experience, &expectedRole, &c.CurrentStatus, &c.CreatedAt)
 
 	if err != nil {
 		log.Printf("Error creating candidate: %v", err)
@@ -58,11 +58,28 @@ func CreateCompany(company models.CompanyRequest, userID uuid.UUID) (models.Comp
 	query := `
 		INSERT INTO companies (user_id, company_name, company_website, company_size, industry, contact_person, contact_phone, company_description)
 		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
-		RETURNING id, company_name, company_website, company_size, industry, contact_person, contact_phone, company_description, created_at, updated_at;
+		RETURNING id;
 	`
 
+	tx, err := orm.DB.Beginx()
+	if err != nil {
+		return models.Company{}, fmt.Errorf("could not start transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// A user belongs to at most one company, whether they created it or were invited
+	var member bool
+	if err := tx.Get(&member, `SELECT EXISTS (SELECT 1 FROM company_members WHERE user_id = $1)`, userID); err != nil {
+		log.Printf("Error checking company membership: %v", err)
+		return models.Company{}, fmt.Errorf("could not create company: %w", err)
+	}
+	if member {
+		return models.Company{}, fmt.Errorf("user already belongs to a company")
+	}
+
 	// Preparing the data to be inserted
-	_, err := orm.DB.Exec(query,
+	var companyID uuid.UUID
+	err = tx.Get(&companyID, query,
 		userID,                     // User ID (foreign key)
 		company.CompanyName,        // Company name from the request
 		company.CompanyWebsite,     // Company website URL
@@ -78,8 +95,23 @@ func CreateCompany(company models.CompanyRequest, userID uuid.UUID) (models.Comp
 		return models.Company{}, fmt.Errorf("could not create company: %w", err)
 	}
 
+	// The creating user becomes the company owner
+	_, err = tx.Exec(`
+		INSERT INTO company_members (company_id, user_id, role)
+		VALUES ($1, $2, $3)
+	`, companyID, userID, models.MemberRoleOwner)
+	if err != nil {
+		log.Printf("Error adding company owner: %v", err)
+		return models.Company{}, fmt.Errorf("could not create company: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return models.Company{}, fmt.Errorf("could not create company: %w", err)
+	}
+
 	// You can return the created company details
 	return models.Company{
+		ID:                 companyID,
 		UserID:             userID,
 		CompanyName:        company.CompanyName,
 		WebsiteURL:         company.CompanyWebsite,
@@ -91,12 +123,13 @@ func CreateCompany(company models.CompanyRequest, userID uuid.UUID) (models.Comp
 	}, nil
 }
 
+// GetCandidateByID fetches a candidate profile. Profiles of erased users have a nil UserID.
 func GetCandidateByID(candidateID uuid.UUID) (models.Candidate, error) {
 	var candidate models.Candidate
 
 	query := `
-		SELECT id, user_id, full_name, location, phone, linkedin_url, portfolio_url, resume_url,
-		       skills, experience_years, expected_role, current_status, created_at
+		SELECT id, COALESCE(user_id, '00000000-0000-0000-0000-000000000000') AS user_id, full_name, location, phone, linkedin_url, portfolio_url, resume_key,
+		       skills, experience_years, expected_role, current_status, created_at, open_to_opportunities
 		FROM candidates
 		WHERE id = $1
 	`
@@ -114,7 +147,7 @@ func GetCompanyByID(companyID uuid.UUID) (models.Company, error) {
 	var company models.Company
 
 	query := `
-		SELECT id, user_id, company_name, company_website, company_size, industry,
+		SELECT id, COALESCE(user_id, '00000000-0000-0000-0000-000000000000') AS user_id, company_name, company_website, company_size, industry,
 		       contact_person, contact_phone, company_description, created_at
 		FROM companies
 		WHERE id = $1
@@ -0,0 +1,208 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/hridaya14/Web-Tech-Project/internal/models"
+	"github.com/hridaya14/Web-Tech-Project/pkg/orm"
+)
+
+// reportView describes the materialized view behind a report. dateColumn, when set, is the
+// date column from/to filter on.
+type reportView struct {
+	view        string
+	description string
+	columns     []string
+	dateColumn  string
+	orderBy     string
+}
+
+// reportViews lists the reports in the order admins see them.
+var reportViews = []struct {
+	name string
+	reportView
+}{
+	{models.ReportSignups, reportView{
+		view:        "report_signups_daily",
+		description: "Signups per day by role",
+		columns:     []string{"day", "role", "signups"},
+		dateColumn:  "day",
+		orderBy:     "day, role",
+	}},
+	{models.ReportOnboarding, reportView{
+		view:        "report_onboarding",
+		description: "Onboarding status and completion rate by role",
+		columns:     []string{"role", "users", "not_started", "in_progress", "completed", "completion_rate"},
+		orderBy:     "role",
+	}},
+	{models.ReportListings, reportView{
+		view:        "report_listings_daily",
+		description: "Job listings created and closed per day",
+		columns:     []string{"day", "created", "closed"},
+		dateColumn:  "day",
+		orderBy:     "day",
+	}},
+	{models.ReportApplicationsPerListing, reportView{
+		view:        "report_applications_per_listing",
+		description: "Applications per job listing, by the day the listing was created",
+		columns:     []string{"listing_id", "title", "company_name", "day", "closed_at", "applications"},
+		dateColumn:  "day",
+		orderBy:     "applications DESC, day DESC, listing_id",
+	}},
+	{models.ReportSkills, reportView{
+		view:        "report_skills",
+		description: "Skills required by open listings against candidates offering them",
+		columns:     []string{"skill", "demand", "supply"},
+		orderBy:     "demand DESC, supply DESC, skill",
+	}},
+}
+
+func findReportView(name string) (reportView, bool) {
+	for _, r := range reportViews {
+		if r.name == name {
+			return r.reportView, true
+		}
+	}
+	return reportView{}, false
+}
+
+// GetReports lists every report with when its view was last refreshed.
+func GetReports() ([]models.ReportInfo, error) {
+	refreshed, err := getReportRefreshes()
+	if err != nil {
+		return nil, err
+	}
+
+	reports := make([]models.ReportInfo, 0, len(reportViews))
+	for _, r := range reportViews {
+		reports = append(reports, reportInfo(r.name, r.reportView, refreshed))
+	}
+	return reports, nil
+}
+
+func reportInfo(name string, r reportView, refreshed map[string]time.Time) models.ReportInfo {
+	info := models.ReportInfo{
+		Name:        name,
+		Description: r.description,
+		Columns:     r.columns,
+		Dated:       r.dateColumn != "",
+	}
+	if at, ok := refreshed[r.view]; ok {
+		info.RefreshedAt = &at
+	}
+	return info
+}
+
+func getReportRefreshes() (map[string]time.Time, error) {
+	refreshes := []models.ReportRefresh{}
+	if err := orm.DB.Select(&refreshes, `SELECT view_name, refreshed_at, duration_ms FROM report_refreshes`); err != nil {
+		log.Printf("Error fetching report refreshes: %v", err)
+		return nil, fmt.Errorf("could not fetch report refreshes: %w", err)
+	}
+
+	refreshed := make(map[string]time.Time, len(refreshes))
+	for _, r := range refreshes {
+		refreshed[r.ViewName] = r.RefreshedAt
+	}
+	return refreshed, nil
+}
+
+// QueryReport reads a report's rows as of its last refresh. from and to bound dated reports
+// by day, inclusive; other reports ignore them.
+func QueryReport(name string, from, to *time.Time) (models.ReportTable, error) {
+	r, ok := findReportView(name)
+	if !ok {
+		return models.ReportTable{}, errors.New("report not found")
+	}
+
+	refreshed, err := getReportRefreshes()
+	if err != nil {
+		return models.ReportTable{}, err
+	}
+
+	query := `SELECT ` + strings.Join(r.columns, ", ") + ` FROM ` + r.view + ` WHERE TRUE`
+	args := []any{}
+	if r.dateColumn != "" && from != nil {
+		query += ` AND ` + r.dateColumn + ` >= ?::date`
+		args = append(args, from.Format(time.DateOnly))
+	}
+	if r.dateColumn != "" && to != nil {
+		query += ` AND ` + r.dateColumn + ` <= ?::date`
+		args = append(args, to.Format(time.DateOnly))
+	}
+	query += ` ORDER BY ` + r.orderBy
+
+	rows, err := orm.DB.Queryx(orm.DB.Rebind(query), args...)
+	if err != nil {
+		log.Printf("Error querying report %s: %v", name, err)
+		return models.ReportTable{}, fmt.Errorf("could not query report: %w", err)
+	}
+	defer rows.Close()
+
+	table := models.ReportTable{ReportInfo: reportInfo(name, r, refreshed), Rows: [][]any{}}
+	for rows.Next() {
+		values, err := rows.SliceScan()
+		if err != nil {
+			log.Printf("Error scanning report %s: %v", name, err)
+			return models.ReportTable{}, fmt.Errorf("could not scan report: %w", err)
+		}
+		for i, value := range values {
+			values[i] = reportValue(r.columns[i] == r.dateColumn, value)
+		}
+		table.Rows = append(table.Rows, values)
+	}
+	if err := rows.Err(); err != nil {
+		log.Printf("Error reading report %s: %v", name, err)
+		return models.ReportTable{}, fmt.Errorf("could not read report: %w", err)
+	}
+	return table, nil
+}
+
+// reportValue turns a scanned column into something JSON and CSV render alike.
+func reportValue(isDate bool, value any) any {
+	switch v := value.(type) {
+	case []byte:
+		return string(v)
+	case time.Time:
+		if isDate {
+			return v.Format(time.DateOnly)
+		}
+		return v.UTC().Format(time.RFC3339)
+	}
+	return value
+}
+
+// RefreshReportViews rebuilds every report view without blocking readers and records how
+// long each took. A failing view does not stop the rest.
+func RefreshReportViews(ctx context.Context) error {
+	var errs []error
+	for _, r := range reportViews {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		started := time.Now()
+		if _, err := orm.DB.ExecContext(ctx, `REFRESH MATERIALIZED VIEW CONCURRENTLY `+r.view); err != nil {
+			log.Printf("Error refreshing report view %s: %v", r.view, err)
+			errs = append(errs, fmt.Errorf("could not refresh %s: %w", r.view, err))
+			continue
+		}
+
+		_, err := orm.DB.ExecContext(ctx, `
+			INSERT INTO report_refreshes (view_name, refreshed_at, duration_ms)
+			VALUES ($1, NOW(), $2)
+			ON CONFLICT (view_name) DO UPDATE
+			SET refreshed_at = EXCLUDED.refreshed_at, duration_ms = EXCLUDED.duration_ms
+		`, r.view, time.Since(started).Milliseconds())
+		if err != nil {
+			log.Printf("Error recording report refresh: %v", err)
+			errs = append(errs, fmt.Errorf("could not record refresh of %s: %w", r.view, err))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,73 @@
+package database
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/google/uuid"
+	"github.com/hridaya14/Web-Tech-Project/internal/models"
+	"github.com/hridaya14/Web-Tech-Project/pkg/orm"
+)
+
+// CandidateAppliedToCompany reports whether the candidate has an application to any listing of the company
+func CandidateAppliedToCompany(candidateID, companyID uuid.UUID) (bool, error) {
+	var applied bool
+	err := orm.DB.Get(&applied, `
+		SELECT EXISTS (
+			SELECT 1 FROM applications a
+			JOIN job_listings j ON a.job_id = j.id
+			WHERE a.candidate_id = $1 AND j.company_id = $2
+		)
+	`, candidateID, companyID)
+	if err != nil {
+		log.Printf("Error checking candidate applications: %v", err)
+		return false, fmt.Errorf("could not check candidate applications: %w", err)
+	}
+	return applied, nil
+}
+
+// CandidateInvitedByCompany reports whether the company invited the candidate from the talent pool.
+func CandidateInvitedByCompany(candidateID, companyID uuid.UUID) (bool, error) {
+	var invited bool
+	err := orm.DB.Get(&invited, `
+		SELECT EXISTS (SELECT 1 FROM talent_invitations WHERE candidate_id = $1 AND company_id = $2)
+	`, candidateID, companyID)
+	if err != nil {
+		log.Printf("Error checking talent invitations: %v", err)
+		return false, fmt.Errorf("could not check talent invitations: %w", err)
+	}
+	return invited, nil
+}
+
+func LogResumeAccess(access models.ResumeAccess) error {
+	_, err := orm.DB.Exec(`
+		INSERT INTO resume_access_log (candidate_id, user_id, company_id, reason, ip_address, user_agent)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`, access.CandidateID, access.UserID, access.CompanyID, access.Reason, access.IPAddress, access.UserAgent)
+	if err != nil {
+		log.Printf("Error logging resume access: %v", err)
+		return fmt.Errorf("could not log resume access: %w", err)
+	}
+	return nil
+}
+
+// GetResumeAccessLog lists who was handed links to the candidate's resume, newest first
+func GetResumeAccessLog(candidateID uuid.UUID, limit int) ([]models.ResumeAccess, error) {
+	var entries []models.ResumeAccess
+	err := orm.DB.Select(&entries, `
+		SELECT l.id, l.candidate_id, l.user_id, l.company_id, l.reason,
+		       COALESCE(l.ip_address, '') AS ip_address,
+		       COALESCE(l.user_agent, '') AS user_agent,
+		       l.created_at, co.company_name
+		FROM resume_access_log l
+		LEFT JOIN companies co ON l.company_id = co.id
+		WHERE l.candidate_id = $1
+		ORDER BY l.created_at DESC
+		LIMIT $2
+	`, candidateID, limit)
+	if err != nil {
+		log.Printf("Error fetching resume access log: %v", err)
+		return nil, fmt.Errorf("could not fetch resume access log: %w", err)
+	}
+	return entries, nil
+}
@@ -0,0 +1,167 @@
+package database
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hridaya14/Web-Tech-Project/internal/models"
+	"github.com/hridaya14/Web-Tech-Project/pkg/orm"
+	"github.com/jmoiron/sqlx/types"
+)
+
+// RecordLogin stamps the user's last login, which retention rules treat as activity.
+func RecordLogin(userID uuid.UUID) error {
+	_, err := orm.DB.Exec(`UPDATE users SET last_login_at = NOW() WHERE id = $1`, userID)
+	if err != nil {
+		log.Printf("Error recording login: %v", err)
+		return fmt.Errorf("could not record login: %w", err)
+	}
+	return nil
+}
+
+// FindRejectedResumes lists candidates still holding a resume whose applications were all
+// rejected before cutoff and who have not logged in since. Talent pool members keep theirs.
+func FindRejectedResumes(cutoff time.Time, limit int) ([]models.RetentionSubject, error) {
+	subjects := []models.RetentionSubject{}
+	err := orm.DB.Select(&subjects, `
+		SELECT c.id AS candidate_id, c.user_id, c.resume_key,
+		       MAX(COALESCE(h.rejected_at, a.applied_at)) AS last_activity_at
+		FROM candidates c
+		JOIN applications a ON a.candidate_id = c.id
+		LEFT JOIN users u ON c.user_id = u.id
+		LEFT JOIN LATERAL (
+			SELECT MAX(changed_at) AS rejected_at
+			FROM application_status_history
+			WHERE application_id = a.application_id AND new_status = 'rejected'
+		) h ON TRUE
+		WHERE COALESCE(c.resume_key, '') <> ''
+		  AND NOT c.open_to_opportunities
+		  AND COALESCE(u.last_login_at, u.created_at, '-infinity') < $1
+		GROUP BY c.id, c.user_id, c.resume_key
+		HAVING BOOL_AND(a.status::text = 'rejected')
+		   AND MAX(COALESCE(h.rejected_at, a.applied_at)) < $1
+		ORDER BY last_activity_at
+		LIMIT $2
+	`, cutoff, limit)
+	if err != nil {
+		log.Printf("Error finding rejected resumes: %v", err)
+		return nil, fmt.Errorf("could not find rejected resumes: %w", err)
+	}
+	return subjects, nil
+}
+
+// FindInactiveCandidates lists candidate accounts with no login or application since cutoff.
+func FindInactiveCandidates(cutoff time.Time, limit int) ([]models.RetentionSubject, error) {
+	subjects := []models.RetentionSubject{}
+	err := orm.DB.Select(&subjects, `
+		SELECT c.id AS candidate_id, c.user_id, COALESCE(c.resume_key, '') AS resume_key,
+		       GREATEST(COALESCE(u.last_login_at, u.created_at), COALESCE(MAX(a.applied_at), '-infinity')) AS last_activity_at
+		FROM candidates c
+		JOIN users u ON c.user_id = u.id
+		LEFT JOIN applications a ON a.candidate_id = c.id
+		WHERE NOT EXISTS (
+			SELECT 1 FROM account_deletions d WHERE d.user_id = u.id AND d.status IN ('pending', 'erasing')
+		)
+		GROUP BY c.id, c.user_id, c.resume_key, u.last_login_at, u.created_at
+		HAVING GREATEST(COALESCE(u.last_login_at, u.created_at), COALESCE(MAX(a.applied_at), '-infinity')) < $1
+		ORDER BY last_activity_at
+		LIMIT $2
+	`, cutoff, limit)
+	if err != nil {
+		log.Printf("Error finding inactive candidates: %v", err)
+		return nil, fmt.Errorf("could not find inactive candidates: %w", err)
+	}
+	return subjects, nil
+}
+
+// ClearCandidateResume forgets the candidate's resume, unless it was replaced meanwhile.
+func ClearCandidateResume(candidateID uuid.UUID, resumeKey string) error {
+	_, err := orm.DB.Exec(`
+		UPDATE candidates SET resume_key = '', resume_text = NULL WHERE id = $1 AND resume_key = $2
+	`, candidateID, resumeKey)
+	if err != nil {
+		log.Printf("Error clearing candidate resume: %v", err)
+		return fmt.Errorf("could not clear candidate resume: %w", err)
+	}
+	return nil
+}
+
+func CreateRetentionRun(dryRun bool, rules types.JSONText) (models.RetentionRun, error) {
+	var run models.RetentionRun
+	err := orm.DB.Get(&run, `
+		INSERT INTO retention_runs (dry_run, rules) VALUES ($1, $2) RETURNING *
+	`, dryRun, rules)
+	if err != nil {
+		log.Printf("Error creating retention run: %v", err)
+		return models.RetentionRun{}, fmt.Errorf("could not create retention run: %w", err)
+	}
+	return run, nil
+}
+
+func FinishRetentionRun(runID uuid.UUID, summary types.JSONText, runErr error) error {
+	status := models.RetentionCompleted
+	var reason *string
+	if runErr != nil {
+		status = models.RetentionFailed
+		message := runErr.Error()
+		reason = &message
+	}
+
+	_, err := orm.DB.Exec(`
+		UPDATE retention_runs SET status = $2, summary = $3, error = $4, finished_at = NOW() WHERE id = $1
+	`, runID, status, summary, reason)
+	if err != nil {
+		log.Printf("Error finishing retention run: %v", err)
+		return fmt.Errorf("could not finish retention run: %w", err)
+	}
+	return nil
+}
+
+func RecordRetentionAction(action models.RetentionAction) (models.RetentionAction, error) {
+	err := orm.DB.Get(&action, `
+		INSERT INTO retention_actions (run_id, rule, action, candidate_id, user_id, last_activity_at, outcome, detail)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING *
+	`, action.RunID, action.Rule, action.Action, action.CandidateID, action.UserID, action.LastActivityAt, action.Outcome, action.Detail)
+	if err != nil {
+		log.Printf("Error recording retention action: %v", err)
+		return action, fmt.Errorf("could not record retention action: %w", err)
+	}
+	return action, nil
+}
+
+func GetRetentionRuns(limit int) ([]models.RetentionRun, error) {
+	runs := []models.RetentionRun{}
+	err := orm.DB.Select(&runs, `SELECT * FROM retention_runs ORDER BY started_at DESC LIMIT $1`, limit)
+	if err != nil {
+		log.Printf("Error fetching retention runs: %v", err)
+		return nil, fmt.Errorf("could not fetch retention runs: %w", err)
+	}
+	return runs, nil
+}
+
+// GetRetentionRun fetches a run with every action it recorded
+func GetRetentionRun(runID uuid.UUID) (models.RetentionRun, error) {
+	var run models.RetentionRun
+	err := orm.DB.Get(&run, `SELECT * FROM retention_runs WHERE id = $1`, runID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.RetentionRun{}, fmt.Errorf("retention run not found")
+	} else if err != nil {
+		log.Printf("Error fetching retention run: %v", err)
+		return models.RetentionRun{}, fmt.Errorf("could not fetch retention run: %w", err)
+	}
+
+	run.Actions = []models.RetentionAction{}
+	err = orm.DB.Select(&run.Actions, `
+		SELECT * FROM retention_actions WHERE run_id = $1 ORDER BY created_at
+	`, runID)
+	if err != nil {
+		log.Printf("Error fetching retention actions: %v", err)
+		return models.RetentionRun{}, fmt.Errorf("could not fetch retention actions: %w", err)
+	}
+	return run, nil
+}
@@ -0,0 +1,218 @@
+package database
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/hridaya14/Web-Tech-Project/internal/models"
+	"github.com/hridaya14/Web-Tech-Project/pkg/orm"
+	"github.com/jmoiron/sqlx"
+)
+
+// ApplicationBelongsToCompany reports whether the application was made to one of the company's listings.
+func ApplicationBelongsToCompany(applicationID, companyID uuid.UUID) (bool, error) {
+	var exists bool
+	query := `
+		SELECT EXISTS (
+			SELECT 1 FROM applications a
+			JOIN job_listings j ON a.job_id = j.id
+			WHERE a.application_id = $1 AND j.company_id = $2
+		)
+	`
+	err := orm.DB.Get(&exists, query, applicationID, companyID)
+	if err != nil {
+		log.Printf("Error checking application company: %v", err)
+		return false, fmt.Errorf("could not verify application ownership: %w", err)
+	}
+	return exists, nil
+}
+
+func CreateApplicationNote(applicationID, companyID, authorID uuid.UUID, body string) (models.ApplicationNote, error) {
+	query := `
+		INSERT INTO application_notes (application_id, company_id, author_id, body)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, application_id, company_id, author_id, body, created_at, updated_at
+	`
+	var note models.ApplicationNote
+	err := orm.DB.Get(&note, query, applicationID, companyID, authorID, body)
+	if err != nil {
+		log.Printf("Error creating application note: %v", err)
+		return models.ApplicationNote{}, fmt.Errorf("could not create note: %w", err)
+	}
+	return note, nil
+}
+
+func DeleteApplicationNote(noteID, companyID, authorID uuid.UUID) error {
+	query := `DELETE FROM application_notes WHERE id = $1 AND company_id = $2 AND author_id = $3`
+	result, err := orm.DB.Exec(query, noteID, companyID, authorID)
+	if err != nil {
+		log.Printf("Error deleting application note: %v", err)
+		return fmt.Errorf("could not delete note: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("could not verify note deletion: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("note not found")
+	}
+	return nil
+}
+
+// UpsertApplicationRating records a reviewer's rating, replacing any earlier rating by the same reviewer.
+func UpsertApplicationRating(applicationID, companyID, reviewerID uuid.UUID, rating int) (models.ApplicationRating, error) {
+	query := `
+		INSERT INTO application_ratings (application_id, company_id, reviewer_id, rating)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (application_id, reviewer_id)
+		DO UPDATE SET rating = EXCLUDED.rating, updated_at = NOW()
+		RETURNING application_id, company_id, reviewer_id, rating, created_at, updated_at
+	`
+	var r models.ApplicationRating
+	err := orm.DB.Get(&r, query, applicationID, companyID, reviewerID, rating)
+	if err != nil {
+		log.Printf("Error saving application rating: %v", err)
+		return models.ApplicationRating{}, fmt.Errorf("could not save rating: %w", err)
+	}
+	return r, nil
+}
+
+func AddApplicationTags(applicationID, companyID, userID uuid.UUID, tags []string) error {
+	tx, err := orm.DB.Beginx()
+	if err != nil {
+		return fmt.Errorf("could not start transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO application_tags (application_id, company_id, tag, created_by)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (application_id, tag) DO NOTHING
+	`
+	for _, tag := range tags {
+		if _, err := tx.Exec(query, applicationID, companyID, tag, userID); err != nil {
+			log.Printf("Error adding application tag: %v", err)
+			return fmt.Errorf("could not add tag: %w", err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+func RemoveApplicationTag(applicationID, companyID uuid.UUID, tag string) error {
+	query := `DELETE FROM application_tags WHERE application_id = $1 AND company_id = $2 AND tag = $3`
+	result, err := orm.DB.Exec(query, applicationID, companyID, tag)
+	if err != nil {
+		log.Printf("Error removing application tag: %v", err)
+		return fmt.Errorf("could not remove tag: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("could not verify tag removal: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("tag not found")
+	}
+	return nil
+}
+
+// NormalizeTags lowercases and trims tags, dropping empties and duplicates.
+func NormalizeTags(tags []string) []string {
+	seen := make(map[string]bool)
+	out := []string{}
+	for _, t := range tags {
+		t = strings.ToLower(strings.TrimSpace(t))
+		if t == "" || seen[t] {
+			continue
+		}
+		seen[t] = true
+		out = append(out, t)
+	}
+	return out
+}
+
+// attachReviews loads the company's notes, ratings and tags for the given applications.
+func attachReviews(apps []models.AppWithCandidate, companyID uuid.UUID) error {
+	if len(apps) == 0 {
+		return nil
+	}
+
+	ids := make([]interface{}, len(apps))
+	index := make(map[uuid.UUID]int, len(apps))
+	for i, app := range apps {
+		ids[i] = app.ApplicationID
+		index[app.ApplicationID] = i
+		apps[i].Notes = []models.ApplicationNote{}
+		apps[i].Ratings = []models.ApplicationRating{}
+		apps[i].Tags = []string{}
+	}
+
+	var notes []models.ApplicationNote
+	if err := selectIn(&notes, `
+		SELECT n.id, n.application_id, n.company_id, n.author_id, u.username AS author_name,
+		       n.body, n.created_at, n.updated_at
+		FROM application_notes n
+		JOIN users u ON n.author_id = u.id
+		WHERE n.company_id = ? AND n.application_id IN (?)
+		ORDER BY n.created_at
+	`, companyID, ids); err != nil {
+		return fmt.Errorf("error fetching notes: %w", err)
+	}
+	for _, n := range notes {
+		i := index[n.ApplicationID]
+		apps[i].Notes = append(apps[i].Notes, n)
+	}
+
+	var ratings []models.ApplicationRating
+	if err := selectIn(&ratings, `
+		SELECT r.application_id, r.company_id, r.reviewer_id, u.username AS reviewer_name,
+		       r.rating, r.created_at, r.updated_at
+		FROM application_ratings r
+		JOIN users u ON r.reviewer_id = u.id
+		WHERE r.company_id = ? AND r.application_id IN (?)
+	`, companyID, ids); err != nil {
+		return fmt.Errorf("error fetching ratings: %w", err)
+	}
+	for _, r := range ratings {
+		i := index[r.ApplicationID]
+		apps[i].Ratings = append(apps[i].Ratings, r)
+	}
+	for i := range apps {
+		if len(apps[i].Ratings) == 0 {
+			continue
+		}
+		total := 0
+		for _, r := range apps[i].Ratings {
+			total += r.Rating
+		}
+		apps[i].AverageRating = float64(total) / float64(len(apps[i].Ratings))
+	}
+
+	var tags []models.ApplicationTag
+	if err := selectIn(&tags, `
+		SELECT application_id, tag FROM application_tags
+		WHERE company_id = ? AND application_id IN (?)
+		ORDER BY tag
+	`, companyID, ids); err != nil {
+		return fmt.Errorf("error fetching tags: %w", err)
+	}
+	for _, t := range tags {
+		i := index[t.ApplicationID]
+		apps[i].Tags = append(apps[i].Tags, t.Tag)
+	}
+
+	return nil
+}
+
+// selectIn expands IN (?) placeholders with sqlx.In and runs the query.
+func selectIn(dest interface{}, query string, args ...interface{}) error {
+	query, args, err := sqlx.In(query, args...)
+	if err != nil {
+		return fmt.Errorf("error building query: %w", err)
+	}
+	return orm.DB.Select(dest, orm.DB.Rebind(query), args...)
+}
@@ -0,0 +1,89 @@
+package database
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hridaya14/Web-Tech-Project/internal/models"
+	"github.com/hridaya14/Web-Tech-Project/pkg/orm"
+)
+
+// SaveJob bookmarks an open listing, or replaces the note of one already saved.
+func SaveJob(candidateID, jobID uuid.UUID, note string) error {
+	result, err := orm.DB.Exec(`
+		INSERT INTO saved_jobs (candidate_id, job_id, note)
+		SELECT $1, id, $3 FROM job_listings
+		WHERE id = $2 AND (
+			(closed_at IS NULL AND (closes_at IS NULL OR closes_at > NOW()))
+			OR EXISTS (SELECT 1 FROM saved_jobs WHERE candidate_id = $1 AND job_id = $2)
+		)
+		ON CONFLICT (candidate_id, job_id) DO UPDATE SET note = EXCLUDED.note, updated_at = NOW()
+	`, candidateID, jobID, note)
+	if err != nil {
+		log.Printf("Error saving job: %v", err)
+		return fmt.Errorf("could not save job: %w", err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return fmt.Errorf("job listing not found")
+	}
+	return nil
+}
+
+func UnsaveJob(candidateID, jobID uuid.UUID) error {
+	result, err := orm.DB.Exec(`DELETE FROM saved_jobs WHERE candidate_id = $1 AND job_id = $2`, candidateID, jobID)
+	if err != nil {
+		log.Printf("Error removing saved job: %v", err)
+		return fmt.Errorf("could not remove saved job: %w", err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return fmt.Errorf("saved job not found")
+	}
+	return nil
+}
+
+// GetSavedJobs lists the candidate's bookmarks, most recently saved first. Listings closed
+// since they were saved stay in the list so the candidate can see what happened to them.
+func GetSavedJobs(candidateID uuid.UUID) ([]models.SavedJob, error) {
+	saved := []models.SavedJob{}
+	err := orm.DB.Select(&saved, `
+		SELECT j.*, s.note, s.created_at AS saved_at,
+			EXISTS (
+				SELECT 1 FROM applications a WHERE a.candidate_id = s.candidate_id AND a.job_id = j.id
+			) AS applied
+		FROM saved_jobs s
+		JOIN job_listings j ON j.id = s.job_id
+		WHERE s.candidate_id = $1
+		ORDER BY s.created_at DESC
+	`, candidateID)
+	if err != nil {
+		log.Printf("Error fetching saved jobs: %v", err)
+		return nil, fmt.Errorf("could not fetch saved jobs: %w", err)
+	}
+	return saved, nil
+}
+
+// ClaimSavedJobsClosingSoon marks and returns saved open listings whose deadline falls within
+// window, skipping jobs the candidate already applied to. Each bookmark is returned once.
+func ClaimSavedJobsClosingSoon(window time.Duration) ([]models.SavedJobClosing, error) {
+	closing := []models.SavedJobClosing{}
+	err := orm.DB.Select(&closing, `
+		UPDATE saved_jobs s SET closing_reminded_at = NOW()
+		FROM job_listings j, candidates c
+		WHERE s.job_id = j.id AND c.id = s.candidate_id
+		AND c.user_id IS NOT NULL
+		AND s.closing_reminded_at IS NULL
+		AND j.closed_at IS NULL
+		AND j.closes_at > NOW() AND j.closes_at <= NOW() + $1 * INTERVAL '1 second'
+		AND NOT EXISTS (
+			SELECT 1 FROM applications a WHERE a.candidate_id = s.candidate_id AND a.job_id = j.id
+		)
+		RETURNING c.user_id AS candidate_user_id, j.id AS job_id, j.title AS job_title, j.closes_at
+	`, window.Seconds())
+	if err != nil {
+		log.Printf("Error claiming closing saved jobs: %v", err)
+		return nil, fmt.Errorf("could not claim closing saved jobs: %w", err)
+	}
+	return closing, nil
+}
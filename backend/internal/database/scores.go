@@ -0,0 +1,69 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+
+	"github.com/google/uuid"
+	"github.com/hridaya14/Web-Tech-Project/internal/models"
+	"github.com/hridaya14/Web-Tech-Project/pkg/orm"
+)
+
+const scoringInputQuery = `
+	SELECT a.application_id, a.candidate_id, a.job_id,
+		c.skills, c.experience_years, COALESCE(c.expected_role::text, '') AS expected_role
+	FROM applications a
+	JOIN candidates c ON a.candidate_id = c.id
+`
+
+// GetScoringInput loads an application together with its candidate's profile.
+func GetScoringInput(applicationID uuid.UUID) (models.ScoringInput, error) {
+	var input models.ScoringInput
+	err := orm.DB.Get(&input, scoringInputQuery+` WHERE a.application_id = $1`, applicationID)
+	if err != nil {
+		log.Printf("Error fetching application for scoring: %v", err)
+		return models.ScoringInput{}, fmt.Errorf("could not fetch application: %w", err)
+	}
+	return input, nil
+}
+
+// GetListingScoringInputs loads every application of a listing together with its candidate's profile.
+func GetListingScoringInputs(listingID uuid.UUID) ([]models.ScoringInput, error) {
+	inputs := []models.ScoringInput{}
+	err := orm.DB.Select(&inputs, scoringInputQuery+` WHERE a.job_id = $1`, listingID)
+	if err != nil {
+		log.Printf("Error fetching applications for scoring: %v", err)
+		return nil, fmt.Errorf("could not fetch applications: %w", err)
+	}
+	return inputs, nil
+}
+
+func UpsertApplicationScore(score models.ApplicationScore) error {
+	_, err := orm.DB.Exec(`
+		INSERT INTO application_scores (application_id, score, explanation, source, scored_at)
+		VALUES ($1, $2, $3, $4, NOW())
+		ON CONFLICT (application_id)
+		DO UPDATE SET score = EXCLUDED.score, explanation = EXCLUDED.explanation,
+			source = EXCLUDED.source, scored_at = EXCLUDED.scored_at
+	`, score.ApplicationID, score.Score, score.Explanation, score.Source)
+	if err != nil {
+		log.Printf("Error saving application score: %v", err)
+		return fmt.Errorf("could not save application score: %w", err)
+	}
+	return nil
+}
+
+// GetApplicationScore returns the stored score of the application, or nil if it has not been scored yet.
+func GetApplicationScore(applicationID uuid.UUID) (*models.ApplicationScore, error) {
+	var score models.ApplicationScore
+	err := orm.DB.Get(&score, `SELECT * FROM application_scores WHERE application_id = $1`, applicationID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		log.Printf("Error fetching application score: %v", err)
+		return nil, fmt.Errorf("could not fetch application score: %w", err)
+	}
+	return &score, nil
+}
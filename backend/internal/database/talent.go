@@ -0,0 +1,218 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+
+	"github.com/google/uuid"
+	"github.com/hridaya14/Web-Tech-Project/internal/matching"
+	"github.com/hridaya14/Web-Tech-Project/internal/models"
+	"github.com/hridaya14/Web-Tech-Project/pkg/orm"
+	"github.com/jmoiron/sqlx"
+)
+
+// SetCandidateResumeText stores the text of the candidate's resume for searching.
+func SetCandidateResumeText(candidateID uuid.UUID, text string) error {
+	_, err := orm.DB.Exec(`UPDATE candidates SET resume_text = $2 WHERE id = $1`, candidateID, text)
+	if err != nil {
+		log.Printf("Error storing resume text: %v", err)
+		return fmt.Errorf("could not store resume text: %w", err)
+	}
+	return nil
+}
+
+func SetOpenToOpportunities(candidateID uuid.UUID, open bool) error {
+	_, err := orm.DB.Exec(`UPDATE candidates SET open_to_opportunities = $2 WHERE id = $1`, candidateID, open)
+	if err != nil {
+		log.Printf("Error updating talent pool opt-in: %v", err)
+		return fmt.Errorf("could not update candidate: %w", err)
+	}
+	return nil
+}
+
+// SearchTalentPool finds candidates open to opportunities. Contact details are only
+// filled in for candidates the company invited or who applied to it.
+func SearchTalentPool(companyID uuid.UUID, filters models.TalentSearchFilters) ([]models.TalentProfile, error) {
+	baseQuery := `
+		SELECT
+			c.id AS candidate_id,
+			c.full_name,
+			c.location,
+			c.skills,
+			c.experience_years,
+			COALESCE(c.expected_role::text, '') AS expected_role,
+			COALESCE(c.current_status::text, '') AS current_status,
+			u.email,
+			c.phone,
+			c.linkedin_url,
+			c.portfolio_url,
+			(
+				EXISTS (SELECT 1 FROM talent_invitations ti WHERE ti.candidate_id = c.id AND ti.company_id = ?)
+				OR EXISTS (
+					SELECT 1 FROM applications a
+					JOIN job_listings j ON a.job_id = j.id
+					WHERE a.candidate_id = c.id AND j.company_id = ?
+				)
+			) AS contact_visible
+		FROM candidates c
+		JOIN users u ON c.user_id = u.id
+		WHERE c.open_to_opportunities
+	`
+	args := []interface{}{companyID, companyID}
+
+	// Every requested skill must be listed, under any of its spellings
+	for _, skill := range filters.Skills {
+		baseQuery += ` AND EXISTS (
+			SELECT 1 FROM unnest(c.skills) s WHERE LOWER(TRIM(s)) IN (?)
+		)`
+		args = append(args, matching.SkillVariants(skill))
+	}
+
+	if filters.MinExperience != nil {
+		baseQuery += " AND c.experience_years >= ?"
+		args = append(args, *filters.MinExperience)
+	}
+	if filters.MaxExperience != nil {
+		baseQuery += " AND c.experience_years <= ?"
+		args = append(args, *filters.MaxExperience)
+	}
+	if filters.Location != "" {
+		baseQuery += " AND c.location ILIKE '%' || ? || '%'"
+		args = append(args, filters.Location)
+	}
+	if filters.Status != "" {
+		baseQuery += " AND c.current_status = ?"
+		args = append(args, filters.Status)
+	}
+	if filters.Query != "" {
+		baseQuery += " AND c.resume_search @@ websearch_to_tsquery('english', ?)"
+		args = append(args, filters.Query)
+	}
+	if filters.ExpectedRole != "" {
+		baseQuery += " AND c.expected_role::text ILIKE '%' || ? || '%'"
+		args = append(args, filters.ExpectedRole)
+	}
+
+	baseQuery += " ORDER BY c.created_at DESC LIMIT ? OFFSET ?"
+	args = append(args, filters.Limit, filters.Offset)
+
+	query, args, err := sqlx.In(baseQuery, args...)
+	if err != nil {
+		return nil, fmt.Errorf("error building query: %w", err)
+	}
+	query = orm.DB.Rebind(query)
+
+	profiles := []models.TalentProfile{}
+	if err := orm.DB.Select(&profiles, query, args...); err != nil {
+		log.Printf("Error searching talent pool: %v", err)
+		return nil, fmt.Errorf("could not search talent pool: %w", err)
+	}
+
+	for i := range profiles {
+		if !profiles[i].ContactVisible {
+			redactContact(&profiles[i])
+		}
+	}
+	return profiles, nil
+}
+
+func redactContact(p *models.TalentProfile) {
+	p.Email = nil
+	p.Phone = nil
+	p.LinkedInURL = nil
+	p.PortfolioURL = nil
+}
+
+// CreateTalentInvitation invites a talent pool candidate to apply to an open listing of the company
+// and queues the invitation email with it.
+func CreateTalentInvitation(companyID, candidateID, jobID, invitedBy uuid.UUID, message string) (models.TalentInvitation, error) {
+	tx, err := orm.DB.Beginx()
+	if err != nil {
+		return models.TalentInvitation{}, fmt.Errorf("could not start transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var candidate struct {
+		UserID   uuid.UUID `db:"user_id"`
+		FullName string    `db:"full_name"`
+	}
+	err = tx.Get(&candidate, `
+		SELECT user_id, full_name FROM candidates WHERE id = $1 AND open_to_opportunities
+	`, candidateID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return models.TalentInvitation{}, fmt.Errorf("candidate not found")
+		}
+		log.Printf("Error fetching candidate to invite: %v", err)
+		return models.TalentInvitation{}, fmt.Errorf("could not fetch candidate: %w", err)
+	}
+
+	var listing struct {
+		Title       string `db:"title"`
+		CompanyName string `db:"company_name"`
+	}
+	err = tx.Get(&listing, `
+		SELECT j.title, co.company_name FROM job_listings j
+		JOIN companies co ON j.company_id = co.id
+		WHERE j.id = $1 AND j.company_id = $2 AND j.closed_at IS NULL AND (j.closes_at IS NULL OR j.closes_at > NOW())
+	`, jobID, companyID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return models.TalentInvitation{}, fmt.Errorf("job listing not found")
+		}
+		log.Printf("Error fetching listing to invite to: %v", err)
+		return models.TalentInvitation{}, fmt.Errorf("could not fetch listing: %w", err)
+	}
+
+	var invitation models.TalentInvitation
+	err = tx.Get(&invitation, `
+		INSERT INTO talent_invitations (company_id, candidate_id, job_id, message, invited_by)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (candidate_id, job_id) DO NOTHING
+		RETURNING *
+	`, companyID, candidateID, jobID, message, invitedBy)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return models.TalentInvitation{}, fmt.Errorf("candidate already invited")
+		}
+		log.Printf("Error creating talent invitation: %v", err)
+		return models.TalentInvitation{}, fmt.Errorf("could not create invitation: %w", err)
+	}
+	invitation.CompanyName = listing.CompanyName
+	invitation.JobTitle = listing.Title
+
+	err = enqueueUserEmail(tx, candidate.UserID, models.EmailTalentInvitation, map[string]any{
+		"CandidateName": candidate.FullName,
+		"CompanyName":   listing.CompanyName,
+		"JobTitle":      listing.Title,
+		"JobID":         jobID,
+		"Message":       message,
+	})
+	if err != nil {
+		return models.TalentInvitation{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return models.TalentInvitation{}, fmt.Errorf("could not create invitation: %w", err)
+	}
+	return invitation, nil
+}
+
+// GetTalentInvitationsByCandidateID lists the invitations to apply a candidate has received, newest first.
+func GetTalentInvitationsByCandidateID(candidateID uuid.UUID) ([]models.TalentInvitation, error) {
+	invitations := []models.TalentInvitation{}
+	err := orm.DB.Select(&invitations, `
+		SELECT ti.*, co.company_name, j.title AS job_title
+		FROM talent_invitations ti
+		JOIN companies co ON ti.company_id = co.id
+		JOIN job_listings j ON ti.job_id = j.id
+		WHERE ti.candidate_id = $1
+		ORDER BY ti.created_at DESC
+	`, candidateID)
+	if err != nil {
+		log.Printf("Error fetching talent invitations: %v", err)
+		return nil, fmt.Errorf("could not fetch invitations: %w", err)
+	}
+	return invitations, nil
+}
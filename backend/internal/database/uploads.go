@@ -0,0 +1,21 @@
+package database
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hridaya14/Web-Tech-Project/internal/models"
+	"github.com/hridaya14/Web-Tech-Project/pkg/orm"
+)
+
+func CreateQuarantinedUpload(upload models.QuarantinedUpload) error {
+	_, err := orm.DB.Exec(`
+		INSERT INTO quarantined_uploads (user_id, purpose, file_name, file_key, sha256, size_bytes, signature)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`, upload.UserID, upload.Purpose, upload.FileName, upload.FileKey, upload.SHA256, upload.SizeBytes, upload.Signature)
+	if err != nil {
+		log.Printf("Error recording quarantined upload: %v", err)
+		return fmt.Errorf("could not record quarantined upload: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,309 @@
+package database
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hridaya14/Web-Tech-Project/internal/models"
+	"github.com/hridaya14/Web-Tech-Project/pkg/orm"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// enqueueWebhookEvent queues a delivery of the event to every active endpoint of the company
+// subscribed to it, inside the caller's transaction.
+func enqueueWebhookEvent(tx *sqlx.Tx, companyID uuid.UUID, eventType string, data map[string]any) error {
+	eventID := uuid.New()
+	payload, err := json.Marshal(map[string]any{
+		"id":         eventID,
+		"type":       eventType,
+		"created_at": time.Now().UTC(),
+		"data":       data,
+	})
+	if err != nil {
+		return fmt.Errorf("could not encode webhook payload: %w", err)
+	}
+
+	_, err = tx.Exec(`
+		INSERT INTO webhook_deliveries (endpoint_id, event_id, event_type, payload)
+		SELECT id, $2, $3, $4 FROM webhook_endpoints
+		WHERE company_id = $1 AND active AND $3 = ANY(event_types)
+	`, companyID, eventID, eventType, payload)
+	if err != nil {
+		log.Printf("Error queueing webhook event: %v", err)
+		return fmt.Errorf("could not queue webhook event: %w", err)
+	}
+	return nil
+}
+
+// applicationWebhookData describes an application the way webhook consumers see it
+func applicationWebhookData(tx *sqlx.Tx, applicationID uuid.UUID) (map[string]any, error) {
+	var row struct {
+		ApplicationID uuid.UUID `db:"application_id"`
+		CandidateID   uuid.UUID `db:"candidate_id"`
+		JobID         uuid.UUID `db:"job_id"`
+		JobTitle      string    `db:"title"`
+		Status        string    `db:"status"`
+		AppliedAt     time.Time `db:"applied_at"`
+	}
+	err := tx.Get(&row, `
+		SELECT a.application_id, a.candidate_id, a.job_id, j.title, a.status, a.applied_at
+		FROM applications a
+		JOIN job_listings j ON a.job_id = j.id
+		WHERE a.application_id = $1
+	`, applicationID)
+	if err != nil {
+		log.Printf("Error fetching application for webhook: %v", err)
+		return nil, fmt.Errorf("could not fetch application: %w", err)
+	}
+
+	return map[string]any{
+		"application_id": row.ApplicationID,
+		"candidate_id":   row.CandidateID,
+		"job_id":         row.JobID,
+		"job_title":      row.JobTitle,
+		"status":         row.Status,
+		"applied_at":     row.AppliedAt,
+	}, nil
+}
+
+// listingWebhookData describes a listing the way webhook consumers see it
+func listingWebhookData(listing models.JobListing) map[string]any {
+	return map[string]any{
+		"listing_id": listing.ID,
+		"title":      listing.Listing_title,
+		"location":   listing.Location,
+		"created_at": listing.CreatedAt,
+		"closed_at":  listing.ClosedAt,
+	}
+}
+
+func CreateWebhookEndpoint(companyID, createdBy uuid.UUID, url, secret string, eventTypes []string) (models.WebhookEndpoint, error) {
+	var endpoint models.WebhookEndpoint
+	err := orm.DB.Get(&endpoint, `
+		INSERT INTO webhook_endpoints (company_id, url, secret, event_types, created_by)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING *
+	`, companyID, url, secret, pq.StringArray(eventTypes), createdBy)
+	if err != nil {
+		log.Printf("Error creating webhook endpoint: %v", err)
+		return models.WebhookEndpoint{}, fmt.Errorf("could not create webhook endpoint: %w", err)
+	}
+	return endpoint, nil
+}
+
+func GetWebhookEndpoints(companyID uuid.UUID) ([]models.WebhookEndpoint, error) {
+	endpoints := []models.WebhookEndpoint{}
+	err := orm.DB.Select(&endpoints, `
+		SELECT * FROM webhook_endpoints WHERE company_id = $1 ORDER BY created_at
+	`, companyID)
+	if err != nil {
+		log.Printf("Error fetching webhook endpoints: %v", err)
+		return nil, fmt.Errorf("could not fetch webhook endpoints: %w", err)
+	}
+	return endpoints, nil
+}
+
+func GetWebhookEndpoint(endpointID, companyID uuid.UUID) (models.WebhookEndpoint, error) {
+	var endpoint models.WebhookEndpoint
+	err := orm.DB.Get(&endpoint, `
+		SELECT * FROM webhook_endpoints WHERE id = $1 AND company_id = $2
+	`, endpointID, companyID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.WebhookEndpoint{}, fmt.Errorf("webhook endpoint not found")
+		}
+		log.Printf("Error fetching webhook endpoint: %v", err)
+		return models.WebhookEndpoint{}, fmt.Errorf("could not fetch webhook endpoint: %w", err)
+	}
+	return endpoint, nil
+}
+
+// UpdateWebhookEndpoint changes the given fields; nil arguments are left as they are.
+func UpdateWebhookEndpoint(endpointID, companyID uuid.UUID, url *string, eventTypes []string, active *bool) (models.WebhookEndpoint, error) {
+	var types interface{}
+	if eventTypes != nil {
+		types = pq.StringArray(eventTypes)
+	}
+
+	var endpoint models.WebhookEndpoint
+	err := orm.DB.Get(&endpoint, `
+		UPDATE webhook_endpoints SET
+			url = COALESCE($3, url),
+			event_types = COALESCE($4, event_types),
+			active = COALESCE($5, active),
+			updated_at = NOW()
+		WHERE id = $1 AND company_id = $2
+		RETURNING *
+	`, endpointID, companyID, url, types, active)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.WebhookEndpoint{}, fmt.Errorf("webhook endpoint not found")
+		}
+		log.Printf("Error updating webhook endpoint: %v", err)
+		return models.WebhookEndpoint{}, fmt.Errorf("could not update webhook endpoint: %w", err)
+	}
+	return endpoint, nil
+}
+
+func RotateWebhookSecret(endpointID, companyID uuid.UUID, secret string) error {
+	result, err := orm.DB.Exec(`
+		UPDATE webhook_endpoints SET secret = $3, updated_at = NOW()
+		WHERE id = $1 AND company_id = $2
+	`, endpointID, companyID, secret)
+	if err != nil {
+		log.Printf("Error rotating webhook secret: %v", err)
+		return fmt.Errorf("could not rotate webhook secret: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("could not verify webhook update: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("webhook endpoint not found")
+	}
+	return nil
+}
+
+func DeleteWebhookEndpoint(endpointID, companyID uuid.UUID) error {
+	result, err := orm.DB.Exec(`DELETE FROM webhook_endpoints WHERE id = $1 AND company_id = $2`, endpointID, companyID)
+	if err != nil {
+		log.Printf("Error deleting webhook endpoint: %v", err)
+		return fmt.Errorf("could not delete webhook endpoint: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("could not verify webhook deletion: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("webhook endpoint not found")
+	}
+	return nil
+}
+
+// GetWebhookDeliveries lists the latest deliveries of an endpoint with every attempt made.
+func GetWebhookDeliveries(endpointID uuid.UUID, limit int) ([]models.WebhookDelivery, error) {
+	deliveries := []models.WebhookDelivery{}
+	err := orm.DB.Select(&deliveries, `
+		SELECT * FROM webhook_deliveries WHERE endpoint_id = $1
+		ORDER BY created_at DESC LIMIT $2
+	`, endpointID, limit)
+	if err != nil {
+		log.Printf("Error fetching webhook deliveries: %v", err)
+		return nil, fmt.Errorf("could not fetch webhook deliveries: %w", err)
+	}
+	if len(deliveries) == 0 {
+		return deliveries, nil
+	}
+
+	ids := make([]interface{}, len(deliveries))
+	index := make(map[uuid.UUID]int, len(deliveries))
+	for i, d := range deliveries {
+		ids[i] = d.ID
+		index[d.ID] = i
+		deliveries[i].AttemptLog = []models.WebhookDeliveryAttempt{}
+	}
+
+	var attempts []models.WebhookDeliveryAttempt
+	if err := selectIn(&attempts, `
+		SELECT * FROM webhook_delivery_attempts WHERE delivery_id IN (?) ORDER BY attempt
+	`, ids); err != nil {
+		return nil, fmt.Errorf("error fetching delivery attempts: %w", err)
+	}
+	for _, a := range attempts {
+		i := index[a.DeliveryID]
+		deliveries[i].AttemptLog = append(deliveries[i].AttemptLog, a)
+	}
+
+	return deliveries, nil
+}
+
+// RedeliverWebhook queues a delivery of the company to be sent again right away with a fresh retry budget.
+func RedeliverWebhook(deliveryID, companyID uuid.UUID) error {
+	result, err := orm.DB.Exec(`
+		UPDATE webhook_deliveries d
+		SET status = 'pending', attempts = 0, next_attempt_at = NOW(), delivered_at = NULL
+		FROM webhook_endpoints e
+		WHERE d.endpoint_id = e.id AND d.id = $1 AND e.company_id = $2
+	`, deliveryID, companyID)
+	if err != nil {
+		log.Printf("Error queueing redelivery: %v", err)
+		return fmt.Errorf("could not queue redelivery: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("could not verify redelivery: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("webhook delivery not found")
+	}
+	return nil
+}
+
+// ClaimDueWebhookDeliveries leases up to limit due deliveries of active endpoints.
+// Deliveries not finished within lease become due again.
+func ClaimDueWebhookDeliveries(limit int, lease time.Duration) ([]models.WebhookDispatch, error) {
+	dispatches := []models.WebhookDispatch{}
+	err := orm.DB.Select(&dispatches, `
+		UPDATE webhook_deliveries d
+		SET attempts = d.attempts + 1, next_attempt_at = NOW() + $2 * INTERVAL '1 second'
+		FROM webhook_endpoints e
+		WHERE e.id = d.endpoint_id AND d.id IN (
+			SELECT wd.id FROM webhook_deliveries wd
+			JOIN webhook_endpoints we ON we.id = wd.endpoint_id
+			WHERE wd.status = 'pending' AND wd.next_attempt_at <= NOW() AND we.active
+			ORDER BY wd.next_attempt_at
+			LIMIT $1
+			FOR UPDATE OF wd SKIP LOCKED
+		)
+		RETURNING d.*, e.url, e.secret
+	`, limit, lease.Seconds())
+	if err != nil {
+		log.Printf("Error claiming webhook deliveries: %v", err)
+		return nil, fmt.Errorf("could not claim webhook deliveries: %w", err)
+	}
+	return dispatches, nil
+}
+
+// RecordWebhookAttempt logs one delivery attempt and moves the delivery to status.
+// Pending deliveries are retried at nextAttempt.
+func RecordWebhookAttempt(attempt models.WebhookDeliveryAttempt, status string, nextAttempt time.Time) error {
+	tx, err := orm.DB.Beginx()
+	if err != nil {
+		return fmt.Errorf("could not start transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec(`
+		INSERT INTO webhook_delivery_attempts (delivery_id, attempt, status_code, error, duration_ms)
+		VALUES ($1, $2, $3, $4, $5)
+	`, attempt.DeliveryID, attempt.Attempt, attempt.StatusCode, attempt.Error, attempt.DurationMs)
+	if err != nil {
+		log.Printf("Error recording webhook attempt: %v", err)
+		return fmt.Errorf("could not record webhook attempt: %w", err)
+	}
+
+	_, err = tx.Exec(`
+		UPDATE webhook_deliveries SET
+			status = $2,
+			last_status_code = $3,
+			last_error = $4,
+			next_attempt_at = $5,
+			delivered_at = CASE WHEN $2 = 'succeeded' THEN NOW() ELSE delivered_at END
+		WHERE id = $1
+	`, attempt.DeliveryID, status, attempt.StatusCode, attempt.Error, nextAttempt)
+	if err != nil {
+		log.Printf("Error updating webhook delivery: %v", err)
+		return fmt.Errorf("could not update webhook delivery: %w", err)
+	}
+
+	return tx.Commit()
+}
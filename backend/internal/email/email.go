@@ -0,0 +1,114 @@
+package email
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"fmt"
+	htmltemplate "html/template"
+	"net/url"
+	"os"
+	"strings"
+	texttemplate "text/template"
+
+	"github.com/google/uuid"
+	"github.com/hridaya14/Web-Tech-Project/internal/models"
+	"github.com/hridaya14/Web-Tech-Project/pkg/auth"
+	"github.com/hridaya14/Web-Tech-Project/pkg/mail"
+)
+
+//go:embed templates
+var templateFS embed.FS
+
+// templateTypes maps templates to the notification type users can opt out of.
+// Templates missing here are account emails and are always sent.
+var templateTypes = map[string]string{
+	models.EmailApplicationSubmitted:     models.NotifyApplicationCreated,
+	models.EmailApplicationReceived:      models.NotifyApplicationCreated,
+	models.EmailApplicationStatusChanged: models.NotifyApplicationStatusChanged,
+	models.EmailTalentInvitation:         models.NotifyTalentInvitation,
+	models.EmailJobAlert:                 models.NotifyJobAlert,
+}
+
+// NotificationType returns the preference a template is governed by, if any
+func NotificationType(template string) (string, bool) {
+	t, ok := templateTypes[template]
+	return t, ok
+}
+
+func appURL() string {
+	if u := os.Getenv("APP_URL"); u != "" {
+		return strings.TrimRight(u, "/")
+	}
+	return "http://localhost:3000"
+}
+
+func apiURL() string {
+	if u := os.Getenv("API_URL"); u != "" {
+		return strings.TrimRight(u, "/")
+	}
+	return "http://localhost:5000"
+}
+
+// UnsubscribeURL is a signed one-click link that turns off emails of one notification type.
+func UnsubscribeURL(userID uuid.UUID, notificationType string) string {
+	q := url.Values{}
+	q.Set("user", userID.String())
+	q.Set("type", notificationType)
+	q.Set("sig", auth.SignValue(userID.String()+":"+notificationType))
+	return apiURL() + "/email/unsubscribe?" + q.Encode()
+}
+
+// VerifyUnsubscribe checks a signature from an unsubscribe link
+func VerifyUnsubscribe(userID uuid.UUID, notificationType, signature string) bool {
+	return auth.VerifySignedValue(userID.String()+":"+notificationType, signature)
+}
+
+// Render builds the message for an outbox email.
+func Render(e models.OutboxEmail) (mail.Message, error) {
+	data := map[string]any{}
+	if len(e.Data) > 0 {
+		if err := json.Unmarshal(e.Data, &data); err != nil {
+			return mail.Message{}, fmt.Errorf("invalid email data: %w", err)
+		}
+	}
+	data["AppURL"] = appURL()
+	data["UnsubscribeURL"] = ""
+
+	msg := mail.Message{To: e.ToEmail}
+	if notificationType, ok := NotificationType(e.Template); ok && e.UserID != nil {
+		unsubscribe := UnsubscribeURL(*e.UserID, notificationType)
+		data["UnsubscribeURL"] = unsubscribe
+		msg.Headers = map[string]string{
+			"List-Unsubscribe":      "<" + unsubscribe + ">",
+			"List-Unsubscribe-Post": "List-Unsubscribe=One-Click",
+		}
+	}
+
+	text, err := texttemplate.ParseFS(templateFS, "templates/"+e.Template+".txt")
+	if err != nil {
+		return mail.Message{}, fmt.Errorf("unknown email template %q: %w", e.Template, err)
+	}
+
+	var subject, body bytes.Buffer
+	if err := text.ExecuteTemplate(&subject, "subject", data); err != nil {
+		return mail.Message{}, fmt.Errorf("could not render subject: %w", err)
+	}
+	if err := text.ExecuteTemplate(&body, "text", data); err != nil {
+		return mail.Message{}, fmt.Errorf("could not render text body: %w", err)
+	}
+	msg.Subject = strings.TrimSpace(subject.String())
+	msg.Text = strings.TrimSpace(body.String()) + "\n"
+
+	html, err := htmltemplate.ParseFS(templateFS, "templates/layout.html", "templates/"+e.Template+".html")
+	if err != nil {
+		return mail.Message{}, fmt.Errorf("unknown email template %q: %w", e.Template, err)
+	}
+	var htmlBody bytes.Buffer
+	if err := html.ExecuteTemplate(&htmlBody, "layout", data); err != nil {
+		return mail.Message{}, fmt.Errorf("could not render html body: %w", err)
+	}
+	msg.HTML = htmlBody.String()
+
+	return msg, nil
+}
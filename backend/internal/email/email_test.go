@@ -0,0 +1,237 @@
+package email
+
+import (
+	"context"
+	"encoding/base64"
+	"html"
+	"io"
+	"mime"
+	"mime/multipart"
+	netmail "net/mail"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/hridaya14/Web-Tech-Project/internal/models"
+	"github.com/hridaya14/Web-Tech-Project/pkg/mail"
+)
+
+// sentEmail is a message read back from the file sink
+type sentEmail struct {
+	header netmail.Header
+	text   string
+	html   string
+}
+
+// sendToFile delivers msg through a file sink and parses the .eml it writes.
+func sendToFile(t *testing.T, msg mail.Message) sentEmail {
+	t.Helper()
+	dir := t.TempDir()
+	driver, err := mail.NewFileDriver(dir)
+	if err != nil {
+		t.Fatalf("NewFileDriver: %v", err)
+	}
+	if err := driver.Send(context.Background(), msg); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+
+	files, _ := filepath.Glob(filepath.Join(dir, "*.eml"))
+	if len(files) != 1 {
+		t.Fatalf("file sink wrote %d messages, want 1", len(files))
+	}
+	raw, err := os.Open(files[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer raw.Close()
+
+	parsed, err := netmail.ReadMessage(raw)
+	if err != nil {
+		t.Fatalf("ReadMessage: %v", err)
+	}
+	_, params, err := mime.ParseMediaType(parsed.Header.Get("Content-Type"))
+	if err != nil {
+		t.Fatalf("Content-Type: %v", err)
+	}
+
+	sent := sentEmail{header: parsed.Header}
+	parts := multipart.NewReader(parsed.Body, params["boundary"])
+	for {
+		part, err := parts.NextPart()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			t.Fatalf("NextPart: %v", err)
+		}
+		body, err := io.ReadAll(base64.NewDecoder(base64.StdEncoding, part))
+		if err != nil {
+			t.Fatalf("decoding part: %v", err)
+		}
+		switch part.Header.Get("Content-Type") {
+		case "text/plain; charset=utf-8":
+			sent.text = string(body)
+		case "text/html; charset=utf-8":
+			sent.html = string(body)
+		}
+	}
+	return sent
+}
+
+func TestRenderTemplates(t *testing.T) {
+	t.Setenv("SECRET_KEY", "test-secret")
+	t.Setenv("APP_URL", "https://jobs.example.com/")
+	t.Setenv("API_URL", "https://api.example.com")
+
+	userID := uuid.New()
+	tests := []struct {
+		template string
+		data     string
+		subject  string
+		contains []string // in both the text and the html body
+	}{
+		{
+			models.EmailWelcome,
+			`{"Username":"jane","Role":"candidate"}`,
+			"Welcome to JobHunt AI, jane",
+			[]string{"jane"},
+		},
+		{
+			models.EmailApplicationSubmitted,
+			`{"CandidateName":"Jane Doe","CompanyName":"Acme","JobTitle":"Go Developer"}`,
+			"Go Developer",
+			[]string{"Jane Doe", "Acme", "Go Developer"},
+		},
+		{
+			models.EmailApplicationReceived,
+			`{"CandidateName":"Jane Doe","JobTitle":"Go Developer"}`,
+			"Go Developer",
+			[]string{"Jane Doe", "Go Developer"},
+		},
+		{
+			models.EmailApplicationStatusChanged,
+			`{"CandidateName":"Jane Doe","CompanyName":"Acme","JobTitle":"Go Developer","Status":"interview"}`,
+			"Go Developer",
+			[]string{"Jane Doe", "Acme", "interview"},
+		},
+		{
+			models.EmailCompanyInvitation,
+			`{"CompanyName":"Acme","Role":"recruiter","Token":"tok123","ExpiresAt":"2026-11-01"}`,
+			"Acme",
+			[]string{"Acme", "recruiter", "tok123"},
+		},
+		{
+			models.EmailTalentInvitation,
+			`{"CandidateName":"Jane Doe","CompanyName":"Acme","JobTitle":"Go Developer","Message":"We'd love to talk"}`,
+			"Acme",
+			[]string{"Jane Doe", "Acme", "Go Developer"},
+		},
+		{
+			models.EmailJobAlert,
+			`{"CandidateName":"Jane Doe","AlertName":"Go in Pune","Count":2,"Jobs":[{"Title":"Go Developer","Location":"Pune","WorkType":"remote"},{"Title":"SRE"}]}`,
+			`2 new jobs match "Go in Pune"`,
+			[]string{"Jane Doe", "Go Developer", "Pune", "SRE"},
+		},
+	}
+
+	for _, tt := range tests {
+		msg, err := Render(models.OutboxEmail{
+			UserID:   &userID,
+			ToEmail:  "jane@example.com",
+			Template: tt.template,
+			Data:     []byte(tt.data),
+		})
+		if err != nil {
+			t.Errorf("%s: Render = %v", tt.template, err)
+			continue
+		}
+		sent := sendToFile(t, msg)
+
+		subject, err := new(mime.WordDecoder).DecodeHeader(sent.header.Get("Subject"))
+		if err != nil || !strings.Contains(subject, tt.subject) {
+			t.Errorf("%s: subject %q, want it to contain %q", tt.template, subject, tt.subject)
+		}
+		if to := sent.header.Get("To"); to != "jane@example.com" {
+			t.Errorf("%s: To = %q", tt.template, to)
+		}
+		if sent.text == "" || sent.html == "" {
+			t.Errorf("%s: missing text or html part", tt.template)
+			continue
+		}
+
+		for _, body := range []string{sent.text, sent.html} {
+			if strings.Contains(body, "<no value>") {
+				t.Errorf("%s: body renders a missing field:\n%s", tt.template, body)
+			}
+			if !strings.Contains(body, "https://jobs.example.com") {
+				t.Errorf("%s: body does not link to the app:\n%s", tt.template, body)
+			}
+		}
+		for _, want := range tt.contains {
+			if !strings.Contains(sent.text, want) {
+				t.Errorf("%s: text part does not contain %q:\n%s", tt.template, want, sent.text)
+			}
+			if !strings.Contains(sent.html, html.EscapeString(want)) {
+				t.Errorf("%s: html part does not contain %q:\n%s", tt.template, want, sent.html)
+			}
+		}
+
+		notificationType, optional := NotificationType(tt.template)
+		header := sent.header.Get("List-Unsubscribe")
+		if !optional {
+			if header != "" || strings.Contains(sent.text, "/email/unsubscribe") || strings.Contains(sent.html, "/email/unsubscribe") {
+				t.Errorf("%s: account email carries an unsubscribe link", tt.template)
+			}
+			continue
+		}
+
+		unsubscribe := UnsubscribeURL(userID, notificationType)
+		if header != "<"+unsubscribe+">" {
+			t.Errorf("%s: List-Unsubscribe = %q, want %q", tt.template, header, unsubscribe)
+		}
+		if sent.header.Get("List-Unsubscribe-Post") != "List-Unsubscribe=One-Click" {
+			t.Errorf("%s: missing List-Unsubscribe-Post", tt.template)
+		}
+		if !strings.Contains(sent.text, unsubscribe) {
+			t.Errorf("%s: text part lacks the unsubscribe link:\n%s", tt.template, sent.text)
+		}
+		if !strings.Contains(sent.html, html.EscapeString(unsubscribe)) {
+			t.Errorf("%s: html part lacks the unsubscribe link:\n%s", tt.template, sent.html)
+		}
+
+		link, _ := url.Parse(unsubscribe)
+		q := link.Query()
+		if link.Host != "api.example.com" || q.Get("user") != userID.String() || q.Get("type") != notificationType ||
+			!VerifyUnsubscribe(userID, notificationType, q.Get("sig")) {
+			t.Errorf("%s: unsubscribe link %q does not verify", tt.template, unsubscribe)
+		}
+	}
+}
+
+func TestRenderEscapesHTML(t *testing.T) {
+	t.Setenv("SECRET_KEY", "test-secret")
+
+	msg, err := Render(models.OutboxEmail{
+		ToEmail:  "jane@example.com",
+		Template: models.EmailApplicationReceived,
+		Data:     []byte(`{"CandidateName":"<script>alert(1)</script>","JobTitle":"Go"}`),
+	})
+	if err != nil {
+		t.Fatalf("Render = %v", err)
+	}
+	if strings.Contains(msg.HTML, "<script>") {
+		t.Errorf("html body contains unescaped user input:\n%s", msg.HTML)
+	}
+	// Without a user there is nobody to unsubscribe
+	if msg.Headers["List-Unsubscribe"] != "" || strings.Contains(msg.HTML, "/email/unsubscribe") {
+		t.Errorf("email to a recipient without an account carries an unsubscribe link")
+	}
+}
+
+func TestRenderUnknownTemplate(t *testing.T) {
+	if _, err := Render(models.OutboxEmail{ToEmail: "jane@example.com", Template: "missing"}); err == nil {
+		t.Error("Render of an unknown template succeeded, want an error")
+	}
+}
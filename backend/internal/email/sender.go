@@ -0,0 +1,83 @@
+package email
+
+import (
+	"context"
+	"log"
+	"math"
+	"time"
+
+	"github.com/hridaya14/Web-Tech-Project/internal/database"
+	"github.com/hridaya14/Web-Tech-Project/internal/models"
+	"github.com/hridaya14/Web-Tech-Project/pkg/mail"
+	"github.com/hridaya14/Web-Tech-Project/pkg/poll"
+)
+
+const (
+	pollInterval = 10 * time.Second
+	batchSize    = 20
+	claimLease   = 5 * time.Minute
+	sendTimeout  = 30 * time.Second
+	maxAttempts  = 8
+)
+
+// RunSender delivers due outbox emails until ctx is cancelled.
+func RunSender(ctx context.Context, driver mail.Driver) {
+	log.Println("✅ Email sender started")
+
+	poll.Run(ctx, pollInterval, nil, func() {
+		poll.Drain(ctx, batchSize, func(limit int) ([]models.OutboxEmail, error) {
+			return database.ClaimDueEmails(limit, claimLease)
+		}, func(e models.OutboxEmail) {
+			deliver(ctx, driver, e)
+		})
+	})
+}
+
+func deliver(ctx context.Context, driver mail.Driver, e models.OutboxEmail) {
+	if notificationType, ok := NotificationType(e.Template); ok && e.UserID != nil {
+		enabled, err := database.IsEmailNotificationEnabled(*e.UserID, notificationType)
+		if err != nil {
+			fail(e, err)
+			return
+		}
+		if !enabled {
+			if err := database.MarkEmailSkipped(e.ID); err != nil {
+				log.Printf("Error skipping email %s: %v", e.ID, err)
+			}
+			return
+		}
+	}
+
+	msg, err := Render(e)
+	if err != nil {
+		// Rendering will not succeed on a retry either
+		log.Printf("Error rendering email %s: %v", e.ID, err)
+		if err := database.MarkEmailFailed(e.ID, err.Error(), time.Now(), true); err != nil {
+			log.Printf("Error recording email failure: %v", err)
+		}
+		return
+	}
+
+	sendCtx, cancel := context.WithTimeout(ctx, sendTimeout)
+	defer cancel()
+
+	if err := driver.Send(sendCtx, msg); err != nil {
+		fail(e, err)
+		return
+	}
+
+	if err := database.MarkEmailSent(e.ID); err != nil {
+		log.Printf("Error marking email %s sent: %v", e.ID, err)
+	}
+}
+
+// fail schedules a retry with exponential backoff, giving up after maxAttempts.
+func fail(e models.OutboxEmail, sendErr error) {
+	final := e.Attempts >= maxAttempts
+	backoff := time.Duration(math.Pow(2, float64(e.Attempts))) * time.Minute
+
+	log.Printf("Error sending email %s (attempt %d): %v", e.ID, e.Attempts, sendErr)
+	if err := database.MarkEmailFailed(e.ID, sendErr.Error(), time.Now().Add(backoff), final); err != nil {
+		log.Printf("Error recording email failure: %v", err)
+	}
+}
@@ -0,0 +1,168 @@
+package matching
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Profile is what a candidate brings
+type Profile struct {
+	Skills          []string
+	ExperienceYears int
+	ExpectedRoles   []string
+}
+
+// Requirements is what a listing asks for
+type Requirements struct {
+	Title            string
+	RequiredSkills   []string
+	ExperienceMonths int // 0 when the listing does not say
+}
+
+// Weights set how much each part counts towards the score
+type Weights struct {
+	Skills     float64
+	Experience float64
+	Role       float64
+}
+
+var DefaultWeights = Weights{Skills: 0.6, Experience: 0.25, Role: 0.15}
+
+// Result is a score from 0 to 100 with the parts it was made of
+type Result struct {
+	Score           float64  `json:"score"`
+	SkillFit        float64  `json:"skill_fit"`
+	ExperienceFit   float64  `json:"experience_fit"`
+	RoleFit         float64  `json:"role_fit"`
+	MatchedSkills   []string `json:"matched_skills"`
+	MissingSkills   []string `json:"missing_skills"`
+	ExtraSkills     []string `json:"extra_skills"`
+	RequiredMonths  int      `json:"required_experience_months"`
+	CandidateMonths int      `json:"candidate_experience_months"`
+	Explanation     string   `json:"explanation"`
+}
+
+// ParseExperienceMonths reads the listing's free-form experience field, taking the first number in it.
+func ParseExperienceMonths(raw string) int {
+	start := strings.IndexAny(raw, "0123456789")
+	if start < 0 {
+		return 0
+	}
+	end := start
+	for end < len(raw) && raw[end] >= '0' && raw[end] <= '9' {
+		end++
+	}
+	months, _ := strconv.Atoi(raw[start:end])
+	return months
+}
+
+// Match scores the profile against the requirements with DefaultWeights.
+func Match(profile Profile, requirements Requirements) Result {
+	return MatchWeighted(profile, requirements, DefaultWeights)
+}
+
+// MatchWeighted scores the profile against the requirements. Parts the listing says nothing
+// about are left out and the remaining weights scaled up, so a listing without required skills
+// is judged on experience and role alone.
+func MatchWeighted(profile Profile, requirements Requirements, weights Weights) Result {
+	result := Result{
+		MatchedSkills:   []string{},
+		MissingSkills:   []string{},
+		ExtraSkills:     []string{},
+		RequiredMonths:  requirements.ExperienceMonths,
+		CandidateMonths: profile.ExperienceYears * 12,
+	}
+
+	have := map[string]bool{}
+	for _, s := range profile.Skills {
+		have[CanonicalSkill(s)] = true
+	}
+	required := map[string]bool{}
+	for _, s := range requirements.RequiredSkills {
+		canonical := CanonicalSkill(s)
+		if canonical == "" || required[canonical] {
+			continue
+		}
+		required[canonical] = true
+		if have[canonical] {
+			result.MatchedSkills = append(result.MatchedSkills, strings.TrimSpace(s))
+		} else {
+			result.MissingSkills = append(result.MissingSkills, strings.TrimSpace(s))
+		}
+	}
+	listed := map[string]bool{}
+	for _, s := range profile.Skills {
+		canonical := CanonicalSkill(s)
+		if canonical == "" || required[canonical] || listed[canonical] {
+			continue
+		}
+		listed[canonical] = true
+		result.ExtraSkills = append(result.ExtraSkills, strings.TrimSpace(s))
+	}
+
+	var total, weightSum float64
+
+	if len(required) > 0 {
+		result.SkillFit = float64(len(result.MatchedSkills)) / float64(len(required))
+		total += weights.Skills * result.SkillFit
+		weightSum += weights.Skills
+	}
+
+	if requirements.ExperienceMonths > 0 {
+		result.ExperienceFit = math.Min(1, float64(result.CandidateMonths)/float64(requirements.ExperienceMonths))
+		total += weights.Experience * result.ExperienceFit
+		weightSum += weights.Experience
+	}
+
+	titleTerms := roleTerms(requirements.Title)
+	if len(titleTerms) > 0 && len(profile.ExpectedRoles) > 0 {
+		for _, role := range profile.ExpectedRoles {
+			result.RoleFit = math.Max(result.RoleFit, overlap(roleTerms(role), titleTerms))
+		}
+		total += weights.Role * result.RoleFit
+		weightSum += weights.Role
+	}
+
+	if weightSum > 0 {
+		result.Score = math.Round(100*total/weightSum*10) / 10
+	}
+	result.Explanation = explain(result, len(required))
+	return result
+}
+
+// overlap is the share of the title's terms the expected role covers.
+func overlap(role, title map[string]bool) float64 {
+	if len(role) == 0 {
+		return 0
+	}
+	shared := 0
+	for term := range title {
+		if role[term] {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(title))
+}
+
+func explain(r Result, required int) string {
+	parts := []string{}
+	if required > 0 {
+		part := fmt.Sprintf("Matches %d of %d required skills", len(r.MatchedSkills), required)
+		if len(r.MissingSkills) > 0 {
+			part += fmt.Sprintf(" (missing %s)", strings.Join(r.MissingSkills, ", "))
+		}
+		parts = append(parts, part)
+	}
+	if r.RequiredMonths > 0 {
+		parts = append(parts, fmt.Sprintf("%d of %d months of required experience", min(r.CandidateMonths, r.RequiredMonths), r.RequiredMonths))
+	}
+	if r.RoleFit > 0 {
+		parts = append(parts, fmt.Sprintf("expected role %.0f%% similar to the title", r.RoleFit*100))
+	}
+	if len(parts) == 0 {
+		return "The listing has no requirements to compare against"
+	}
+	return strings.Join(parts, "; ")
+}
@@ -0,0 +1,130 @@
+package matching
+
+import (
+	"maps"
+	"slices"
+	"testing"
+)
+
+func TestCanonicalSkill(t *testing.T) {
+	tests := []struct {
+		skill string
+		want  string
+	}{
+		{"Go", "go"},
+		{"Golang", "go"},
+		{"  React.JS ", "react"},
+		{"c  sharp", "csharp"},
+		{"K8s", "kubernetes"},
+		{"PostgreSQL", "postgresql"},
+		{"Haskell", "haskell"},
+		{"", ""},
+		{"   ", ""},
+	}
+
+	for _, tt := range tests {
+		if got := CanonicalSkill(tt.skill); got != tt.want {
+			t.Errorf("CanonicalSkill(%q) = %q, want %q", tt.skill, got, tt.want)
+		}
+	}
+}
+
+func TestSkillVariants(t *testing.T) {
+	variants := SkillVariants("JS")
+	if variants[0] != "javascript" {
+		t.Fatalf("SkillVariants(%q)[0] = %q, want the canonical name first", "JS", variants[0])
+	}
+	for _, alias := range []string{"js", "ecmascript", "es6"} {
+		if !slices.Contains(variants, alias) {
+			t.Errorf("SkillVariants(%q) = %v, missing %q", "JS", variants, alias)
+		}
+	}
+
+	if got := SkillVariants("Haskell"); !slices.Equal(got, []string{"haskell"}) {
+		t.Errorf("SkillVariants(%q) = %v, want only the skill itself", "Haskell", got)
+	}
+}
+
+func TestRoleTerms(t *testing.T) {
+	tests := []struct {
+		title string
+		want  []string
+	}{
+		{"Senior Software Developer", []string{"software", "engineer"}},
+		{"Full Stack Engineer (Remote)", []string{"full-stack", "engineer"}},
+		{"Front-End/UI Developer", []string{"frontend", "design", "engineer"}},
+		{"Machine Learning Engineer II", []string{"machine-learning", "engineer"}},
+		{"SWE Intern", []string{"software"}},
+		{"QA Tester", []string{"quality"}},
+		{"", nil},
+	}
+
+	for _, tt := range tests {
+		got := slices.Sorted(maps.Keys(roleTerms(tt.title)))
+		want := slices.Sorted(slices.Values(tt.want))
+		if !slices.Equal(got, want) {
+			t.Errorf("roleTerms(%q) = %v, want %v", tt.title, got, want)
+		}
+	}
+}
+
+func TestParseExperienceMonths(t *testing.T) {
+	tests := []struct {
+		raw  string
+		want int
+	}{
+		{"24", 24},
+		{"at least 36 months", 36},
+		{"12-24", 12},
+		{"none", 0},
+		{"", 0},
+	}
+
+	for _, tt := range tests {
+		if got := ParseExperienceMonths(tt.raw); got != tt.want {
+			t.Errorf("ParseExperienceMonths(%q) = %d, want %d", tt.raw, got, tt.want)
+		}
+	}
+}
+
+func TestMatch(t *testing.T) {
+	profile := Profile{
+		Skills:          []string{"Golang", "postgres", "Docker"},
+		ExperienceYears: 1,
+		ExpectedRoles:   []string{"Backend Developer"},
+	}
+	requirements := Requirements{
+		Title:            "Senior Backend Engineer",
+		RequiredSkills:   []string{"Go", "PostgreSQL", "Kubernetes", "go"},
+		ExperienceMonths: 24,
+	}
+
+	result := Match(profile, requirements)
+
+	if !slices.Equal(result.MatchedSkills, []string{"Go", "PostgreSQL"}) {
+		t.Errorf("MatchedSkills = %v", result.MatchedSkills)
+	}
+	if !slices.Equal(result.MissingSkills, []string{"Kubernetes"}) {
+		t.Errorf("MissingSkills = %v", result.MissingSkills)
+	}
+	if !slices.Equal(result.ExtraSkills, []string{"Docker"}) {
+		t.Errorf("ExtraSkills = %v", result.ExtraSkills)
+	}
+	if result.ExperienceFit != 0.5 {
+		t.Errorf("ExperienceFit = %v, want 0.5", result.ExperienceFit)
+	}
+	if result.RoleFit != 1 {
+		t.Errorf("RoleFit = %v, want 1", result.RoleFit)
+	}
+	// (0.6 * 2/3 + 0.25 * 0.5 + 0.15 * 1) / 1
+	if result.Score != 67.5 {
+		t.Errorf("Score = %v, want 67.5", result.Score)
+	}
+}
+
+func TestMatchWithoutRequirements(t *testing.T) {
+	result := Match(Profile{Skills: []string{"go"}}, Requirements{})
+	if result.Score != 0 {
+		t.Errorf("Score = %v, want 0 when the listing asks for nothing", result.Score)
+	}
+}
@@ -0,0 +1,127 @@
+package matching
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// Seeker is a candidate looking for jobs, with the listings they already applied to
+type Seeker struct {
+	Profile
+	Location string
+	Applied  []Requirements
+}
+
+// Opening is a listing the seeker could apply to
+type Opening struct {
+	Requirements
+	Location string
+	WorkType string
+}
+
+// Recommendation rates an opening for a seeker from 0 to 100 and says why
+type Recommendation struct {
+	Score  float64 `json:"score"`
+	Reason string  `json:"reason"`
+}
+
+// How much each signal counts towards a recommendation
+const (
+	recommendMatchWeight    = 0.7
+	recommendLocationWeight = 0.15
+	recommendHistoryWeight  = 0.15
+)
+
+// Recommend rates how well the opening suits the seeker from their profile match,
+// whether they can work where the job is, and how much it resembles jobs they applied to.
+func Recommend(seeker Seeker, opening Opening) Recommendation {
+	match := Match(seeker.Profile, opening.Requirements)
+	location := locationFit(seeker.Location, opening.Location, opening.WorkType)
+	history := historyFit(seeker.Applied, opening.Requirements)
+
+	score := recommendMatchWeight*match.Score + 100*(recommendLocationWeight*location+recommendHistoryWeight*history)
+
+	reasons := []string{}
+	if len(match.MatchedSkills) > 0 {
+		reasons = append(reasons, fmt.Sprintf("matches your skills in %s", strings.Join(match.MatchedSkills, ", ")))
+	}
+	if match.RoleFit >= 0.5 {
+		reasons = append(reasons, "fits the role you are looking for")
+	}
+	if location == 1 {
+		if isRemote(opening.WorkType, opening.Location) {
+			reasons = append(reasons, "can be done remotely")
+		} else {
+			reasons = append(reasons, fmt.Sprintf("is in %s", strings.TrimSpace(opening.Location)))
+		}
+	}
+	if history >= 0.5 {
+		reasons = append(reasons, "is similar to jobs you applied for")
+	}
+
+	reason := "Newly posted and open to applications"
+	if len(reasons) > 0 {
+		reasons[0] = strings.ToUpper(reasons[0][:1]) + reasons[0][1:]
+		reason = strings.Join(reasons, "; ")
+	}
+
+	return Recommendation{Score: math.Round(score*10) / 10, Reason: reason}
+}
+
+func isRemote(workType, location string) bool {
+	return strings.EqualFold(strings.TrimSpace(workType), "remote") ||
+		strings.Contains(strings.ToLower(location), "remote")
+}
+
+// locationFit is 1 for remote jobs and jobs in the seeker's city, 0 otherwise.
+func locationFit(seekerLocation, jobLocation, workType string) float64 {
+	if isRemote(workType, jobLocation) {
+		return 1
+	}
+
+	seeker, job := city(seekerLocation), city(jobLocation)
+	if seeker == "" || seeker != job {
+		return 0
+	}
+	return 1
+}
+
+// city is the first part of a location, so "Pune" matches "Pune, Maharashtra" either way
+// round but two cities in the same state do not match.
+func city(location string) string {
+	city, _, _ := strings.Cut(location, ",")
+	return strings.ToLower(strings.TrimSpace(city))
+}
+
+// historyFit is how close the opening is to the most similar listing the seeker applied to,
+// by shared required skills and title terms.
+func historyFit(applied []Requirements, opening Requirements) float64 {
+	skills := map[string]bool{}
+	for _, s := range opening.RequiredSkills {
+		skills[CanonicalSkill(s)] = true
+	}
+	title := roleTerms(opening.Title)
+
+	best := 0.0
+	for _, past := range applied {
+		var skillFit, titleFit float64
+		if len(skills) > 0 && len(past.RequiredSkills) > 0 {
+			shared := 0
+			seen := map[string]bool{}
+			for _, s := range past.RequiredSkills {
+				canonical := CanonicalSkill(s)
+				if skills[canonical] && !seen[canonical] {
+					shared++
+				}
+				seen[canonical] = true
+			}
+			skillFit = float64(shared) / float64(len(skills))
+		}
+		if len(title) > 0 {
+			titleFit = overlap(roleTerms(past.Title), title)
+		}
+		best = math.Max(best, (skillFit+titleFit)/2)
+	}
+	return best
+}
@@ -0,0 +1,119 @@
+package matching
+
+import "testing"
+
+func TestLocationFit(t *testing.T) {
+	tests := []struct {
+		seeker   string
+		job      string
+		workType string
+		want     float64
+	}{
+		{"Pune", "Pune", "onsite", 1},
+		{"Pune", "Pune, Maharashtra", "onsite", 1},
+		{"Pune, Maharashtra", "Pune", "hybrid", 1},
+		{" pune , maharashtra", "PUNE, MH", "onsite", 1},
+		{"Pune, Maharashtra", "Mumbai, Maharashtra", "onsite", 0},
+		{"Pune", "Punes", "onsite", 0},
+		{"Pune", "Bengaluru", "Remote", 1},
+		{"Pune", "Remote (India)", "", 1},
+		{"", "Bengaluru", "remote", 1},
+		{"", "Pune", "onsite", 0},
+		{"Pune", "", "onsite", 0},
+	}
+
+	for _, tt := range tests {
+		if got := locationFit(tt.seeker, tt.job, tt.workType); got != tt.want {
+			t.Errorf("locationFit(%q, %q, %q) = %v, want %v", tt.seeker, tt.job, tt.workType, got, tt.want)
+		}
+	}
+}
+
+func TestHistoryFit(t *testing.T) {
+	opening := Requirements{Title: "Backend Engineer", RequiredSkills: []string{"Go", "PostgreSQL", "Docker", "Kafka"}}
+
+	tests := []struct {
+		name    string
+		applied []Requirements
+		want    float64
+	}{
+		{"no history", nil, 0},
+		{"same job", []Requirements{{Title: "Backend Engineer", RequiredSkills: []string{"golang", "postgres", "docker", "kafka"}}}, 1},
+		{"half the skills, same title", []Requirements{{Title: "Backend Developer", RequiredSkills: []string{"Go", "Docker"}}}, 0.75},
+		{"repeated skills count once", []Requirements{{Title: "Data Scientist", RequiredSkills: []string{"Go", "golang", "Go"}}}, 0.125},
+		{"unrelated", []Requirements{{Title: "Data Scientist", RequiredSkills: []string{"Python", "Pandas"}}}, 0},
+		{"best of several", []Requirements{
+			{Title: "Data Scientist", RequiredSkills: []string{"Python"}},
+			{Title: "Backend Engineer", RequiredSkills: []string{"Go"}},
+		}, 0.625},
+	}
+
+	for _, tt := range tests {
+		if got := historyFit(tt.applied, opening); got != tt.want {
+			t.Errorf("%s: historyFit = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestRecommend(t *testing.T) {
+	profile := Profile{Skills: []string{"Go", "PostgreSQL"}, ExpectedRoles: []string{"Backend Engineer"}}
+	backend := Requirements{Title: "Backend Engineer", RequiredSkills: []string{"Go", "PostgreSQL"}}
+
+	tests := []struct {
+		name    string
+		seeker  Seeker
+		opening Opening
+		score   float64
+		reason  string
+	}{
+		{
+			"everything fits",
+			Seeker{Profile: profile, Location: "Pune, Maharashtra", Applied: []Requirements{backend}},
+			Opening{Requirements: backend, Location: "Pune", WorkType: "onsite"},
+			100,
+			"Matches your skills in Go, PostgreSQL; fits the role you are looking for; is in Pune; is similar to jobs you applied for",
+		},
+		{
+			"remote job",
+			Seeker{Profile: profile, Location: "Pune"},
+			Opening{Requirements: backend, Location: "Bengaluru", WorkType: "remote"},
+			85,
+			"Matches your skills in Go, PostgreSQL; fits the role you are looking for; can be done remotely",
+		},
+		{
+			"city named with its state",
+			Seeker{Profile: profile, Location: "pune"},
+			Opening{Requirements: backend, Location: " Pune, Maharashtra ", WorkType: "onsite"},
+			85,
+			"Matches your skills in Go, PostgreSQL; fits the role you are looking for; is in Pune, Maharashtra",
+		},
+		{
+			"another city in the same state",
+			Seeker{Profile: profile, Location: "Pune, Maharashtra"},
+			Opening{Requirements: backend, Location: "Mumbai, Maharashtra", WorkType: "onsite"},
+			70,
+			"Matches your skills in Go, PostgreSQL; fits the role you are looking for",
+		},
+		{
+			"only similar to past applications",
+			Seeker{Profile: Profile{ExpectedRoles: []string{"Designer"}}, Applied: []Requirements{backend}},
+			Opening{Requirements: backend, Location: "Delhi", WorkType: "onsite"},
+			15,
+			"Is similar to jobs you applied for",
+		},
+		{
+			"nothing in common",
+			Seeker{Profile: Profile{Skills: []string{"Figma"}, ExpectedRoles: []string{"Designer"}}, Location: "Pune"},
+			Opening{Requirements: backend, Location: "Delhi", WorkType: "onsite"},
+			0,
+			"Newly posted and open to applications",
+		},
+	}
+
+	for _, tt := range tests {
+		got := Recommend(tt.seeker, tt.opening)
+		if got.Score != tt.score || got.Reason != tt.reason {
+			t.Errorf("%s: Recommend = %+v, want score %v reason %q", tt.name, got, tt.score, tt.reason)
+		}
+	}
+}
@@ -0,0 +1,172 @@
+package matching
+
+import "strings"
+
+// skillSynonyms maps alternative spellings of a skill to one canonical name.
+// Keys and values are lowercase; canonical names map to themselves implicitly.
+var skillSynonyms = map[string]string{
+	"golang":                      "go",
+	"js":                          "javascript",
+	"ecmascript":                  "javascript",
+	"es6":                         "javascript",
+	"ts":                          "typescript",
+	"node":                        "node.js",
+	"nodejs":                      "node.js",
+	"react.js":                    "react",
+	"reactjs":                     "react",
+	"vue":                         "vue.js",
+	"vuejs":                       "vue.js",
+	"angularjs":                   "angular",
+	"next":                        "next.js",
+	"nextjs":                      "next.js",
+	"express":                     "express.js",
+	"expressjs":                   "express.js",
+	"py":                          "python",
+	"python3":                     "python",
+	"c#":                          "csharp",
+	"c sharp":                     "csharp",
+	"cpp":                         "c++",
+	"dotnet":                      ".net",
+	"asp.net":                     ".net",
+	"postgres":                    "postgresql",
+	"psql":                        "postgresql",
+	"mongo":                       "mongodb",
+	"mssql":                       "sql server",
+	"ms sql":                      "sql server",
+	"k8s":                         "kubernetes",
+	"kube":                        "kubernetes",
+	"aws":                         "amazon web services",
+	"gcp":                         "google cloud",
+	"google cloud platform":       "google cloud",
+	"azure cloud":                 "azure",
+	"ml":                          "machine learning",
+	"dl":                          "deep learning",
+	"nlp":                         "natural language processing",
+	"ai":                          "artificial intelligence",
+	"sklearn":                     "scikit-learn",
+	"tf":                          "tensorflow",
+	"ci/cd":                       "ci-cd",
+	"cicd":                        "ci-cd",
+	"continuous integration":      "ci-cd",
+	"rest":                        "rest api",
+	"restful":                     "rest api",
+	"restful api":                 "rest api",
+	"gql":                         "graphql",
+	"html5":                       "html",
+	"css3":                        "css",
+	"tailwindcss":                 "tailwind",
+	"tailwind css":                "tailwind",
+	"springboot":                  "spring boot",
+	"ror":                         "ruby on rails",
+	"rails":                       "ruby on rails",
+	"objective c":                 "objective-c",
+	"ux":                          "user experience",
+	"ui":                          "user interface",
+	"amazon s3":                   "s3",
+	"elastic":                     "elasticsearch",
+	"gitlab ci":                   "ci-cd",
+	"github actions":              "ci-cd",
+	"structured query language":   "sql",
+	"object oriented programming": "oop",
+}
+
+// commonSkills are canonical skills with no alternative spellings worth listing above.
+var commonSkills = []string{
+	"java", "kotlin", "scala", "rust", "ruby", "php", "swift", "dart", "r", "matlab", "bash",
+	"sql", "mysql", "sqlite", "redis", "kafka", "rabbitmq", "cassandra", "dynamodb", "oracle",
+	"docker", "terraform", "ansible", "jenkins", "linux", "git", "nginx", "microservices",
+	"html", "css", "sass", "redux", "jquery", "bootstrap", "webpack", "flutter", "react native",
+	"django", "flask", "fastapi", "gin", "laravel", "spring", "hibernate",
+	"pandas", "numpy", "pytorch", "keras", "spark", "hadoop", "airflow", "tableau", "power bi", "excel",
+	"figma", "photoshop", "jira", "agile", "scrum",
+}
+
+// KnownSkills lists every canonical skill and alias in the dictionary.
+func KnownSkills() []string {
+	seen := map[string]bool{}
+	skills := []string{}
+	add := func(s string) {
+		if !seen[s] {
+			seen[s] = true
+			skills = append(skills, s)
+		}
+	}
+	for _, s := range commonSkills {
+		add(s)
+	}
+	for alias, canonical := range skillSynonyms {
+		add(canonical)
+		add(alias)
+	}
+	return skills
+}
+
+// roleSynonyms maps words in job titles and expected roles to a shared form.
+var roleSynonyms = map[string]string{
+	"developer":  "engineer",
+	"dev":        "engineer",
+	"programmer": "engineer",
+	"swe":        "software",
+	"sde":        "software",
+	"back-end":   "backend",
+	"front-end":  "frontend",
+	"fullstack":  "full-stack",
+	"ml":         "machine-learning",
+	"sre":        "devops",
+	"qa":         "quality",
+	"tester":     "quality",
+	"test":       "quality",
+	"ux":         "design",
+	"ui":         "design",
+	"designer":   "design",
+}
+
+// roleNoise are title words that say nothing about the kind of role.
+var roleNoise = map[string]bool{
+	"senior": true, "junior": true, "sr": true, "jr": true, "lead": true, "principal": true,
+	"staff": true, "intern": true, "internship": true, "associate": true, "mid": true, "level": true,
+	"i": true, "ii": true, "iii": true, "the": true, "a": true, "an": true, "of": true, "and": true,
+	"for": true, "in": true, "with": true, "remote": true, "contract": true,
+}
+
+// CanonicalSkill returns the dictionary form of a skill so spelling variants compare equal.
+func CanonicalSkill(skill string) string {
+	s := strings.Join(strings.Fields(strings.ToLower(skill)), " ")
+	if canonical, ok := skillSynonyms[s]; ok {
+		return canonical
+	}
+	return s
+}
+
+// SkillVariants lists every known spelling of a skill, its canonical name first.
+func SkillVariants(skill string) []string {
+	canonical := CanonicalSkill(skill)
+	variants := []string{canonical}
+	for alias, c := range skillSynonyms {
+		if c == canonical {
+			variants = append(variants, alias)
+		}
+	}
+	return variants
+}
+
+// roleTerms splits a title into its meaningful, normalized words.
+func roleTerms(title string) map[string]bool {
+	title = strings.ToLower(title)
+	title = strings.NewReplacer("/", " ", ",", " ", "(", " ", ")", " ", "&", " ").Replace(title)
+	// "full stack" and "machine learning" are one term each
+	title = strings.ReplaceAll(title, "full stack", "full-stack")
+	title = strings.ReplaceAll(title, "machine learning", "machine-learning")
+
+	terms := map[string]bool{}
+	for _, word := range strings.Fields(title) {
+		if roleNoise[word] {
+			continue
+		}
+		if synonym, ok := roleSynonyms[word]; ok {
+			word = synonym
+		}
+		terms[word] = true
+	}
+	return terms
+}
@@ -0,0 +1,59 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// Alert frequencies
+const (
+	AlertInstant = "instant"
+	AlertDaily   = "daily"
+	AlertWeekly  = "weekly"
+)
+
+// Database models
+type JobAlert struct {
+	ID              uuid.UUID      `db:"id" json:"id"`
+	CandidateID     uuid.UUID      `db:"candidate_id" json:"-"`
+	Name            string         `db:"name" json:"name"`
+	Query           string         `db:"query" json:"query"`
+	WorkType        string         `db:"work_type" json:"work_type"`
+	JobType         string         `db:"job_type" json:"job_type"`
+	ExperienceLevel string         `db:"experience_level" json:"experience_level"`
+	SalaryRange     string         `db:"salary_range" json:"salary_range"`
+	RequiredSkills  pq.StringArray `db:"required_skills" json:"required_skills"`
+	Frequency       string         `db:"frequency" json:"frequency"`
+	Active          bool           `db:"active" json:"active"`
+	LastSentAt      *time.Time     `db:"last_sent_at" json:"last_sent_at"` // nullable until the first digest
+	NextRunAt       time.Time      `db:"next_run_at" json:"next_run_at"`
+	CreatedAt       time.Time      `db:"created_at" json:"created_at"`
+	UpdatedAt       time.Time      `db:"updated_at" json:"updated_at"`
+}
+
+// Filters are the saved listing filters, matched the same way as /candidate/getJobs
+func (a JobAlert) Filters() JobListingFilters {
+	return JobListingFilters{
+		Query:           a.Query,
+		WorkType:        a.WorkType,
+		JobType:         a.JobType,
+		ExperienceLevel: a.ExperienceLevel,
+		SalaryRange:     a.SalaryRange,
+		RequiredSkills:  a.RequiredSkills,
+	}
+}
+
+// Handler models
+type JobAlertRequest struct {
+	Name            string   `json:"name" binding:"required,max=100"`
+	Query           string   `json:"query" binding:"max=200"`
+	WorkType        string   `json:"work_type"`
+	JobType         string   `json:"job_type"`
+	ExperienceLevel string   `json:"experience_level"`
+	SalaryRange     string   `json:"salary_range"`
+	RequiredSkills  []string `json:"required_skills"`
+	Frequency       string   `json:"frequency" binding:"required,oneof=instant daily weekly"`
+	Active          *bool    `json:"active"`
+}
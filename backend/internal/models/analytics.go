@@ -0,0 +1,101 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Application sources
+const (
+	SourceDirect         = "direct"
+	SourceSearch         = "search"
+	SourceRecommendation = "recommendation"
+	SourceAlert          = "alert"
+	SourceSaved          = "saved"
+	SourceInvitation     = "invitation"
+)
+
+var ApplicationSources = []string{
+	SourceDirect,
+	SourceSearch,
+	SourceRecommendation,
+	SourceAlert,
+	SourceSaved,
+	SourceInvitation,
+}
+
+// FunnelStages are the application statuses in hiring order. Rejected is reported apart.
+var FunnelStages = []string{"pending", "reviewing", "interview", "offered", "accepted"}
+
+// AnalyticsRange scopes the analytics to applications and views in [From, To),
+// bucketed by Interval (day, week or month) and optionally one listing.
+type AnalyticsRange struct {
+	From      time.Time  `json:"from"`
+	To        time.Time  `json:"to"`
+	Interval  string     `json:"interval"`
+	ListingID *uuid.UUID `json:"listing_id,omitempty"`
+}
+
+type CompanyAnalytics struct {
+	Range               AnalyticsRange     `json:"range"`
+	Listings            []ListingAnalytics `json:"listings"`
+	OverTime            []AnalyticsBucket  `json:"over_time"`
+	Funnel              []FunnelStage      `json:"funnel"`
+	Rejected            int                `json:"rejected"`
+	TimeToFirstResponse DurationStats      `json:"time_to_first_response"`
+	TimeToHire          DurationStats      `json:"time_to_hire"`
+	Sources             []SourceAnalytics  `json:"sources"`
+}
+
+type ListingAnalytics struct {
+	ListingID      uuid.UUID  `db:"listing_id" json:"listing_id"`
+	Title          string     `db:"title" json:"title"`
+	ClosedAt       *time.Time `db:"closed_at" json:"closed_at"`
+	Impressions    int        `db:"impressions" json:"impressions"`
+	Views          int        `db:"views" json:"views"`
+	UniqueViewers  int        `db:"unique_viewers" json:"unique_viewers"`
+	Applications   int        `db:"applications" json:"applications"`
+	ConversionRate *float64   `db:"-" json:"conversion_rate"` // applications per unique viewer, nil without views
+}
+
+type AnalyticsBucket struct {
+	Period       time.Time `db:"period" json:"period"`
+	Impressions  int       `db:"impressions" json:"impressions"`
+	Views        int       `db:"views" json:"views"`
+	Applications int       `db:"applications" json:"applications"`
+}
+
+// FunnelStage counts applications that reached a status, directly or by moving past it
+type FunnelStage struct {
+	Status         string   `json:"status"`
+	Count          int      `json:"count"`
+	ConversionRate *float64 `json:"conversion_rate"` // share of the previous stage, nil for the first
+}
+
+// DurationStats summarizes how long something took, over the applications it happened to
+type DurationStats struct {
+	Count       int      `db:"count" json:"count"`
+	MedianHours *float64 `db:"median_hours" json:"median_hours"` // nil when Count is 0
+}
+
+type SourceAnalytics struct {
+	Source       string `db:"source" json:"source"`
+	Applications int    `db:"applications" json:"applications"`
+	Hired        int    `db:"hired" json:"hired"`
+}
+
+// Listing view kinds
+const (
+	ViewImpression = "impression" // the listing showed up in search results
+	ViewDetail     = "detail"     // the candidate opened the listing
+)
+
+// ListingView is one de-duplicated view waiting to be written
+type ListingView struct {
+	JobID    uuid.UUID
+	ViewerID uuid.UUID
+	Kind     string
+	Bucket   time.Time // start of the de-duplication window
+	ViewedAt time.Time
+}
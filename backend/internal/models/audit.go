@@ -0,0 +1,54 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx/types"
+)
+
+// Audit actions
+const (
+	AuditLogin              = "auth.login"
+	AuditLoginFailed        = "auth.login_failed"
+	AuditRegister           = "auth.register"
+	AuditCandidateRead      = "candidate.read"
+	AuditResumeLink         = "resume.link"
+	AuditApplicationStatus  = "application.status_changed"
+	AuditApplicationDeleted = "application.deleted"
+	AuditListingClosed      = "listing.closed"
+	AuditListingDeleted     = "listing.deleted"
+	AuditAccountErased      = "account.erased"
+	AuditRequest            = "request" // any other state changing request
+)
+
+// Database models
+type AuditEvent struct {
+	ID         uuid.UUID      `db:"id" json:"id"`
+	OccurredAt time.Time      `db:"occurred_at" json:"occurred_at"`
+	ActorID    *uuid.UUID     `db:"actor_id" json:"actor_id"` // nullable for anonymous and system actions
+	ActorRole  string         `db:"actor_role" json:"actor_role"`
+	Action     string         `db:"action" json:"action"`
+	TargetType string         `db:"target_type" json:"target_type"`
+	TargetID   string         `db:"target_id" json:"target_id"`
+	IPAddress  string         `db:"ip_address" json:"ip_address"`
+	UserAgent  string         `db:"user_agent" json:"user_agent"`
+	Method     string         `db:"method" json:"method"`
+	Route      string         `db:"route" json:"route"`
+	StatusCode *int           `db:"status_code" json:"status_code"` // nullable outside requests
+	Before     types.JSONText `db:"before" json:"before"`
+	After      types.JSONText `db:"after" json:"after"`
+	Metadata   types.JSONText `db:"metadata" json:"metadata"`
+}
+
+// Handler models
+type AuditFilters struct {
+	ActorID    *uuid.UUID
+	Action     string
+	TargetType string
+	TargetID   string
+	From       *time.Time
+	To         *time.Time
+	Limit      int
+	Offset     int
+}
@@ -23,6 +23,9 @@ type ExtendedApplication struct {
 	AppliedAt       time.Time      `db:"applied_at" json:"AppliedAt"`
 	CandidateName   string         `db:"candidate_name" json:"CandidateName"`
 	CandidateSkills pq.StringArray `db:"candidate_skills" json:"CandidateSkills"`
+
+	MatchScore       *float64 `db:"match_score" json:"MatchScore"`             // nullable until scored
+	MatchExplanation *string  `db:"match_explanation" json:"MatchExplanation"` // nullable until scored
 }
 
 type AppWithCandidate struct {
@@ -33,6 +36,14 @@ type AppWithCandidate struct {
 	AppliedAt       time.Time `json:"AppliedAt"`
 	CandidateName   string    `json:"CandidateName"`
 	CandidateSkills []string  `json:"CandidateSkills"`
+
+	Notes         []ApplicationNote   `json:"Notes"`
+	Ratings       []ApplicationRating `json:"Ratings"`
+	AverageRating float64             `json:"AverageRating"`
+	Tags          []string            `json:"Tags"`
+
+	MatchScore       *float64 `json:"MatchScore"`
+	MatchExplanation *string  `json:"MatchExplanation"`
 }
 
 type ApplicantPool struct {
@@ -0,0 +1,42 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx/types"
+)
+
+// Email templates
+const (
+	EmailWelcome                  = "welcome"
+	EmailApplicationSubmitted     = "application_submitted"
+	EmailApplicationReceived      = "application_received"
+	EmailApplicationStatusChanged = "application_status_changed"
+	EmailCompanyInvitation        = "company_invitation"
+	EmailTalentInvitation         = "talent_invitation"
+	EmailJobAlert                 = "job_alert"
+)
+
+// Outbox statuses
+const (
+	OutboxPending = "pending"
+	OutboxSent    = "sent"
+	OutboxFailed  = "failed"
+	OutboxSkipped = "skipped"
+)
+
+// Database models
+type OutboxEmail struct {
+	ID            uuid.UUID      `db:"id"`
+	UserID        *uuid.UUID     `db:"user_id"` // nullable for recipients without an account
+	ToEmail       string         `db:"to_email"`
+	Template      string         `db:"template"`
+	Data          types.JSONText `db:"data"`
+	Status        string         `db:"status"`
+	Attempts      int            `db:"attempts"`
+	NextAttemptAt time.Time      `db:"next_attempt_at"`
+	LastError     *string        `db:"last_error"`
+	CreatedAt     time.Time      `db:"created_at"`
+	SentAt        *time.Time     `db:"sent_at"`
+}
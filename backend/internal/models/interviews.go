@@ -0,0 +1,102 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Application statuses
+const (
+	StatusPending   = "pending"
+	StatusReviewing = "reviewing"
+	StatusInterview = "interview"
+	StatusOffered   = "offered"
+	StatusAccepted  = "accepted"
+	StatusRejected  = "rejected"
+)
+
+var ApplicationStatuses = []string{
+	StatusPending, StatusReviewing, StatusInterview, StatusOffered, StatusAccepted, StatusRejected,
+}
+
+// Interview statuses
+const (
+	InterviewProposed  = "proposed"
+	InterviewScheduled = "scheduled"
+	InterviewCancelled = "cancelled"
+	InterviewCompleted = "completed"
+)
+
+// Database models
+type Interview struct {
+	ID              uuid.UUID  `db:"id" json:"id"`
+	ApplicationID   uuid.UUID  `db:"application_id" json:"application_id"`
+	CompanyID       uuid.UUID  `db:"company_id" json:"company_id"`
+	Title           string     `db:"title" json:"title"`
+	Location        string     `db:"location" json:"location"`
+	VideoLink       string     `db:"video_link" json:"video_link"`
+	DurationMinutes int        `db:"duration_minutes" json:"duration_minutes"`
+	Status          string     `db:"status" json:"status"`
+	StartsAt        *time.Time `db:"starts_at" json:"starts_at"` // nullable until a slot is picked
+	EndsAt          *time.Time `db:"ends_at" json:"ends_at"`     // nullable until a slot is picked
+	Sequence        int        `db:"sequence" json:"-"`
+	CreatedBy       *uuid.UUID `db:"created_by" json:"created_by"` // nullable
+	CreatedAt       time.Time  `db:"created_at" json:"created_at"`
+	UpdatedAt       time.Time  `db:"updated_at" json:"updated_at"`
+
+	Slots        []InterviewSlot        `db:"-" json:"slots"`
+	Interviewers []InterviewParticipant `db:"-" json:"interviewers"`
+}
+
+type InterviewSlot struct {
+	ID          uuid.UUID `db:"id" json:"id"`
+	InterviewID uuid.UUID `db:"interview_id" json:"interview_id"`
+	StartsAt    time.Time `db:"starts_at" json:"starts_at"`
+	EndsAt      time.Time `db:"ends_at" json:"ends_at"`
+}
+
+type InterviewParticipant struct {
+	InterviewID uuid.UUID `db:"interview_id" json:"-"`
+	UserID      uuid.UUID `db:"user_id" json:"user_id"`
+	Name        string    `db:"name" json:"name"`
+	Email       string    `db:"email" json:"email"`
+}
+
+// InterviewConflict describes an interviewer who is already booked over a slot.
+type InterviewConflict struct {
+	UserID      uuid.UUID `db:"user_id" json:"user_id"`
+	InterviewID uuid.UUID `db:"interview_id" json:"interview_id"`
+	StartsAt    time.Time `db:"starts_at" json:"starts_at"`
+	EndsAt      time.Time `db:"ends_at" json:"ends_at"`
+}
+
+// Handler models
+type InterviewRequest struct {
+	ApplicationID   string      `json:"application_id" binding:"required"`
+	Title           string      `json:"title" binding:"required"`
+	Location        string      `json:"location,omitempty"`
+	VideoLink       string      `json:"video_link,omitempty"`
+	DurationMinutes int         `json:"duration_minutes" binding:"required,min=5,max=480"`
+	InterviewerIDs  []string    `json:"interviewer_ids" binding:"required,min=1"`
+	Slots           []time.Time `json:"slots" binding:"required,min=1,max=10"`
+}
+
+type SelectSlotRequest struct {
+	SlotID string `json:"slot_id" binding:"required"`
+}
+
+type ApplicationStatusRequest struct {
+	ApplicationID string `json:"application_id" binding:"required"`
+	Status        string `json:"status" binding:"required,oneof=pending reviewing interview offered accepted rejected"`
+}
+
+// InterviewParties holds the people an interview invite is addressed to.
+type InterviewParties struct {
+	CandidateID     uuid.UUID `db:"candidate_id"`
+	CandidateUserID uuid.UUID `db:"candidate_user_id"`
+	CandidateName   string    `db:"candidate_name"`
+	CandidateEmail  string    `db:"candidate_email"`
+	CompanyName     string    `db:"company_name"`
+	JobTitle        string    `db:"job_title"`
+}
@@ -8,15 +8,16 @@ import (
 
 // Handler models
 type JobListingRequest struct {
-	Listing_title     string   `json:"Listing_title"`
-	Description       string   `json:"description"`
-	Location          string   `json:"location"`
-	Work_type         string   `json:"work_type"`
-	Job_type          string   `json:"job_type"`
-	Experience_type   string   `json:"experience_type"`
-	Experience_months string   `json:"experience_months"`
-	Salary_range      string   `json:"salary_range"`
-	Required_skills   []string `json:"required_skills"`
+	Listing_title     string     `json:"Listing_title"`
+	Description       string     `json:"description"`
+	Location          string     `json:"location"`
+	Work_type         string     `json:"work_type"`
+	Job_type          string     `json:"job_type"`
+	Experience_type   string     `json:"experience_type"`
+	Experience_months string     `json:"experience_months"`
+	Salary_range      string     `json:"salary_range"`
+	Required_skills   []string   `json:"required_skills"`
+	Closes_at         *time.Time `json:"closes_at"` // optional application deadline
 }
 
 // Database models
@@ -34,12 +35,29 @@ type JobListing struct {
 	Required_skills   pq.StringArray `db:"required_skills"`
 	CreatedAt         time.Time      `json:"created_at" db:"created_at"`
 	UpdatedAt         time.Time      `db:"updated_at"`
+	ClosedAt          *time.Time     `json:"closed_at" db:"closed_at"` // nullable while open
+	ClosesAt          *time.Time     `json:"closes_at" db:"closes_at"` // nullable without a deadline
+}
+
+// CandidateJobListing is a listing as a candidate browsing jobs sees it
+type CandidateJobListing struct {
+	JobListing
+	Saved   bool `db:"saved" json:"saved"`
+	Applied bool `db:"applied" json:"applied"`
 }
 
 type JobListingFilters struct {
+	Query           string // keyword matched against title and description
 	WorkType        string
 	JobType         string
 	ExperienceLevel string
 	SalaryRange     string
 	RequiredSkills  []string
 }
+
+// JobRecommendation is an open listing suggested to a candidate
+type JobRecommendation struct {
+	Listing JobListing `json:"listing"`
+	Score   float64    `json:"score"`
+	Reason  string     `json:"reason"`
+}
@@ -0,0 +1,53 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Company member roles, from most to least privileged.
+const (
+	MemberRoleOwner     = "owner"
+	MemberRoleAdmin     = "admin"
+	MemberRoleRecruiter = "recruiter"
+	MemberRoleViewer    = "viewer"
+)
+
+// Database models
+type CompanyMember struct {
+	CompanyID uuid.UUID  `db:"company_id" json:"company_id"`
+	UserID    uuid.UUID  `db:"user_id" json:"user_id"`
+	Username  string     `db:"username" json:"username"`
+	Email     string     `db:"email" json:"email"`
+	Role      string     `db:"role" json:"role"`
+	InvitedBy *uuid.UUID `db:"invited_by" json:"invited_by"` // nullable
+	CreatedAt time.Time  `db:"created_at" json:"created_at"`
+}
+
+type CompanyInvitation struct {
+	ID         uuid.UUID  `db:"id" json:"id"`
+	CompanyID  uuid.UUID  `db:"company_id" json:"company_id"`
+	Email      string     `db:"email" json:"email"`
+	Role       string     `db:"role" json:"role"`
+	TokenHash  string     `db:"token_hash" json:"-"`
+	InvitedBy  *uuid.UUID `db:"invited_by" json:"invited_by"` // nullable
+	CreatedAt  time.Time  `db:"created_at" json:"created_at"`
+	ExpiresAt  time.Time  `db:"expires_at" json:"expires_at"`
+	AcceptedAt *time.Time `db:"accepted_at" json:"accepted_at"` // nullable
+}
+
+// Handler models
+type InviteMemberRequest struct {
+	Email string `json:"email" binding:"required,email"`
+	Role  string `json:"role" binding:"required,oneof=admin recruiter viewer"`
+}
+
+type AcceptInvitationRequest struct {
+	Token string `json:"token" binding:"required"`
+}
+
+type UpdateMemberRoleRequest struct {
+	UserID string `json:"user_id" binding:"required"`
+	Role   string `json:"role" binding:"required,oneof=admin recruiter viewer"`
+}
@@ -0,0 +1,52 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Database models
+type MessageThread struct {
+	ID            uuid.UUID  `db:"id" json:"id"`
+	ApplicationID uuid.UUID  `db:"application_id" json:"application_id"`
+	Subject       string     `db:"subject" json:"subject"`
+	CreatedBy     *uuid.UUID `db:"created_by" json:"created_by"` // nullable
+	CreatedAt     time.Time  `db:"created_at" json:"created_at"`
+	LastMessageAt time.Time  `db:"last_message_at" json:"last_message_at"`
+	UnreadCount   int        `db:"unread_count" json:"unread_count"`
+}
+
+type Message struct {
+	ID         uuid.UUID  `db:"id" json:"id"`
+	ThreadID   uuid.UUID  `db:"thread_id" json:"thread_id"`
+	SenderID   *uuid.UUID `db:"sender_id" json:"sender_id"` // nullable once the sender is deleted
+	SenderName string     `db:"sender_name" json:"sender_name"`
+	SenderRole string     `db:"sender_role" json:"sender_role"`
+	Body       string     `db:"body" json:"body"`
+	CreatedAt  time.Time  `db:"created_at" json:"created_at"`
+
+	Attachments []MessageAttachment `db:"-" json:"attachments"`
+}
+
+type MessageAttachment struct {
+	ID          uuid.UUID `db:"id" json:"id"`
+	MessageID   uuid.UUID `db:"message_id" json:"message_id"`
+	FileName    string    `db:"file_name" json:"file_name"`
+	FileKey     string    `db:"file_key" json:"-"`
+	ContentType string    `db:"content_type" json:"content_type"`
+	SizeBytes   int64     `db:"size_bytes" json:"size_bytes"`
+	CreatedAt   time.Time `db:"created_at" json:"created_at"`
+
+	DownloadURL string `db:"-" json:"download_url"` // presigned for the viewer
+}
+
+// ApplicationParties identifies who may take part in conversations about an application.
+type ApplicationParties struct {
+	ApplicationID   uuid.UUID `db:"application_id"`
+	CandidateID     uuid.UUID `db:"candidate_id"`
+	CandidateUserID uuid.UUID `db:"candidate_user_id"`
+	CompanyID       uuid.UUID `db:"company_id"`
+	JobID           uuid.UUID `db:"job_id"`
+	JobTitle        string    `db:"job_title"`
+}
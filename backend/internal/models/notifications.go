@@ -0,0 +1,59 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx/types"
+)
+
+// Notification types
+const (
+	NotifyApplicationCreated       = "application.created"
+	NotifyApplicationStatusChanged = "application.status_changed"
+	NotifyMessageReceived          = "message.received"
+	NotifyInterviewProposed        = "interview.proposed"
+	NotifyInterviewScheduled       = "interview.scheduled"
+	NotifyInterviewCancelled       = "interview.cancelled"
+	NotifyTalentInvitation         = "talent.invitation"
+	NotifyJobAlert                 = "job.alert"
+	NotifySavedJobClosing          = "saved_job.closing"
+)
+
+var NotificationTypes = []string{
+	NotifyApplicationCreated,
+	NotifyApplicationStatusChanged,
+	NotifyMessageReceived,
+	NotifyInterviewProposed,
+	NotifyInterviewScheduled,
+	NotifyInterviewCancelled,
+	NotifyTalentInvitation,
+	NotifyJobAlert,
+	NotifySavedJobClosing,
+}
+
+// Database models
+type Notification struct {
+	ID        uuid.UUID      `db:"id" json:"id"`
+	UserID    uuid.UUID      `db:"user_id" json:"-"`
+	Type      string         `db:"type" json:"type"`
+	Title     string         `db:"title" json:"title"`
+	Body      string         `db:"body" json:"body"`
+	Data      types.JSONText `db:"data" json:"data"`
+	ReadAt    *time.Time     `db:"read_at" json:"read_at"` // nullable
+	CreatedAt time.Time      `db:"created_at" json:"created_at"`
+}
+
+type NotificationPreference struct {
+	Type  string `db:"type" json:"type"`
+	InApp bool   `db:"in_app" json:"in_app"`
+	Email bool   `db:"email" json:"email"`
+}
+
+// Handler models
+// NotificationPreferenceRequest changes the channels that are set; nil channels keep their value.
+type NotificationPreferenceRequest struct {
+	Type  string `json:"type" binding:"required"`
+	InApp *bool  `json:"in_app"`
+	Email *bool  `json:"email"`
+}
@@ -0,0 +1,96 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	DataExportPending = "pending"
+	DataExportReady   = "ready"
+	DataExportFailed  = "failed"
+	DataExportExpired = "expired"
+
+	AccountDeletionPending   = "pending"
+	AccountDeletionErasing   = "erasing" // claimed by the worker, past cancelling
+	AccountDeletionCancelled = "cancelled"
+	AccountDeletionCompleted = "completed"
+)
+
+// Database models
+type DataExport struct {
+	ID            uuid.UUID  `db:"id" json:"id"`
+	UserID        uuid.UUID  `db:"user_id" json:"-"`
+	Status        string     `db:"status" json:"status"`
+	ObjectKey     *string    `db:"object_key" json:"-"`
+	SizeBytes     *int64     `db:"size_bytes" json:"size_bytes"` // nullable until ready
+	Attempts      int        `db:"attempts" json:"-"`
+	NextAttemptAt time.Time  `db:"next_attempt_at" json:"-"`
+	LastError     *string    `db:"last_error" json:"-"`
+	CreatedAt     time.Time  `db:"created_at" json:"created_at"`
+	CompletedAt   *time.Time `db:"completed_at" json:"completed_at"` // nullable
+	ExpiresAt     *time.Time `db:"expires_at" json:"expires_at"`     // nullable
+
+	DownloadURL string `db:"-" json:"download_url,omitempty"`
+}
+
+type AccountDeletion struct {
+	UserID      uuid.UUID  `db:"user_id" json:"-"`
+	Status      string     `db:"status" json:"status"`
+	RequestedAt time.Time  `db:"requested_at" json:"requested_at"`
+	PurgeAfter  time.Time  `db:"purge_after" json:"purge_after"`
+	CompletedAt *time.Time `db:"completed_at" json:"completed_at"` // nullable
+}
+
+// PersonalData is everything exported for a user
+type PersonalData struct {
+	User          ExportedUser          `json:"user"`
+	Candidate     *Candidate            `json:"candidate_profile,omitempty"`
+	Company       *Company              `json:"company,omitempty"`
+	Applications  []ExportedApplication `json:"applications"`
+	Messages      []ExportedMessage     `json:"messages"`
+	Notifications []Notification        `json:"notifications"`
+	SavedJobs     []ExportedSavedJob    `json:"saved_jobs"`
+	JobAlerts     []JobAlert            `json:"job_alerts"`
+}
+
+type ExportedUser struct {
+	ID               uuid.UUID `db:"id" json:"id"`
+	Username         string    `db:"username" json:"username"`
+	Email            string    `db:"email" json:"email"`
+	Role             string    `db:"role" json:"role"`
+	OnboardingStatus string    `db:"onboarding_status" json:"onboarding_status"`
+	CreatedAt        time.Time `db:"created_at" json:"created_at"`
+}
+
+type ExportedApplication struct {
+	ApplicationID uuid.UUID `db:"application_id" json:"application_id"`
+	JobID         uuid.UUID `db:"job_id" json:"job_id"`
+	JobTitle      string    `db:"job_title" json:"job_title"`
+	CompanyName   string    `db:"company_name" json:"company_name"`
+	Status        string    `db:"status" json:"status"`
+	AppliedAt     time.Time `db:"applied_at" json:"applied_at"`
+}
+
+type ExportedSavedJob struct {
+	JobID    uuid.UUID `db:"job_id" json:"job_id"`
+	JobTitle string    `db:"job_title" json:"job_title"`
+	Note     string    `db:"note" json:"note"`
+	SavedAt  time.Time `db:"saved_at" json:"saved_at"`
+}
+
+type ExportedMessage struct {
+	ThreadID   uuid.UUID `db:"thread_id" json:"thread_id"`
+	Subject    string    `db:"subject" json:"subject"`
+	MessageID  uuid.UUID `db:"message_id" json:"message_id"`
+	SenderRole string    `db:"sender_role" json:"sender_role"`
+	SentByMe   bool      `db:"sent_by_me" json:"sent_by_me"`
+	Body       string    `db:"body" json:"body"`
+	CreatedAt  time.Time `db:"created_at" json:"created_at"`
+}
+
+// Handler models
+type DeleteAccountRequest struct {
+	Password string `json:"password" binding:"required"`
+}
@@ -7,6 +7,16 @@ import (
 	"github.com/lib/pq"
 )
 
+// Values of the candidate current_status enum
+const (
+	CandidateActivelyLooking = "ACTIVELY_LOOKING"
+	CandidateOpenToOffers    = "OPEN_TO_OFFERS"
+	CandidateNotLooking      = "NOT_LOOKING"
+	CandidateSwitchingSoon   = "SWITCHING_SOON"
+)
+
+var CandidateStatuses = []string{CandidateActivelyLooking, CandidateOpenToOffers, CandidateNotLooking, CandidateSwitchingSoon}
+
 // Database Definitions
 type Candidate struct {
 	ID            uuid.UUID      `json:"id" db:"id"`
@@ -16,12 +26,17 @@ type Candidate struct {
 	PhoneNumber   string         `json:"phone_number" db:"phone"`
 	LinkedInURL   *string        `json:"linkedin_url" db:"linkedin_url"`   // nullable
 	PortfolioURL  *string        `json:"portfolio_url" db:"portfolio_url"` // nullable
-	ResumeURL     string         `json:"resume_url" db:"resume_url"`
+	ResumeKey     string         `json:"-" db:"resume_key"`
 	Skills        pq.StringArray `json:"skills" db:"skills"`
 	Experience    int            `json:"experience_years" db:"experience_years"`
 	ExpectedRoles string         `json:"expected_roles" db:"expected_role"`  // ARRAY
 	CurrentStatus string         `json:"current_status" db:"current_status"` // ENUM
 	CreatedAt     time.Time      `json:"created_at" db:"created_at"`
+
+	OpenToOpportunities bool `json:"open_to_opportunities" db:"open_to_opportunities"`
+
+	// Short-lived download link, only filled in for viewers allowed to read the resume
+	ResumeURL string `json:"resume_url,omitempty" db:"-"`
 }
 
 type Company struct {
@@ -45,7 +60,7 @@ type CandidateRequest struct {
 	Location        string   `json:"location,omitempty"`
 	LinkedInURL     string   `json:"linkedin_url,omitempty"`
 	PortfolioURL    string   `json:"portfolio_url,omitempty"`
-	ResumeURL       string   `json:"resume_url,omitempty"`
+	ResumeKey       string   `json:"-"`
 	Skills          []string `json:"skills,omitempty"`
 	ExperienceYears int      `json:"experience_years,omitempty"`
 	ExpectedRole    []string `json:"expected_roles" binding:"required"`
@@ -0,0 +1,33 @@
+package models
+
+import "time"
+
+const (
+	ReportSignups                = "signups"
+	ReportOnboarding             = "onboarding"
+	ReportListings               = "listings"
+	ReportApplicationsPerListing = "applications_per_listing"
+	ReportSkills                 = "skills"
+)
+
+// Database models
+type ReportRefresh struct {
+	ViewName    string    `db:"view_name" json:"view_name"`
+	RefreshedAt time.Time `db:"refreshed_at" json:"refreshed_at"`
+	DurationMs  int       `db:"duration_ms" json:"duration_ms"`
+}
+
+// Response models
+type ReportInfo struct {
+	Name        string     `json:"name"`
+	Description string     `json:"description"`
+	Columns     []string   `json:"columns"`
+	Dated       bool       `json:"dated"`        // accepts from/to
+	RefreshedAt *time.Time `json:"refreshed_at"` // nil until the first refresh
+}
+
+// ReportTable holds report rows in column order; values are strings, numbers, booleans or nil.
+type ReportTable struct {
+	ReportInfo
+	Rows [][]any `json:"-"`
+}
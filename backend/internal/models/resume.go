@@ -0,0 +1,57 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	ResumeAccessOwner     = "owner"
+	ResumeAccessApplicant = "applicant" // a company the candidate applied to
+	ResumeAccessScoring   = "scoring"
+)
+
+// Database models
+type ResumeAccess struct {
+	ID          uuid.UUID  `db:"id" json:"id"`
+	CandidateID uuid.UUID  `db:"candidate_id" json:"candidate_id"`
+	UserID      *uuid.UUID `db:"user_id" json:"user_id"`       // nullable
+	CompanyID   *uuid.UUID `db:"company_id" json:"company_id"` // nullable
+	Reason      string     `db:"reason" json:"reason"`
+	IPAddress   string     `db:"ip_address" json:"ip_address"`
+	UserAgent   string     `db:"user_agent" json:"user_agent"`
+	CreatedAt   time.Time  `db:"created_at" json:"created_at"`
+
+	CompanyName *string `db:"company_name" json:"company_name,omitempty"`
+}
+
+// Handler models
+
+// ResumeLink is a short-lived download link for a private resume
+type ResumeLink struct {
+	URL       string    `json:"url"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// ParsedResume holds the profile fields guessed from a resume's text, to pre-fill onboarding
+type ParsedResume struct {
+	FullName        string             `json:"full_name"`
+	Email           string             `json:"email"`
+	Phone           string             `json:"phone"`
+	LinkedInURL     string             `json:"linkedin_url"`
+	PortfolioURL    string             `json:"portfolio_url"`
+	Skills          []string           `json:"skills"`
+	ExperienceYears int                `json:"experience_years"`
+	ExpectedRole    string             `json:"expected_role"`
+	WorkHistory     []WorkHistoryEntry `json:"work_history"`
+}
+
+type WorkHistoryEntry struct {
+	Title       string `json:"title"`
+	Company     string `json:"company"`
+	StartDate   string `json:"start_date"`         // YYYY-MM
+	EndDate     string `json:"end_date,omitempty"` // YYYY-MM, empty while current
+	Current     bool   `json:"current"`
+	Description string `json:"description,omitempty"`
+}
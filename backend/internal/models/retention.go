@@ -0,0 +1,60 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx/types"
+)
+
+const (
+	RetentionRunning   = "running"
+	RetentionCompleted = "completed"
+	RetentionFailed    = "failed"
+
+	RetentionPlanned = "planned"
+	RetentionDone    = "done"
+	RetentionError   = "failed"
+)
+
+// Database models
+type RetentionRun struct {
+	ID         uuid.UUID      `db:"id" json:"id"`
+	DryRun     bool           `db:"dry_run" json:"dry_run"`
+	Status     string         `db:"status" json:"status"`
+	Rules      types.JSONText `db:"rules" json:"rules"`
+	Summary    types.JSONText `db:"summary" json:"summary"`
+	Error      *string        `db:"error" json:"error"` // nullable
+	StartedAt  time.Time      `db:"started_at" json:"started_at"`
+	FinishedAt *time.Time     `db:"finished_at" json:"finished_at"` // nullable while running
+
+	Actions []RetentionAction `db:"-" json:"actions,omitempty"`
+}
+
+type RetentionAction struct {
+	ID             uuid.UUID  `db:"id" json:"id"`
+	RunID          uuid.UUID  `db:"run_id" json:"run_id"`
+	Rule           string     `db:"rule" json:"rule"`
+	Action         string     `db:"action" json:"action"`
+	CandidateID    uuid.UUID  `db:"candidate_id" json:"candidate_id"`
+	UserID         *uuid.UUID `db:"user_id" json:"user_id"`                   // nullable
+	LastActivityAt *time.Time `db:"last_activity_at" json:"last_activity_at"` // nullable
+	Outcome        string     `db:"outcome" json:"outcome"`
+	Detail         string     `db:"detail" json:"detail"`
+	CreatedAt      time.Time  `db:"created_at" json:"created_at"`
+}
+
+// RetentionSubject is a candidate a retention rule applies to
+type RetentionSubject struct {
+	CandidateID    uuid.UUID  `db:"candidate_id"`
+	UserID         *uuid.UUID `db:"user_id"` // nullable once erased
+	ResumeKey      string     `db:"resume_key"`
+	LastActivityAt time.Time  `db:"last_activity_at"`
+}
+
+// RetentionRuleSummary counts what a run did under one rule
+type RetentionRuleSummary struct {
+	Matched int `json:"matched"`
+	Done    int `json:"done"`
+	Failed  int `json:"failed"`
+}
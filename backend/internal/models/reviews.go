@@ -0,0 +1,55 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Database models
+type ApplicationNote struct {
+	ID            uuid.UUID `db:"id" json:"ID"`
+	ApplicationID uuid.UUID `db:"application_id" json:"ApplicationID"`
+	CompanyID     uuid.UUID `db:"company_id" json:"-"`
+	AuthorID      uuid.UUID `db:"author_id" json:"AuthorID"`
+	AuthorName    string    `db:"author_name" json:"AuthorName"`
+	Body          string    `db:"body" json:"Body"`
+	CreatedAt     time.Time `db:"created_at" json:"CreatedAt"`
+	UpdatedAt     time.Time `db:"updated_at" json:"UpdatedAt"`
+}
+
+type ApplicationRating struct {
+	ApplicationID uuid.UUID `db:"application_id" json:"ApplicationID"`
+	CompanyID     uuid.UUID `db:"company_id" json:"-"`
+	ReviewerID    uuid.UUID `db:"reviewer_id" json:"ReviewerID"`
+	ReviewerName  string    `db:"reviewer_name" json:"ReviewerName"`
+	Rating        int       `db:"rating" json:"Rating"`
+	CreatedAt     time.Time `db:"created_at" json:"CreatedAt"`
+	UpdatedAt     time.Time `db:"updated_at" json:"UpdatedAt"`
+}
+
+type ApplicationTag struct {
+	ApplicationID uuid.UUID `db:"application_id"`
+	Tag           string    `db:"tag"`
+}
+
+// Handler models
+type ApplicationNoteRequest struct {
+	Body string `json:"body" binding:"required"`
+}
+
+type ApplicationRatingRequest struct {
+	Rating int `json:"rating" binding:"required,min=1,max=5"`
+}
+
+type ApplicationTagsRequest struct {
+	Tags []string `json:"tags" binding:"required,min=1"`
+}
+
+// ApplicantFilters narrows and orders the applicant pools returned to a company.
+type ApplicantFilters struct {
+	Tags      []string
+	MinRating float64
+	Status    string
+	Sort      string // "score" ranks the best matches first, otherwise oldest application first
+}
@@ -0,0 +1,29 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SavedJob is a bookmarked listing together with the candidate's note
+type SavedJob struct {
+	JobListing
+	Note        string    `db:"note" json:"note"`
+	SavedAt     time.Time `db:"saved_at" json:"saved_at"`
+	Applied     bool      `db:"applied" json:"applied"`
+	ClosingSoon bool      `db:"-" json:"closing_soon"`
+}
+
+// SavedJobClosing is a saved open listing whose deadline is near
+type SavedJobClosing struct {
+	CandidateUserID uuid.UUID `db:"candidate_user_id"`
+	JobID           uuid.UUID `db:"job_id"`
+	JobTitle        string    `db:"job_title"`
+	ClosesAt        time.Time `db:"closes_at"`
+}
+
+// Handler models
+type SaveJobRequest struct {
+	Note string `json:"note" binding:"max=2000"`
+}
@@ -0,0 +1,38 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// Where a match score came from
+const (
+	ScoreSourceAI       = "ai"
+	ScoreSourceFallback = "fallback"
+)
+
+// Database models
+type ApplicationScore struct {
+	ApplicationID uuid.UUID `db:"application_id" json:"application_id"`
+	Score         float64   `db:"score" json:"score"`
+	Explanation   string    `db:"explanation" json:"explanation"`
+	Source        string    `db:"source" json:"source"`
+	ScoredAt      time.Time `db:"scored_at" json:"scored_at"`
+}
+
+// ScoringInput is an application with everything needed to score it
+type ScoringInput struct {
+	ApplicationID   uuid.UUID      `db:"application_id"`
+	CandidateID     uuid.UUID      `db:"candidate_id"`
+	CandidateSkills pq.StringArray `db:"skills"`
+	ExperienceYears int            `db:"experience_years"`
+	ExpectedRole    string         `db:"expected_role"`
+	JobID           uuid.UUID      `db:"job_id"`
+}
+
+// Handler models
+type RescoreListingRequest struct {
+	ListingID string `json:"listing_id" binding:"required"`
+}
@@ -0,0 +1,62 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// Database models
+type TalentProfile struct {
+	CandidateID     uuid.UUID      `db:"candidate_id" json:"candidate_id"`
+	FullName        string         `db:"full_name" json:"full_name"`
+	Location        string         `db:"location" json:"location"`
+	Skills          pq.StringArray `db:"skills" json:"skills"`
+	ExperienceYears int            `db:"experience_years" json:"experience_years"`
+	ExpectedRole    string         `db:"expected_role" json:"expected_role"`
+	CurrentStatus   string         `db:"current_status" json:"current_status"`
+
+	// Contact details stay nil until the company has invited the candidate or they applied to it
+	ContactVisible bool    `db:"contact_visible" json:"contact_visible"`
+	Email          *string `db:"email" json:"email"`
+	Phone          *string `db:"phone" json:"phone"`
+	LinkedInURL    *string `db:"linkedin_url" json:"linkedin_url"`
+	PortfolioURL   *string `db:"portfolio_url" json:"portfolio_url"`
+}
+
+type TalentInvitation struct {
+	ID          uuid.UUID  `db:"id" json:"id"`
+	CompanyID   uuid.UUID  `db:"company_id" json:"company_id"`
+	CandidateID uuid.UUID  `db:"candidate_id" json:"candidate_id"`
+	JobID       uuid.UUID  `db:"job_id" json:"job_id"`
+	Message     string     `db:"message" json:"message"`
+	InvitedBy   *uuid.UUID `db:"invited_by" json:"invited_by"` // nullable
+	CreatedAt   time.Time  `db:"created_at" json:"created_at"`
+
+	CompanyName string `db:"company_name" json:"company_name,omitempty"`
+	JobTitle    string `db:"job_title" json:"job_title,omitempty"`
+}
+
+// TalentSearchFilters narrows a company's search of the talent pool
+type TalentSearchFilters struct {
+	Skills        []string
+	MinExperience *int
+	MaxExperience *int
+	Location      string
+	Status        string
+	ExpectedRole  string
+	Query         string // full text search of resumes
+	Limit         int
+	Offset        int
+}
+
+// Handler models
+type OpenToOpportunitiesRequest struct {
+	Open *bool `json:"open" binding:"required"`
+}
+
+type TalentInviteRequest struct {
+	JobID   string `json:"job_id" binding:"required"`
+	Message string `json:"message" binding:"max=2000"`
+}
@@ -0,0 +1,20 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Database models
+type QuarantinedUpload struct {
+	ID        uuid.UUID  `db:"id" json:"id"`
+	UserID    *uuid.UUID `db:"user_id" json:"user_id"` // nullable
+	Purpose   string     `db:"purpose" json:"purpose"`
+	FileName  string     `db:"file_name" json:"file_name"`
+	FileKey   *string    `db:"file_key" json:"file_key"` // nullable when storing the file failed
+	SHA256    string     `db:"sha256" json:"sha256"`
+	SizeBytes int64      `db:"size_bytes" json:"size_bytes"`
+	Signature string     `db:"signature" json:"signature"`
+	CreatedAt time.Time  `db:"created_at" json:"created_at"`
+}
@@ -18,4 +18,5 @@ type User struct {
 type AuthenticatedUser struct {
 	ID       uuid.UUID
 	Username string
+	Role     string
 }
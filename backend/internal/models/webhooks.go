@@ -0,0 +1,88 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx/types"
+	"github.com/lib/pq"
+)
+
+// Webhook event types
+const (
+	EventApplicationCreated       = "application.created"
+	EventApplicationStatusChanged = "application.status_changed"
+	EventListingClosed            = "listing.closed"
+)
+
+var WebhookEventTypes = []string{
+	EventApplicationCreated,
+	EventApplicationStatusChanged,
+	EventListingClosed,
+}
+
+// Delivery statuses
+const (
+	DeliveryPending   = "pending"
+	DeliverySucceeded = "succeeded"
+	DeliveryFailed    = "failed"
+)
+
+// Database models
+type WebhookEndpoint struct {
+	ID         uuid.UUID      `db:"id" json:"id"`
+	CompanyID  uuid.UUID      `db:"company_id" json:"company_id"`
+	URL        string         `db:"url" json:"url"`
+	Secret     string         `db:"secret" json:"-"`
+	EventTypes pq.StringArray `db:"event_types" json:"event_types"`
+	Active     bool           `db:"active" json:"active"`
+	CreatedBy  *uuid.UUID     `db:"created_by" json:"created_by"` // nullable
+	CreatedAt  time.Time      `db:"created_at" json:"created_at"`
+	UpdatedAt  time.Time      `db:"updated_at" json:"updated_at"`
+}
+
+type WebhookDelivery struct {
+	ID             uuid.UUID      `db:"id" json:"id"`
+	EndpointID     uuid.UUID      `db:"endpoint_id" json:"endpoint_id"`
+	EventID        uuid.UUID      `db:"event_id" json:"event_id"`
+	EventType      string         `db:"event_type" json:"event_type"`
+	Payload        types.JSONText `db:"payload" json:"payload"`
+	Status         string         `db:"status" json:"status"`
+	Attempts       int            `db:"attempts" json:"attempts"`
+	NextAttemptAt  time.Time      `db:"next_attempt_at" json:"next_attempt_at"`
+	LastStatusCode *int           `db:"last_status_code" json:"last_status_code"` // nullable
+	LastError      *string        `db:"last_error" json:"last_error"`             // nullable
+	CreatedAt      time.Time      `db:"created_at" json:"created_at"`
+	DeliveredAt    *time.Time     `db:"delivered_at" json:"delivered_at"` // nullable
+
+	AttemptLog []WebhookDeliveryAttempt `db:"-" json:"attempt_log,omitempty"`
+}
+
+type WebhookDeliveryAttempt struct {
+	ID          uuid.UUID `db:"id" json:"id"`
+	DeliveryID  uuid.UUID `db:"delivery_id" json:"-"`
+	Attempt     int       `db:"attempt" json:"attempt"`
+	StatusCode  *int      `db:"status_code" json:"status_code"` // nullable when no response arrived
+	Error       *string   `db:"error" json:"error"`             // nullable
+	DurationMs  int       `db:"duration_ms" json:"duration_ms"`
+	AttemptedAt time.Time `db:"attempted_at" json:"attempted_at"`
+}
+
+// WebhookDispatch is a claimed delivery together with where and how to send it
+type WebhookDispatch struct {
+	WebhookDelivery
+	URL    string `db:"url"`
+	Secret string `db:"secret"`
+}
+
+// Handler models
+type WebhookEndpointRequest struct {
+	URL        string   `json:"url" binding:"required,url"`
+	EventTypes []string `json:"event_types" binding:"required,min=1"`
+}
+
+type WebhookEndpointUpdateRequest struct {
+	URL        *string  `json:"url" binding:"omitempty,url"`
+	EventTypes []string `json:"event_types"`
+	Active     *bool    `json:"active"`
+}
@@ -0,0 +1,62 @@
+package notify
+
+import (
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/hridaya14/Web-Tech-Project/internal/models"
+)
+
+// Hub fans notifications out to the live streams of each user.
+// It is in-process, so every API instance only reaches the clients connected to it.
+type Hub struct {
+	mu   sync.RWMutex
+	subs map[uuid.UUID]map[chan models.Notification]struct{}
+}
+
+func NewHub() *Hub {
+	return &Hub{subs: make(map[uuid.UUID]map[chan models.Notification]struct{})}
+}
+
+// DefaultHub is the hub used by Send and the stream handler
+var DefaultHub = NewHub()
+
+// Subscribe registers a stream for the user. The returned cancel func must be called when the stream ends.
+func (h *Hub) Subscribe(userID uuid.UUID) (<-chan models.Notification, func()) {
+	ch := make(chan models.Notification, 16)
+
+	h.mu.Lock()
+	if h.subs[userID] == nil {
+		h.subs[userID] = make(map[chan models.Notification]struct{})
+	}
+	h.subs[userID][ch] = struct{}{}
+	h.mu.Unlock()
+
+	cancel := func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		if _, ok := h.subs[userID][ch]; !ok {
+			return
+		}
+		delete(h.subs[userID], ch)
+		if len(h.subs[userID]) == 0 {
+			delete(h.subs, userID)
+		}
+		close(ch)
+	}
+	return ch, cancel
+}
+
+// Publish delivers n to every stream of its user. Slow streams drop the event
+// rather than block the caller; clients catch up from the notifications list.
+func (h *Hub) Publish(n models.Notification) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	for ch := range h.subs[n.UserID] {
+		select {
+		case ch <- n:
+		default:
+		}
+	}
+}
@@ -0,0 +1,180 @@
+package notify
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/google/uuid"
+	"github.com/hridaya14/Web-Tech-Project/internal/database"
+	"github.com/hridaya14/Web-Tech-Project/internal/models"
+	"github.com/jmoiron/sqlx/types"
+)
+
+// Send stores a notification for each user that has the type enabled and pushes it to their open streams.
+// Failures are logged; notifying never fails the action that caused it.
+func Send(userIDs []uuid.UUID, notificationType, title, body string, data map[string]any) {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		log.Printf("Error encoding notification data: %v", err)
+		return
+	}
+
+	for _, userID := range userIDs {
+		if userID == uuid.Nil {
+			// The user erased their account
+			continue
+		}
+
+		enabled, err := database.IsInAppNotificationEnabled(userID, notificationType)
+		if err != nil {
+			log.Printf("Error checking notification preference: %v", err)
+			continue
+		}
+		if !enabled {
+			continue
+		}
+
+		n, err := database.CreateNotification(userID, notificationType, title, body, types.JSONText(payload))
+		if err != nil {
+			continue
+		}
+		DefaultHub.Publish(n)
+	}
+}
+
+// SendToCompany notifies every member of the company except the user who caused the event.
+func SendToCompany(companyID, exceptUserID uuid.UUID, notificationType, title, body string, data map[string]any) {
+	memberIDs, err := database.GetCompanyMemberUserIDs(companyID)
+	if err != nil {
+		log.Printf("Error fetching company members to notify: %v", err)
+		return
+	}
+
+	recipients := make([]uuid.UUID, 0, len(memberIDs))
+	for _, id := range memberIDs {
+		if id != exceptUserID {
+			recipients = append(recipients, id)
+		}
+	}
+	Send(recipients, notificationType, title, body, data)
+}
+
+func ApplicationCreated(applicationID uuid.UUID) {
+	parties, err := database.GetApplicationParties(applicationID)
+	if err != nil {
+		return
+	}
+	candidate, err := database.GetCandidateByID(parties.CandidateID)
+	if err != nil {
+		return
+	}
+
+	SendToCompany(parties.CompanyID, uuid.Nil, models.NotifyApplicationCreated,
+		"New applicant",
+		fmt.Sprintf("%s applied to %s", candidate.FullName, parties.JobTitle),
+		map[string]any{"application_id": parties.ApplicationID, "job_id": parties.JobID},
+	)
+}
+
+func ApplicationStatusChanged(applicationID uuid.UUID, oldStatus, newStatus string) {
+	parties, err := database.GetApplicationParties(applicationID)
+	if err != nil {
+		return
+	}
+
+	Send([]uuid.UUID{parties.CandidateUserID}, models.NotifyApplicationStatusChanged,
+		"Application update",
+		fmt.Sprintf("Your application for %s is now %s", parties.JobTitle, newStatus),
+		map[string]any{
+			"application_id": parties.ApplicationID,
+			"job_id":         parties.JobID,
+			"old_status":     oldStatus,
+			"status":         newStatus,
+		},
+	)
+}
+
+// MessageReceived notifies the other side of the conversation about a new message.
+func MessageReceived(thread models.MessageThread, message models.Message) {
+	parties, err := database.GetApplicationParties(thread.ApplicationID)
+	if err != nil {
+		return
+	}
+
+	data := map[string]any{
+		"application_id": parties.ApplicationID,
+		"thread_id":      thread.ID,
+		"message_id":     message.ID,
+	}
+	title := "New message"
+	body := fmt.Sprintf("%s: %s", message.SenderName, thread.Subject)
+
+	if message.SenderRole == "candidate" {
+		SendToCompany(parties.CompanyID, uuid.Nil, models.NotifyMessageReceived, title, body, data)
+		return
+	}
+	Send([]uuid.UUID{parties.CandidateUserID}, models.NotifyMessageReceived, title, body, data)
+}
+
+func InterviewProposed(interview models.Interview) {
+	parties, err := database.GetInterviewParties(interview.ID)
+	if err != nil {
+		return
+	}
+
+	Send([]uuid.UUID{parties.CandidateUserID}, models.NotifyInterviewProposed,
+		"Interview invitation",
+		fmt.Sprintf("%s invited you to pick a time for \"%s\"", parties.CompanyName, interview.Title),
+		map[string]any{"interview_id": interview.ID, "application_id": interview.ApplicationID},
+	)
+}
+
+func InterviewScheduled(interview models.Interview) {
+	parties, err := database.GetInterviewParties(interview.ID)
+	if err != nil || interview.StartsAt == nil {
+		return
+	}
+
+	Send(interviewRecipients(interview, parties), models.NotifyInterviewScheduled,
+		"Interview scheduled",
+		fmt.Sprintf("\"%s\" with %s is scheduled for %s", interview.Title, parties.CandidateName,
+			interview.StartsAt.UTC().Format("Mon 2 Jan 2006 15:04 MST")),
+		map[string]any{"interview_id": interview.ID, "application_id": interview.ApplicationID},
+	)
+}
+
+func InterviewCancelled(interview models.Interview) {
+	parties, err := database.GetInterviewParties(interview.ID)
+	if err != nil {
+		return
+	}
+
+	Send(interviewRecipients(interview, parties), models.NotifyInterviewCancelled,
+		"Interview cancelled",
+		fmt.Sprintf("\"%s\" with %s has been cancelled", interview.Title, parties.CompanyName),
+		map[string]any{"interview_id": interview.ID, "application_id": interview.ApplicationID},
+	)
+}
+
+// interviewRecipients is the candidate plus every interviewer
+func interviewRecipients(interview models.Interview, parties models.InterviewParties) []uuid.UUID {
+	recipients := []uuid.UUID{parties.CandidateUserID}
+	for _, p := range interview.Interviewers {
+		recipients = append(recipients, p.UserID)
+	}
+	return recipients
+}
+
+func TalentInvited(invitation models.TalentInvitation) {
+	candidate, err := database.GetCandidateByID(invitation.CandidateID)
+	if err != nil {
+		return
+	}
+
+	Send([]uuid.UUID{candidate.UserID}, models.NotifyTalentInvitation,
+		"Invitation to apply",
+		fmt.Sprintf("%s invited you to apply for %s", invitation.CompanyName, invitation.JobTitle),
+		map[string]any{"invitation_id": invitation.ID, "job_id": invitation.JobID},
+	)
+}
@@ -0,0 +1,98 @@
+package policy
+
+import (
+	"github.com/google/uuid"
+	"github.com/hridaya14/Web-Tech-Project/internal/models"
+)
+
+const (
+	roleCandidate = "candidate"
+	roleCompany   = "company"
+	roleAdmin     = "admin"
+)
+
+// Visibility is how much of a candidate profile a viewer may see
+type Visibility int
+
+const (
+	Hidden   Visibility = iota // answered as if the candidate did not exist
+	Redacted                   // profile without contact details or resume
+	Full
+)
+
+func (v Visibility) String() string {
+	switch v {
+	case Redacted:
+		return "redacted"
+	case Full:
+		return "full"
+	default:
+		return "hidden"
+	}
+}
+
+// Viewer is the user reading a profile. CompanyID is only set for company users.
+type Viewer struct {
+	UserID    uuid.UUID
+	Role      string
+	CompanyID uuid.UUID
+}
+
+// CandidateFacts is what the policy needs to know about a candidate relative to the viewer
+type CandidateFacts struct {
+	UserID              uuid.UUID
+	OpenToOpportunities bool
+	AppliedToViewer     bool // applied to a listing of the viewer's company
+	InvitedByViewer     bool // invited from the talent pool by the viewer's company
+}
+
+// CandidateVisibility decides what viewer sees of a candidate profile: candidates their own,
+// companies their applicants and the talent pool members they invited in full and other
+// talent pool members redacted, admins everything.
+func CandidateVisibility(viewer Viewer, candidate CandidateFacts) Visibility {
+	switch viewer.Role {
+	case roleAdmin:
+		return Full
+	case roleCandidate:
+		if viewer.UserID == candidate.UserID {
+			return Full
+		}
+	case roleCompany:
+		if viewer.CompanyID == uuid.Nil {
+			return Hidden
+		}
+		if candidate.AppliedToViewer {
+			return Full
+		}
+		if candidate.OpenToOpportunities {
+			// Talent pool search shows invited candidates' contact details too
+			if candidate.InvitedByViewer {
+				return Full
+			}
+			return Redacted
+		}
+	}
+	return Hidden
+}
+
+// ResumeAccess says why viewer may download the candidate's resume, one of the
+// models.ResumeAccess reasons, or "" when they may not. Only the owner and companies
+// the candidate applied to are ever handed the file.
+func ResumeAccess(viewer Viewer, candidate CandidateFacts) string {
+	if viewer.UserID == candidate.UserID {
+		return models.ResumeAccessOwner
+	}
+	if viewer.Role == roleCompany && viewer.CompanyID != uuid.Nil && candidate.AppliedToViewer {
+		return models.ResumeAccessApplicant
+	}
+	return ""
+}
+
+// RedactCandidate clears the contact details and resume of a profile.
+func RedactCandidate(candidate *models.Candidate) {
+	candidate.PhoneNumber = ""
+	candidate.LinkedInURL = nil
+	candidate.PortfolioURL = nil
+	candidate.ResumeKey = ""
+	candidate.ResumeURL = ""
+}
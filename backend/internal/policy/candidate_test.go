@@ -0,0 +1,88 @@
+package policy
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/hridaya14/Web-Tech-Project/internal/models"
+)
+
+func TestCandidateVisibility(t *testing.T) {
+	owner := uuid.New()
+	company := uuid.New()
+
+	tests := []struct {
+		name      string
+		viewer    Viewer
+		candidate CandidateFacts
+		want      Visibility
+	}{
+		{"admin", Viewer{UserID: uuid.New(), Role: roleAdmin}, CandidateFacts{UserID: owner}, Full},
+		{"owner", Viewer{UserID: owner, Role: roleCandidate}, CandidateFacts{UserID: owner}, Full},
+		{"other candidate", Viewer{UserID: uuid.New(), Role: roleCandidate}, CandidateFacts{UserID: owner, OpenToOpportunities: true}, Hidden},
+		{"company applied to", Viewer{UserID: uuid.New(), Role: roleCompany, CompanyID: company}, CandidateFacts{UserID: owner, AppliedToViewer: true}, Full},
+		{"company, open to opportunities", Viewer{UserID: uuid.New(), Role: roleCompany, CompanyID: company}, CandidateFacts{UserID: owner, OpenToOpportunities: true}, Redacted},
+		{"company, invited talent", Viewer{UserID: uuid.New(), Role: roleCompany, CompanyID: company}, CandidateFacts{UserID: owner, OpenToOpportunities: true, InvitedByViewer: true}, Full},
+		{"company, invited but opted out", Viewer{UserID: uuid.New(), Role: roleCompany, CompanyID: company}, CandidateFacts{UserID: owner, InvitedByViewer: true}, Hidden},
+		{"company, not open", Viewer{UserID: uuid.New(), Role: roleCompany, CompanyID: company}, CandidateFacts{UserID: owner}, Hidden},
+		{"company user without a company", Viewer{UserID: uuid.New(), Role: roleCompany}, CandidateFacts{UserID: owner, AppliedToViewer: true, OpenToOpportunities: true}, Hidden},
+		{"unknown role", Viewer{UserID: owner, Role: "guest"}, CandidateFacts{UserID: owner}, Hidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CandidateVisibility(tt.viewer, tt.candidate); got != tt.want {
+				t.Errorf("CandidateVisibility() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResumeAccess(t *testing.T) {
+	owner := uuid.New()
+	company := uuid.New()
+
+	tests := []struct {
+		name      string
+		viewer    Viewer
+		candidate CandidateFacts
+		want      string
+	}{
+		{"owner", Viewer{UserID: owner, Role: roleCandidate}, CandidateFacts{UserID: owner}, models.ResumeAccessOwner},
+		{"company applied to", Viewer{UserID: uuid.New(), Role: roleCompany, CompanyID: company}, CandidateFacts{UserID: owner, AppliedToViewer: true}, models.ResumeAccessApplicant},
+		{"company, invited talent", Viewer{UserID: uuid.New(), Role: roleCompany, CompanyID: company}, CandidateFacts{UserID: owner, OpenToOpportunities: true, InvitedByViewer: true}, ""},
+		{"company, talent pool only", Viewer{UserID: uuid.New(), Role: roleCompany, CompanyID: company}, CandidateFacts{UserID: owner, OpenToOpportunities: true}, ""},
+		{"company user without a company", Viewer{UserID: uuid.New(), Role: roleCompany}, CandidateFacts{UserID: owner, AppliedToViewer: true}, ""},
+		{"admin", Viewer{UserID: uuid.New(), Role: roleAdmin}, CandidateFacts{UserID: owner}, ""},
+		{"other candidate", Viewer{UserID: uuid.New(), Role: roleCandidate}, CandidateFacts{UserID: owner, AppliedToViewer: true}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ResumeAccess(tt.viewer, tt.candidate); got != tt.want {
+				t.Errorf("ResumeAccess() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRedactCandidate(t *testing.T) {
+	url := "https://example.com"
+	candidate := models.Candidate{
+		FullName:     "Ada Lovelace",
+		PhoneNumber:  "555-0100",
+		LinkedInURL:  &url,
+		PortfolioURL: &url,
+		ResumeKey:    "resumes/a.pdf",
+		ResumeURL:    "https://example.com/a.pdf",
+	}
+
+	RedactCandidate(&candidate)
+
+	if candidate.PhoneNumber != "" || candidate.LinkedInURL != nil || candidate.PortfolioURL != nil || candidate.ResumeKey != "" || candidate.ResumeURL != "" {
+		t.Errorf("contact details left after redaction: %+v", candidate)
+	}
+	if candidate.FullName != "Ada Lovelace" {
+		t.Errorf("FullName = %q, want it kept", candidate.FullName)
+	}
+}
@@ -0,0 +1,95 @@
+package privacy
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hridaya14/Web-Tech-Project/internal/database"
+	"github.com/hridaya14/Web-Tech-Project/pkg/bucket"
+)
+
+type archiveFile struct {
+	name  string
+	value any
+}
+
+// BuildArchive packs a user's data into a ZIP: one JSON file per kind of record and
+// the resume file as it was uploaded.
+func BuildArchive(ctx context.Context, storage bucket.Storage, userID uuid.UUID) ([]byte, error) {
+	data, err := database.GetPersonalData(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+	modified := time.Now()
+
+	files := []archiveFile{
+		{"user.json", data.User},
+		{"applications.json", data.Applications},
+		{"messages.json", data.Messages},
+		{"notifications.json", data.Notifications},
+	}
+	if data.Candidate != nil {
+		files = append(files,
+			archiveFile{"candidate_profile.json", data.Candidate},
+			archiveFile{"saved_jobs.json", data.SavedJobs},
+			archiveFile{"job_alerts.json", data.JobAlerts},
+		)
+	}
+	if data.Company != nil {
+		files = append(files, archiveFile{"company.json", data.Company})
+	}
+
+	for _, file := range files {
+		body, err := json.MarshalIndent(file.value, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("could not encode %s: %w", file.name, err)
+		}
+		w, err := archive.CreateHeader(&zip.FileHeader{Name: file.name, Method: zip.Deflate, Modified: modified})
+		if err != nil {
+			return nil, err
+		}
+		if _, err := w.Write(body); err != nil {
+			return nil, err
+		}
+	}
+
+	if data.Candidate != nil && data.Candidate.ResumeKey != "" {
+		if err := addObject(ctx, archive, storage, data.Candidate.ResumeKey, "resume"+path.Ext(data.Candidate.ResumeKey), modified); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := archive.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// addObject copies a stored object into the archive. Objects that are already gone are skipped.
+func addObject(ctx context.Context, archive *zip.Writer, storage bucket.Storage, key, name string, modified time.Time) error {
+	body, _, err := storage.Get(ctx, key)
+	if errors.Is(err, bucket.ErrNotFound) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	defer body.Close()
+
+	w, err := archive.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Store, Modified: modified})
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(w, body)
+	return err
+}
@@ -0,0 +1,138 @@
+package privacy
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log"
+	"math"
+	"time"
+
+	"github.com/hridaya14/Web-Tech-Project/internal/audit"
+	"github.com/hridaya14/Web-Tech-Project/internal/database"
+	"github.com/hridaya14/Web-Tech-Project/internal/models"
+	"github.com/hridaya14/Web-Tech-Project/pkg/bucket"
+	"github.com/hridaya14/Web-Tech-Project/pkg/poll"
+)
+
+const (
+	// DeletionGracePeriod is how long an erasure request can still be cancelled
+	DeletionGracePeriod = 30 * 24 * time.Hour
+	// ExportRetention is how long a finished export stays available for download
+	ExportRetention = 7 * 24 * time.Hour
+
+	pollInterval  = 30 * time.Second
+	batchSize     = 5
+	claimLease    = 10 * time.Minute
+	exportTimeout = 5 * time.Minute
+	maxAttempts   = 5
+)
+
+// RunWorker builds requested exports, removes expired ones and erases accounts whose
+// grace period is over, until ctx is cancelled.
+func RunWorker(ctx context.Context, storage bucket.Storage) {
+	log.Println("✅ Privacy worker started")
+
+	poll.Run(ctx, pollInterval, nil, func() {
+		poll.Drain(ctx, batchSize, func(limit int) ([]models.DataExport, error) {
+			return database.ClaimDueDataExports(limit, claimLease)
+		}, func(export models.DataExport) {
+			buildExport(ctx, storage, export)
+		})
+		expireExports(ctx, storage)
+		eraseDue(ctx, storage)
+	})
+}
+
+func buildExport(ctx context.Context, storage bucket.Storage, export models.DataExport) {
+	ctx, cancel := context.WithTimeout(ctx, exportTimeout)
+	defer cancel()
+
+	archive, err := BuildArchive(ctx, storage, export.UserID)
+	if err != nil {
+		failExport(export, err)
+		return
+	}
+
+	key := bucket.NewKey("exports", ".zip")
+	if _, err := storage.Put(ctx, key, bytes.NewReader(archive), int64(len(archive)), "application/zip"); err != nil {
+		failExport(export, err)
+		return
+	}
+
+	if err := database.CompleteDataExport(export.ID, key, int64(len(archive)), time.Now().Add(ExportRetention)); err != nil {
+		storage.Delete(context.Background(), key)
+	}
+}
+
+// failExport schedules a retry with exponential backoff, giving up after maxAttempts.
+func failExport(export models.DataExport, buildErr error) {
+	final := export.Attempts >= maxAttempts
+	backoff := time.Duration(math.Pow(2, float64(export.Attempts))) * time.Minute
+
+	log.Printf("Error building data export %s (attempt %d): %v", export.ID, export.Attempts, buildErr)
+	database.FailDataExport(export.ID, buildErr.Error(), time.Now().Add(backoff), final)
+}
+
+func expireExports(ctx context.Context, storage bucket.Storage) {
+	exports, err := database.GetExpiredDataExports(batchSize * 10)
+	if err != nil {
+		return
+	}
+
+	for _, export := range exports {
+		if export.ObjectKey != nil {
+			if err := storage.Delete(ctx, *export.ObjectKey); err != nil && !errors.Is(err, bucket.ErrNotFound) {
+				log.Printf("Error removing expired data export %s: %v", export.ID, err)
+				continue
+			}
+		}
+		database.MarkDataExportExpired(export.ID)
+	}
+}
+
+func eraseDue(ctx context.Context, storage bucket.Storage) {
+	deletions, err := database.GetDueAccountDeletions(batchSize)
+	if err != nil {
+		return
+	}
+
+	for _, deletion := range deletions {
+		if ctx.Err() != nil {
+			return
+		}
+		if err := Erase(ctx, storage, deletion); err != nil {
+			log.Printf("Error erasing account %s: %v", deletion.UserID, err)
+		}
+	}
+}
+
+// Erase claims the deletion, removes the user's stored files, then anonymizes and deletes
+// the account. Once claimed it can no longer be cancelled, so no file is removed for an
+// account that stays; if a later step fails the claimed erasure is retried with its keys.
+func Erase(ctx context.Context, storage bucket.Storage, deletion models.AccountDeletion) error {
+	// The user may have cancelled since the deletion was listed
+	claimed, err := database.ClaimAccountDeletion(deletion.UserID)
+	if err != nil || !claimed {
+		return err
+	}
+
+	keys, err := database.GetUserStorageKeys(deletion.UserID)
+	if err != nil {
+		return err
+	}
+
+	for _, key := range keys {
+		if err := storage.Delete(ctx, key); err != nil && !errors.Is(err, bucket.ErrNotFound) {
+			return err
+		}
+	}
+
+	if err := database.EraseAccount(deletion.UserID); err != nil {
+		return err
+	}
+
+	audit.System(models.AuditAccountErased, audit.Target{Type: "user", ID: deletion.UserID.String()}, nil,
+		map[string]any{"requested_at": deletion.RequestedAt, "files": len(keys)})
+	return nil
+}
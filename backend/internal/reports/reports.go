@@ -0,0 +1,46 @@
+package reports
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/hridaya14/Web-Tech-Project/internal/database"
+)
+
+const DefaultInterval = time.Hour
+
+// IntervalFromEnv reads REPORTS_REFRESH_INTERVAL, how often the report views are rebuilt.
+func IntervalFromEnv() (time.Duration, error) {
+	v := os.Getenv("REPORTS_REFRESH_INTERVAL")
+	if v == "" {
+		return DefaultInterval, nil
+	}
+	interval, err := time.ParseDuration(v)
+	if err != nil || interval <= 0 {
+		return 0, fmt.Errorf("invalid REPORTS_REFRESH_INTERVAL %q", v)
+	}
+	return interval, nil
+}
+
+// RunScheduler refreshes the admin report views on start and every interval after.
+func RunScheduler(ctx context.Context, interval time.Duration) {
+	log.Printf("✅ Report refresher started (every %s)", interval)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := database.RefreshReportViews(ctx); err != nil && ctx.Err() == nil {
+			log.Printf("Error refreshing reports: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,185 @@
+package resume
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hridaya14/Web-Tech-Project/internal/models"
+)
+
+var (
+	monthNames = map[string]int{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}
+	numericDate  = regexp.MustCompile(`^(\d{1,2})[/.-](\d{4})$`)
+	namedDate    = regexp.MustCompile(`(?i)^([a-z]+)\.?\s*'?(\d{2,4})$`)
+	headerSplit  = regexp.MustCompile(`\s+(?:at|@)\s+|\s*[|,]\s*|\s+[-–—]\s+`)
+	bulletPrefix = regexp.MustCompile(`^[•·●▪■◦*-]\s*`)
+	trailingJunk = regexp.MustCompile(`[\s(|,–—-]+$`)
+	leadingJunk  = regexp.MustCompile(`^[\s)|,–—-]+`)
+)
+
+const maxDescriptionLength = 500
+
+// findWorkHistory reads one entry per line carrying a date range, most recent first.
+// The title and company come from the text before the dates or, failing that, the line above.
+func findWorkHistory(section []string) []models.WorkHistoryEntry {
+	entries := []models.WorkHistoryEntry{}
+	var current *models.WorkHistoryEntry
+
+	for i, line := range section {
+		loc := dateRange.FindStringSubmatchIndex(line)
+		if loc == nil {
+			if current != nil {
+				text := bulletPrefix.ReplaceAllString(line, "")
+				if current.Description != "" {
+					text = current.Description + " " + text
+				}
+				if len(text) <= maxDescriptionLength {
+					current.Description = text
+				}
+			}
+			continue
+		}
+
+		start, ok := parseDate(line[loc[2]:loc[3]], false)
+		if !ok {
+			continue
+		}
+		endText := strings.ToLower(line[loc[4]:loc[5]])
+		entry := models.WorkHistoryEntry{StartDate: start}
+		switch endText {
+		case "present", "current", "now", "today", "date":
+			entry.Current = true
+		default:
+			end, ok := parseDate(endText, true)
+			if !ok {
+				continue
+			}
+			entry.EndDate = end
+		}
+
+		header := trailingJunk.ReplaceAllString(line[:loc[0]], "")
+		if header == "" {
+			header = leadingJunk.ReplaceAllString(line[loc[1]:], "")
+		}
+		if header == "" && i > 0 && dateRange.FindStringIndex(section[i-1]) == nil {
+			header = section[i-1]
+			// The line above was taken as the previous entry's description
+			if current != nil && strings.HasSuffix(current.Description, header) {
+				current.Description = strings.TrimSpace(strings.TrimSuffix(current.Description, header))
+			}
+		}
+		entry.Title, entry.Company = splitHeader(header)
+
+		entries = append(entries, entry)
+		current = &entries[len(entries)-1]
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		if entries[i].Current != entries[j].Current {
+			return entries[i].Current
+		}
+		return entries[i].StartDate > entries[j].StartDate
+	})
+	return entries
+}
+
+// splitHeader reads "Title at Company", "Title | Company", "Title, Company" and "Title - Company".
+func splitHeader(header string) (string, string) {
+	parts := headerSplit.Split(strings.TrimSpace(header), 3)
+	title := strings.TrimSpace(parts[0])
+	company := ""
+	if len(parts) > 1 {
+		company = strings.TrimSpace(parts[1])
+	}
+	return title, company
+}
+
+// parseDate turns "Jan 2020", "01/2020" or "2020" into YYYY-MM. A bare year is taken as
+// January when it starts a range and December when it ends one.
+func parseDate(raw string, end bool) (string, bool) {
+	raw = strings.TrimSpace(raw)
+
+	if year, err := strconv.Atoi(raw); err == nil && len(raw) == 4 {
+		m := 1
+		if end {
+			m = 12
+		}
+		return fmt.Sprintf("%04d-%02d", year, m), true
+	}
+	if m := numericDate.FindStringSubmatch(raw); m != nil {
+		mon, _ := strconv.Atoi(m[1])
+		year, _ := strconv.Atoi(m[2])
+		if mon < 1 || mon > 12 {
+			return "", false
+		}
+		return fmt.Sprintf("%04d-%02d", year, mon), true
+	}
+	if m := namedDate.FindStringSubmatch(raw); m != nil {
+		name := strings.ToLower(m[1])
+		if len(name) < 3 {
+			return "", false
+		}
+		mon, ok := monthNames[name[:3]]
+		if !ok {
+			return "", false
+		}
+		year, _ := strconv.Atoi(m[2])
+		if len(m[2]) == 2 {
+			year += 2000
+			if year > time.Now().Year() {
+				year -= 100
+			}
+		}
+		return fmt.Sprintf("%04d-%02d", year, mon), true
+	}
+	return "", false
+}
+
+// experienceMonths adds up the months covered by the entries, counting overlapping jobs once.
+func experienceMonths(entries []models.WorkHistoryEntry, now time.Time) int {
+	type span struct{ start, end int }
+	spans := []span{}
+	for _, e := range entries {
+		start, ok := monthIndex(e.StartDate)
+		if !ok {
+			continue
+		}
+		end := now.Year()*12 + int(now.Month()) - 1
+		if !e.Current {
+			if end, ok = monthIndex(e.EndDate); !ok {
+				continue
+			}
+		}
+		if end >= start {
+			spans = append(spans, span{start, end + 1})
+		}
+	}
+
+	sort.Slice(spans, func(i, j int) bool { return spans[i].start < spans[j].start })
+	total, reach := 0, -1
+	for _, s := range spans {
+		if s.start > reach {
+			reach = s.start
+		}
+		if s.end > reach {
+			total += s.end - reach
+			reach = s.end
+		}
+	}
+	return total
+}
+
+func monthIndex(date string) (int, bool) {
+	t, err := time.Parse("2006-01", date)
+	if err != nil {
+		return 0, false
+	}
+	return t.Year()*12 + int(t.Month()) - 1, true
+}
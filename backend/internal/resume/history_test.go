@@ -0,0 +1,93 @@
+package resume
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/hridaya14/Web-Tech-Project/internal/models"
+)
+
+func TestParseDate(t *testing.T) {
+	tests := []struct {
+		raw  string
+		end  bool
+		want string
+		ok   bool
+	}{
+		{"2020", false, "2020-01", true},
+		{"2020", true, "2020-12", true},
+		{"Jan 2020", false, "2020-01", true},
+		{"September 2019", false, "2019-09", true},
+		{"Sept. 2019", false, "2019-09", true},
+		{"03/2018", false, "2018-03", true},
+		{"3.2018", false, "2018-03", true},
+		{"13/2018", false, "", false},
+		// Two-digit years are taken as the latest year not in the future
+		{"Mar '19", false, "2019-03", true},
+		{"Feb 22", true, "2022-02", true},
+		{"Dec 99", true, "1999-12", true},
+		{"Jun 85", false, "1985-06", true},
+		{"Ma 2020", false, "", false},
+		{"Foo 2020", false, "", false},
+		{"20", false, "", false},
+	}
+
+	for _, tt := range tests {
+		got, ok := parseDate(tt.raw, tt.end)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("parseDate(%q, %v) = %q, %v, want %q, %v", tt.raw, tt.end, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestExperienceMonths(t *testing.T) {
+	now := time.Date(2024, time.June, 15, 0, 0, 0, 0, time.UTC)
+	job := func(start, end string) models.WorkHistoryEntry {
+		return models.WorkHistoryEntry{StartDate: start, EndDate: end, Current: end == ""}
+	}
+
+	tests := []struct {
+		name    string
+		entries []models.WorkHistoryEntry
+		want    int
+	}{
+		{"none", nil, 0},
+		{"single month", []models.WorkHistoryEntry{job("2020-01", "2020-01")}, 1},
+		{"one year", []models.WorkHistoryEntry{job("2020-01", "2020-12")}, 12},
+		{"current job runs to now", []models.WorkHistoryEntry{job("2024-01", "")}, 6},
+		{"gap between jobs", []models.WorkHistoryEntry{job("2018-01", "2018-12"), job("2020-01", "2020-06")}, 18},
+		{"overlap counted once", []models.WorkHistoryEntry{job("2018-01", "2019-12"), job("2019-01", "2020-12")}, 36},
+		{"nested job", []models.WorkHistoryEntry{job("2015-01", "2020-12"), job("2016-01", "2016-06")}, 72},
+		{"adjacent jobs", []models.WorkHistoryEntry{job("2019-01", "2019-06"), job("2019-07", "2019-12")}, 12},
+		{"unordered with current", []models.WorkHistoryEntry{job("2023-01", ""), job("2022-01", "2023-06")}, 30},
+		{"end before start ignored", []models.WorkHistoryEntry{job("2021-01", "2020-01")}, 0},
+		{"unparsable ignored", []models.WorkHistoryEntry{job("sometime", "2020-01"), job("2020-01", "2020-03")}, 3},
+	}
+
+	for _, tt := range tests {
+		if got := experienceMonths(tt.entries, now); got != tt.want {
+			t.Errorf("%s: experienceMonths = %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestFindWorkHistory(t *testing.T) {
+	section := []string{
+		"Backend Engineer @ Acme | Jan 2022 - Present",
+		"• Owned the search service",
+		"• Cut latency in half",
+		"Oct 2019 – Dec 2021 Developer, Initech",
+		"Support Engineer - Globex",
+		"2017 to 2019",
+	}
+
+	want := []models.WorkHistoryEntry{
+		{Title: "Backend Engineer", Company: "Acme", StartDate: "2022-01", Current: true, Description: "Owned the search service Cut latency in half"},
+		{Title: "Developer", Company: "Initech", StartDate: "2019-10", EndDate: "2021-12"},
+		{Title: "Support Engineer", Company: "Globex", StartDate: "2017-01", EndDate: "2019-12"},
+	}
+	if got := findWorkHistory(section); !reflect.DeepEqual(got, want) {
+		t.Errorf("findWorkHistory =\n%+v\nwant\n%+v", got, want)
+	}
+}
@@ -0,0 +1,238 @@
+package resume
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hridaya14/Web-Tech-Project/internal/matching"
+	"github.com/hridaya14/Web-Tech-Project/internal/models"
+)
+
+// Section headings, lowercase and without trailing colons
+var (
+	skillHeadings = map[string]bool{
+		"skills": true, "technical skills": true, "key skills": true, "core skills": true,
+		"technologies": true, "tech stack": true, "competencies": true, "core competencies": true,
+		"tools": true, "tools & technologies": true, "skills & tools": true,
+	}
+	experienceHeadings = map[string]bool{
+		"experience": true, "work experience": true, "professional experience": true,
+		"employment": true, "employment history": true, "work history": true, "career history": true,
+	}
+	otherHeadings = map[string]bool{
+		"education": true, "projects": true, "personal projects": true, "summary": true,
+		"profile": true, "objective": true, "certifications": true, "awards": true,
+		"achievements": true, "publications": true, "languages": true, "interests": true,
+		"hobbies": true, "contact": true, "references": true, "volunteering": true,
+	}
+)
+
+var (
+	emailPattern    = regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`)
+	phonePattern    = regexp.MustCompile(`\+?\(?\d[\d\s().-]{7,}\d`)
+	linkedInPattern = regexp.MustCompile(`(?i)(?:https?://)?(?:[a-z]{2,3}\.)?linkedin\.com/in/[A-Za-z0-9_%-]+/?`)
+	urlPattern      = regexp.MustCompile(`(?i)(?:https?://|www\.)[^\s,;|()<>]+|(?:github|gitlab|behance|dribbble)\.com/[A-Za-z0-9_.-]+`)
+	skillSeparators = regexp.MustCompile(`[,;|•·●▪■◦/]|\s-\s|\t`)
+	nonSkillPrefix  = regexp.MustCompile(`^(?i)[a-z &]+:\s*`)
+
+	month     = `(?:jan|feb|mar|apr|may|jun|jul|aug|sep|sept|oct|nov|dec)[a-z]*\.?`
+	dateToken = `(?:` + month + `\s*'?\d{2,4}|\d{1,2}[/.-]\d{4}|\d{4})`
+	dateRange = regexp.MustCompile(`(?i)(` + dateToken + `)\s*(?:-|–|—|to|until)\s*(` + dateToken + `|present|current|now|today|date)`)
+)
+
+// ambiguousSkills are dictionary terms that are ordinary words in prose; they only count
+// when listed in a skills section.
+var ambiguousSkills = map[string]bool{
+	"go": true, "r": true, "rest": true, "next": true, "express": true, "elastic": true,
+	"spring": true, "rails": true, "node": true, "ai": true, "ml": true, "dl": true, "ui": true,
+	"ux": true, "ts": true, "tf": true, "js": true, "py": true, "kube": true, "excel": true,
+	"agile": true, "tools": true, "oracle": true, "swift": true, "dart": true, "git": true,
+}
+
+// Parse guesses profile fields from the plain text of a resume.
+func Parse(text string) models.ParsedResume {
+	lines := splitLines(text)
+	parsed := models.ParsedResume{
+		Skills:      []string{},
+		WorkHistory: []models.WorkHistoryEntry{},
+	}
+
+	parsed.FullName = guessName(lines)
+	parsed.Email = emailPattern.FindString(text)
+	parsed.Phone = findPhone(text)
+	if linkedIn := linkedInPattern.FindString(text); linkedIn != "" {
+		parsed.LinkedInURL = withScheme(linkedIn)
+	}
+	for _, u := range urlPattern.FindAllString(text, -1) {
+		if !strings.Contains(strings.ToLower(u), "linkedin.com") && !strings.Contains(u, "@") {
+			parsed.PortfolioURL = withScheme(strings.TrimRight(u, "./"))
+			break
+		}
+	}
+
+	sections := splitSections(lines)
+	parsed.Skills = findSkills(sections["skills"], text)
+	parsed.WorkHistory = findWorkHistory(sections["experience"])
+	if len(parsed.WorkHistory) > 0 {
+		parsed.ExpectedRole = parsed.WorkHistory[0].Title
+		parsed.ExperienceYears = experienceMonths(parsed.WorkHistory, time.Now()) / 12
+	}
+
+	return parsed
+}
+
+func splitLines(text string) []string {
+	lines := []string{}
+	for _, line := range strings.Split(text, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// headingKind tells whether a line is a section heading, and of which section.
+func headingKind(line string) string {
+	key := strings.ToLower(strings.TrimSpace(strings.TrimRight(strings.TrimSpace(line), ":")))
+	switch {
+	case skillHeadings[key]:
+		return "skills"
+	case experienceHeadings[key]:
+		return "experience"
+	case otherHeadings[key]:
+		return "other"
+	}
+	return ""
+}
+
+// splitSections groups the lines under each heading; "skills" and "experience" are the ones parsed.
+func splitSections(lines []string) map[string][]string {
+	sections := map[string][]string{}
+	current := ""
+	for _, line := range lines {
+		if kind := headingKind(line); kind != "" {
+			current = kind
+			continue
+		}
+		// "Skills: Go, Python" puts the section on the heading line. Within a skills section
+		// labels such as "Languages:" only group skills.
+		if prefix := nonSkillPrefix.FindString(line); prefix != "" {
+			if kind := headingKind(prefix); kind != "" && !(current == "skills" && kind == "other") {
+				sections[kind] = append(sections[kind], strings.TrimSpace(line[len(prefix):]))
+				continue
+			}
+		}
+		if current != "" {
+			sections[current] = append(sections[current], line)
+		}
+	}
+	return sections
+}
+
+// guessName takes the first short line near the top made only of words, which is where resumes put the name.
+func guessName(lines []string) string {
+	for i, line := range lines {
+		if i >= 5 {
+			break
+		}
+		if headingKind(line) != "" || strings.ContainsAny(line, "@0123456789/:|,") {
+			continue
+		}
+		words := strings.Fields(line)
+		if len(words) < 2 || len(words) > 4 {
+			continue
+		}
+		onlyLetters := true
+		for _, r := range line {
+			if !(r == ' ' || r == '.' || r == '\'' || r == '-' || (r >= 'A' && r <= 'Z') || (r >= 'a' && r <= 'z') || r > 0x7f) {
+				onlyLetters = false
+				break
+			}
+		}
+		if onlyLetters {
+			return line
+		}
+	}
+	return ""
+}
+
+func findPhone(text string) string {
+	for _, candidate := range phonePattern.FindAllString(text, -1) {
+		digits := 0
+		for _, r := range candidate {
+			if r >= '0' && r <= '9' {
+				digits++
+			}
+		}
+		// Date ranges like 2019 - 2021 also match the pattern
+		if digits >= 10 && digits <= 15 && !dateRange.MatchString(candidate) {
+			return strings.TrimSpace(candidate)
+		}
+	}
+	return ""
+}
+
+func withScheme(u string) string {
+	if strings.HasPrefix(strings.ToLower(u), "http") {
+		return u
+	}
+	return "https://" + strings.TrimPrefix(u, "www.")
+}
+
+// findSkills takes everything listed in the skills section and adds dictionary skills mentioned elsewhere.
+func findSkills(section []string, text string) []string {
+	skills := []string{}
+	seen := map[string]bool{}
+	add := func(skill string) {
+		skill = strings.Trim(strings.TrimSpace(skill), ".-*")
+		canonical := matching.CanonicalSkill(skill)
+		if canonical == "" || seen[canonical] || len(skill) > 40 || len(strings.Fields(skill)) > 4 {
+			return
+		}
+		seen[canonical] = true
+		skills = append(skills, skill)
+	}
+
+	for _, line := range section {
+		// "Languages: Go, Python" lists skills after a label
+		line = nonSkillPrefix.ReplaceAllString(line, "")
+		for _, item := range skillSeparators.Split(line, -1) {
+			add(item)
+		}
+	}
+
+	lower := " " + strings.ToLower(text) + " "
+	known := matching.KnownSkills()
+	sort.Strings(known)
+	for _, skill := range known {
+		if ambiguousSkills[skill] || len(skill) < 2 {
+			continue
+		}
+		if containsTerm(lower, skill) {
+			add(matching.CanonicalSkill(skill))
+		}
+	}
+	return skills
+}
+
+// containsTerm finds term in text as a whole word; text must be lowercase and padded with spaces.
+func containsTerm(text, term string) bool {
+	for offset := 0; ; {
+		i := strings.Index(text[offset:], term)
+		if i < 0 {
+			return false
+		}
+		start := offset + i
+		end := start + len(term)
+		if !isWordByte(text[start-1]) && (end >= len(text) || !isWordByte(text[end])) {
+			return true
+		}
+		offset = start + 1
+	}
+}
+
+func isWordByte(b byte) bool {
+	return (b >= 'a' && b <= 'z') || (b >= '0' && b <= '9') || b == '+' || b == '#'
+}
@@ -0,0 +1,183 @@
+package resume
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hridaya14/Web-Tech-Project/internal/models"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name  string
+		text  string
+		want  models.ParsedResume
+		years int // -1 when a current job makes it depend on today
+	}{
+		{
+			name: "single column with inline skills",
+			text: `Jane Doe
+jane.doe@example.com | +91 98765 43210 | linkedin.com/in/janedoe | github.com/janedoe
+
+Summary
+Backend engineer who likes distributed systems.
+
+Skills: Go, PostgreSQL, Docker, Kubernetes
+
+Experience
+Senior Software Engineer at Acme Corp  Jan 2021 - Present
+• Built the payments API
+Software Engineer | Initech
+Jun 2018 - Dec 2020
+- Maintained Django services
+Intern, Globex  2017 - 2018
+
+Education
+B.Tech Computer Science 2013 - 2017`,
+			want: models.ParsedResume{
+				FullName:     "Jane Doe",
+				Email:        "jane.doe@example.com",
+				Phone:        "+91 98765 43210",
+				LinkedInURL:  "https://linkedin.com/in/janedoe",
+				PortfolioURL: "https://github.com/janedoe",
+				Skills:       []string{"Go", "PostgreSQL", "Docker", "Kubernetes", "django"},
+				ExpectedRole: "Senior Software Engineer",
+				WorkHistory: []models.WorkHistoryEntry{
+					{Title: "Senior Software Engineer", Company: "Acme Corp", StartDate: "2021-01", Current: true, Description: "Built the payments API"},
+					{Title: "Software Engineer", Company: "Initech", StartDate: "2018-06", EndDate: "2020-12", Description: "Maintained Django services"},
+					{Title: "Intern", Company: "Globex", StartDate: "2017-01", EndDate: "2018-12"},
+				},
+			},
+			years: -1,
+		},
+		{
+			name: "labelled skills and two-digit years",
+			text: `RAHUL SHARMA
+Email: rahul@mail.co.in
+www.rahul.dev
+
+TECHNICAL SKILLS
+Languages: Python, JavaScript / TypeScript
+Frameworks: React; Node.js; Express
+
+WORK EXPERIENCE
+Frontend Developer - Webify
+Mar '19 to Feb '22
+Worked with react and redux.
+Data Analyst at Numbers Inc 01/2016 – 12/2018`,
+			want: models.ParsedResume{
+				FullName:     "RAHUL SHARMA",
+				Email:        "rahul@mail.co.in",
+				PortfolioURL: "https://rahul.dev",
+				Skills:       []string{"Python", "JavaScript", "TypeScript", "React", "Node.js", "Express", "redux"},
+				ExpectedRole: "Frontend Developer",
+				WorkHistory: []models.WorkHistoryEntry{
+					{Title: "Frontend Developer", Company: "Webify", StartDate: "2019-03", EndDate: "2022-02", Description: "Worked with react and redux."},
+					{Title: "Data Analyst", Company: "Numbers Inc", StartDate: "2016-01", EndDate: "2018-12"},
+				},
+			},
+			years: 6,
+		},
+		{
+			name: "ambiguous words in prose",
+			text: `Priya Nair
+I like to go hiking and rest on weekends. Built tools in Rust.`,
+			want: models.ParsedResume{
+				FullName:    "Priya Nair",
+				Skills:      []string{"rust"},
+				WorkHistory: []models.WorkHistoryEntry{},
+			},
+		},
+		{
+			name: "empty",
+			text: "",
+			want: models.ParsedResume{Skills: []string{}, WorkHistory: []models.WorkHistoryEntry{}},
+		},
+	}
+
+	for _, tt := range tests {
+		got := Parse(tt.text)
+		if tt.years >= 0 && got.ExperienceYears != tt.years {
+			t.Errorf("%s: ExperienceYears = %d, want %d", tt.name, got.ExperienceYears, tt.years)
+		}
+		got.ExperienceYears = tt.want.ExperienceYears
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: Parse =\n%+v\nwant\n%+v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestFindPhone(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"Call me on +91 98765 43210 anytime", "+91 98765 43210"},
+		{"Phone: (415) 555-0134 ext", "(415) 555-0134"},
+		{"+44 20 7946 0958", "+44 20 7946 0958"},
+		// Date ranges match the phone pattern but are not numbers to call
+		{"B.Sc Physics 2012 - 2016 (2018)", ""},
+		{"Worked 2014-2016 2019", ""},
+		{"2012 - 2016\n+1 415 555 0134", "+1 415 555 0134"},
+		// Too short or too long to be a phone number
+		{"Order 1234567", ""},
+		{"Card 1234 5678 9012 3456 7890", ""},
+	}
+
+	for _, tt := range tests {
+		if got := findPhone(tt.text); got != tt.want {
+			t.Errorf("findPhone(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestSplitSections(t *testing.T) {
+	lines := splitLines(`Jane Doe
+Skills: Go, SQL
+Experience:
+Engineer at Acme 2019 - 2021
+  Technical Skills :   Docker
+Education
+B.Sc 2015 - 2019
+Note: not a heading
+Skills
+Languages: Rust, C++`)
+
+	sections := splitSections(lines)
+	want := map[string][]string{
+		"skills":     {"Go, SQL", "Docker", "Languages: Rust, C++"},
+		"experience": {"Engineer at Acme 2019 - 2021"},
+		"other":      {"B.Sc 2015 - 2019", "Note: not a heading"},
+	}
+	if !reflect.DeepEqual(sections, want) {
+		t.Errorf("splitSections = %v, want %v", sections, want)
+	}
+}
+
+func TestContainsTerm(t *testing.T) {
+	tests := []struct {
+		text  string
+		term  string
+		found bool
+	}{
+		{"built apis in rust", "rust", true},
+		{"trusted by teams", "rust", false},
+		{"c++ and c# daily", "c++", true},
+		{"c++ and c# daily", "c#", true},
+		{"wrote c", "c", true},
+		{"objective-c developer", "c", true},
+		{"using go1.22", "go", false},
+		{"react, react-native", "react-native", true},
+		{"node.js, next.js", "next.js", true},
+		{"python3 scripts", "python", false},
+		{"python python3", "python", true},
+	}
+
+	for _, tt := range tests {
+		text := " " + strings.ToLower(tt.text) + " "
+		if got := containsTerm(text, tt.term); got != tt.found {
+			t.Errorf("containsTerm(%q, %q) = %v, want %v", tt.text, tt.term, got, tt.found)
+		}
+	}
+}
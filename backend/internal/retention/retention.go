@@ -0,0 +1,123 @@
+package retention
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"time"
+
+	"github.com/hridaya14/Web-Tech-Project/internal/database"
+	"github.com/hridaya14/Web-Tech-Project/internal/models"
+	"github.com/hridaya14/Web-Tech-Project/pkg/bucket"
+)
+
+// maxSubjectsPerRule bounds one run; whatever is left is picked up by the next
+const maxSubjectsPerRule = 500
+
+// RunScheduler applies the rules every cfg.Interval until ctx is cancelled.
+// In dry-run mode each run only reports what it would remove.
+func RunScheduler(ctx context.Context, storage bucket.Storage, cfg Config) {
+	if cfg.Mode == ModeOff {
+		return
+	}
+	log.Printf("✅ Retention scheduler started (%s)", cfg.Mode)
+
+	ticker := time.NewTicker(cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := Run(ctx, storage, cfg, cfg.Mode != ModeEnforce); err != nil {
+			log.Printf("Error running retention rules: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Run applies every enabled rule once and records each subject it matched. A dry run
+// records them as planned and changes nothing else.
+func Run(ctx context.Context, storage bucket.Storage, cfg Config, dryRun bool) (models.RetentionRun, error) {
+	rules := cfg.Rules(time.Now())
+
+	snapshot, err := json.Marshal(cfg)
+	if err != nil {
+		return models.RetentionRun{}, err
+	}
+	run, err := database.CreateRetentionRun(dryRun, snapshot)
+	if err != nil {
+		return models.RetentionRun{}, err
+	}
+
+	summary := map[string]*models.RetentionRuleSummary{}
+	var runErr error
+	for _, rule := range rules {
+		counts := &models.RetentionRuleSummary{}
+		summary[rule.Name] = counts
+
+		subjects, err := rule.find(rule.Cutoff, maxSubjectsPerRule)
+		if err != nil {
+			runErr = err
+			break
+		}
+		counts.Matched = len(subjects)
+
+		for _, subject := range subjects {
+			if ctx.Err() != nil {
+				runErr = ctx.Err()
+				break
+			}
+
+			action := models.RetentionAction{
+				RunID:          run.ID,
+				Rule:           rule.Name,
+				Action:         rule.Action,
+				CandidateID:    subject.CandidateID,
+				UserID:         subject.UserID,
+				LastActivityAt: &subject.LastActivityAt,
+				Outcome:        models.RetentionPlanned,
+			}
+			if !dryRun {
+				if err := rule.apply(ctx, storage, subject); err != nil {
+					log.Printf("Error applying retention rule %s to candidate %s: %v", rule.Name, subject.CandidateID, err)
+					action.Outcome = models.RetentionError
+					action.Detail = err.Error()
+					counts.Failed++
+				} else {
+					action.Outcome = models.RetentionDone
+					counts.Done++
+				}
+			}
+
+			recorded, err := database.RecordRetentionAction(action)
+			if err == nil {
+				run.Actions = append(run.Actions, recorded)
+			}
+		}
+		if runErr != nil {
+			break
+		}
+	}
+
+	encoded, err := json.Marshal(summary)
+	if err != nil {
+		return run, err
+	}
+	if err := database.FinishRetentionRun(run.ID, encoded, runErr); err != nil {
+		return run, err
+	}
+
+	run.Summary = encoded
+	run.Status = models.RetentionCompleted
+	if runErr != nil {
+		run.Status = models.RetentionFailed
+		message := runErr.Error()
+		run.Error = &message
+	}
+	finished := time.Now()
+	run.FinishedAt = &finished
+	return run, runErr
+}
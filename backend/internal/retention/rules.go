@@ -0,0 +1,129 @@
+package retention
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/hridaya14/Web-Tech-Project/internal/database"
+	"github.com/hridaya14/Web-Tech-Project/internal/models"
+	"github.com/hridaya14/Web-Tech-Project/internal/privacy"
+	"github.com/hridaya14/Web-Tech-Project/pkg/bucket"
+)
+
+const (
+	ModeOff     = "off"
+	ModeDryRun  = "dry-run"
+	ModeEnforce = "enforce"
+)
+
+// Config holds the retention rules. A zero age turns its rule off.
+type Config struct {
+	Mode                   string        `json:"mode"`
+	Interval               time.Duration `json:"-"`
+	RejectedResumeMonths   int           `json:"rejected_resume_months"`
+	InactiveCandidateYears int           `json:"inactive_candidate_years"`
+}
+
+// DefaultConfig reports what the rules would remove without removing anything.
+func DefaultConfig() Config {
+	return Config{
+		Mode:                   ModeDryRun,
+		Interval:               24 * time.Hour,
+		RejectedResumeMonths:   6,
+		InactiveCandidateYears: 3,
+	}
+}
+
+// ConfigFromEnv reads RETENTION_MODE (off, dry-run or enforce), RETENTION_INTERVAL,
+// RETENTION_REJECTED_RESUME_MONTHS and RETENTION_INACTIVE_CANDIDATE_YEARS.
+func ConfigFromEnv() (Config, error) {
+	cfg := DefaultConfig()
+
+	switch mode := os.Getenv("RETENTION_MODE"); mode {
+	case "":
+	case ModeOff, ModeDryRun, ModeEnforce:
+		cfg.Mode = mode
+	default:
+		return Config{}, fmt.Errorf("unknown retention mode %q", mode)
+	}
+
+	if v := os.Getenv("RETENTION_INTERVAL"); v != "" {
+		interval, err := time.ParseDuration(v)
+		if err != nil || interval <= 0 {
+			return Config{}, fmt.Errorf("invalid RETENTION_INTERVAL %q", v)
+		}
+		cfg.Interval = interval
+	}
+
+	for name, target := range map[string]*int{
+		"RETENTION_REJECTED_RESUME_MONTHS":   &cfg.RejectedResumeMonths,
+		"RETENTION_INACTIVE_CANDIDATE_YEARS": &cfg.InactiveCandidateYears,
+	} {
+		if v := os.Getenv(name); v != "" {
+			n, err := strconv.Atoi(v)
+			if err != nil || n < 0 {
+				return Config{}, fmt.Errorf("invalid %s %q", name, v)
+			}
+			*target = n
+		}
+	}
+
+	return cfg, nil
+}
+
+// Rule finds candidates past a retention limit and removes the data it covers
+type Rule struct {
+	Name   string
+	Action string
+	Cutoff time.Time
+	find   func(cutoff time.Time, limit int) ([]models.RetentionSubject, error)
+	apply  func(ctx context.Context, storage bucket.Storage, subject models.RetentionSubject) error
+}
+
+// Rules lists the enabled rules, with cutoffs counted back from now.
+func (c Config) Rules(now time.Time) []Rule {
+	var rules []Rule
+	if c.RejectedResumeMonths > 0 {
+		rules = append(rules, Rule{
+			Name:   "rejected_resumes",
+			Action: "purge_resume",
+			Cutoff: now.AddDate(0, -c.RejectedResumeMonths, 0),
+			find:   database.FindRejectedResumes,
+			apply:  purgeResume,
+		})
+	}
+	if c.InactiveCandidateYears > 0 {
+		rules = append(rules, Rule{
+			Name:   "inactive_candidates",
+			Action: "anonymize_candidate",
+			Cutoff: now.AddDate(-c.InactiveCandidateYears, 0, 0),
+			find:   database.FindInactiveCandidates,
+			apply:  anonymizeCandidate,
+		})
+	}
+	return rules
+}
+
+func purgeResume(ctx context.Context, storage bucket.Storage, subject models.RetentionSubject) error {
+	if err := storage.Delete(ctx, subject.ResumeKey); err != nil && !errors.Is(err, bucket.ErrNotFound) {
+		return err
+	}
+	return database.ClearCandidateResume(subject.CandidateID, subject.ResumeKey)
+}
+
+// anonymizeCandidate erases the account the same way a user's own deletion request does,
+// with no grace period.
+func anonymizeCandidate(ctx context.Context, storage bucket.Storage, subject models.RetentionSubject) error {
+	if subject.UserID == nil {
+		return nil
+	}
+	deletion, err := database.ScheduleAccountDeletion(*subject.UserID, time.Now())
+	if err != nil {
+		return err
+	}
+	return privacy.Erase(ctx, storage, deletion)
+}
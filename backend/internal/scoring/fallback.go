@@ -0,0 +1,39 @@
+package scoring
+
+import (
+	"github.com/hridaya14/Web-Tech-Project/internal/matching"
+	"github.com/hridaya14/Web-Tech-Project/internal/models"
+)
+
+// Fallback scores an application with the built-in matching engine, for when the scoring service cannot answer.
+func Fallback(input models.ScoringInput, listing models.JobListing) models.ApplicationScore {
+	result := Breakdown(input, listing)
+	return models.ApplicationScore{
+		ApplicationID: input.ApplicationID,
+		Score:         result.Score,
+		Explanation:   result.Explanation,
+		Source:        models.ScoreSourceFallback,
+	}
+}
+
+// Breakdown shows how the application's candidate measures up to the listing, skill by skill.
+func Breakdown(input models.ScoringInput, listing models.JobListing) matching.Result {
+	profile := matching.Profile{
+		Skills:          input.CandidateSkills,
+		ExperienceYears: input.ExperienceYears,
+	}
+	if input.ExpectedRole != "" {
+		profile.ExpectedRoles = []string{input.ExpectedRole}
+	}
+
+	return matching.Match(profile, Requirements(listing))
+}
+
+// Requirements describes a listing the way the matching engine expects it.
+func Requirements(listing models.JobListing) matching.Requirements {
+	return matching.Requirements{
+		Title:            listing.Listing_title,
+		RequiredSkills:   listing.Required_skills,
+		ExperienceMonths: matching.ParseExperienceMonths(listing.Experience_months),
+	}
+}
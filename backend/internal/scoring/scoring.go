@@ -0,0 +1,114 @@
+package scoring
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+
+	"github.com/google/uuid"
+	"github.com/hridaya14/Web-Tech-Project/internal/database"
+	"github.com/hridaya14/Web-Tech-Project/internal/models"
+	"github.com/hridaya14/Web-Tech-Project/pkg/ai"
+)
+
+// ScoreApplication scores one application against its listing and stores the result.
+// The built-in fallback is used whenever the scoring service cannot answer.
+func ScoreApplication(ctx context.Context, client ai.Client, applicationID uuid.UUID) error {
+	input, err := database.GetScoringInput(applicationID)
+	if err != nil {
+		return err
+	}
+	listing, err := database.GetJobListingByID(input.JobID)
+	if err != nil {
+		return err
+	}
+	return database.UpsertApplicationScore(score(ctx, client, input, listing))
+}
+
+// score rates one application with the scoring service, or with the fallback when it cannot answer.
+func score(ctx context.Context, client ai.Client, input models.ScoringInput, listing models.JobListing) models.ApplicationScore {
+	s, err := client.ScoreCandidate(ctx, input.CandidateID, Job(listing))
+	if err != nil {
+		log.Printf("Scoring service unavailable for application %s, using fallback: %v", input.ApplicationID, err)
+		return Fallback(input, listing)
+	}
+	return fromAI(input.ApplicationID, s)
+}
+
+// RescoreListing scores every application of the listing again and returns how many were scored.
+// An application that cannot be stored does not stop the rest; their errors are returned joined.
+func RescoreListing(ctx context.Context, client ai.Client, listingID uuid.UUID) (int, error) {
+	listing, err := database.GetJobListingByID(listingID)
+	if err != nil {
+		return 0, err
+	}
+	inputs, err := database.GetListingScoringInputs(listingID)
+	if err != nil {
+		return 0, err
+	}
+
+	scored := 0
+	var errs []error
+	for _, result := range rank(ctx, client, listing, inputs) {
+		if err := database.UpsertApplicationScore(result); err != nil {
+			errs = append(errs, fmt.Errorf("application %s: %w", result.ApplicationID, err))
+			continue
+		}
+		scored++
+	}
+	return scored, errors.Join(errs...)
+}
+
+// rank scores the applications with one call to the scoring service. Those it leaves out,
+// or all of them when it cannot answer, get the fallback score.
+func rank(ctx context.Context, client ai.Client, listing models.JobListing, inputs []models.ScoringInput) []models.ApplicationScore {
+	if len(inputs) == 0 {
+		return nil
+	}
+
+	candidateIDs := make([]uuid.UUID, len(inputs))
+	for i, input := range inputs {
+		candidateIDs[i] = input.CandidateID
+	}
+
+	ranked := map[uuid.UUID]ai.Score{}
+	scores, err := client.RankApplicants(ctx, Job(listing), candidateIDs)
+	if err != nil {
+		log.Printf("Scoring service unavailable for listing %s, using fallback: %v", listing.ID, err)
+	}
+	for _, s := range scores {
+		ranked[s.CandidateID] = s
+	}
+
+	results := make([]models.ApplicationScore, 0, len(inputs))
+	for _, input := range inputs {
+		result := Fallback(input, listing)
+		if s, ok := ranked[input.CandidateID]; ok {
+			result = fromAI(input.ApplicationID, s)
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+// Job describes a listing the way the scoring service expects it.
+func Job(listing models.JobListing) ai.Job {
+	return ai.Job{
+		ID:               listing.ID,
+		Title:            listing.Listing_title,
+		Description:      listing.Description,
+		RequiredSkills:   listing.Required_skills,
+		ExperienceMonths: listing.Experience_months,
+	}
+}
+
+func fromAI(applicationID uuid.UUID, s ai.Score) models.ApplicationScore {
+	return models.ApplicationScore{
+		ApplicationID: applicationID,
+		Score:         math.Max(0, math.Min(100, s.Score)),
+		Explanation:   s.Explanation,
+		Source:        models.ScoreSourceAI,
+	}
+}
@@ -0,0 +1,125 @@
+package scoring
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/hridaya14/Web-Tech-Project/internal/models"
+	"github.com/hridaya14/Web-Tech-Project/pkg/ai"
+)
+
+// stubClient answers with fixed scores, or fails every call when err is set
+type stubClient struct {
+	scores []ai.Score
+	err    error
+}
+
+func (s stubClient) IngestResume(ctx context.Context, resume ai.Resume) error {
+	return s.err
+}
+
+func (s stubClient) ScoreCandidate(ctx context.Context, candidateID uuid.UUID, job ai.Job) (ai.Score, error) {
+	if s.err != nil {
+		return ai.Score{}, s.err
+	}
+	return s.scores[0], nil
+}
+
+func (s stubClient) RankApplicants(ctx context.Context, job ai.Job, candidateIDs []uuid.UUID) ([]ai.Score, error) {
+	return s.scores, s.err
+}
+
+var listing = models.JobListing{
+	ID:              uuid.New(),
+	Listing_title:   "Backend Engineer",
+	Required_skills: []string{"Go", "PostgreSQL"},
+}
+
+func input(skills ...string) models.ScoringInput {
+	return models.ScoringInput{
+		ApplicationID:   uuid.New(),
+		CandidateID:     uuid.New(),
+		CandidateSkills: skills,
+		ExpectedRole:    "Backend Engineer",
+		JobID:           listing.ID,
+	}
+}
+
+func TestFromAIClamps(t *testing.T) {
+	tests := []struct {
+		score float64
+		want  float64
+	}{
+		{-12, 0},
+		{0, 0},
+		{42.5, 42.5},
+		{100, 100},
+		{130, 100},
+	}
+
+	applicationID := uuid.New()
+	for _, tt := range tests {
+		got := fromAI(applicationID, ai.Score{Score: tt.score, Explanation: "why"})
+		if got.Score != tt.want || got.ApplicationID != applicationID || got.Source != models.ScoreSourceAI || got.Explanation != "why" {
+			t.Errorf("fromAI(%v) = %+v, want score %v from the service", tt.score, got, tt.want)
+		}
+	}
+}
+
+func TestScore(t *testing.T) {
+	ctx := context.Background()
+	in := input("Go")
+
+	got := score(ctx, stubClient{scores: []ai.Score{{CandidateID: in.CandidateID, Score: 140}}}, in, listing)
+	if got.Source != models.ScoreSourceAI || got.Score != 100 || got.ApplicationID != in.ApplicationID {
+		t.Errorf("score from the service = %+v, want it clamped to 100", got)
+	}
+
+	got = score(ctx, stubClient{err: ai.ErrCircuitOpen}, in, listing)
+	if want := Fallback(in, listing); got != want {
+		t.Errorf("score with the service down = %+v, want the fallback %+v", got, want)
+	}
+	if got.Source != models.ScoreSourceFallback {
+		t.Errorf("fallback score has source %q", got.Source)
+	}
+
+	// The fake does not know candidates it has not ingested
+	if got := score(ctx, ai.NewFake(), in, listing); got.Source != models.ScoreSourceFallback {
+		t.Errorf("score of an unknown candidate = %+v, want the fallback", got)
+	}
+}
+
+func TestRank(t *testing.T) {
+	ctx := context.Background()
+	known, unknown := input("Go", "PostgreSQL"), input("Go")
+	inputs := []models.ScoringInput{known, unknown}
+
+	fake := ai.NewFake()
+	fake.IngestResume(ctx, ai.Resume{CandidateID: known.CandidateID, Skills: []string{"Go"}})
+
+	// The service scores only the candidates it knows; the rest fall back
+	results := rank(ctx, fake, listing, inputs)
+	if len(results) != 2 {
+		t.Fatalf("rank returned %d results, want 2", len(results))
+	}
+	if results[0].ApplicationID != known.ApplicationID || results[0].Source != models.ScoreSourceAI || results[0].Score != 50 {
+		t.Errorf("known candidate = %+v, want the service's 50", results[0])
+	}
+	if want := Fallback(unknown, listing); results[1] != want {
+		t.Errorf("unknown candidate = %+v, want the fallback %+v", results[1], want)
+	}
+
+	// With the service down every application falls back
+	fake.Err = errors.New("unavailable")
+	for i, result := range rank(ctx, fake, listing, inputs) {
+		if want := Fallback(inputs[i], listing); result != want {
+			t.Errorf("application %d with the service down = %+v, want the fallback %+v", i, result, want)
+		}
+	}
+
+	if results := rank(ctx, fake, listing, nil); len(results) != 0 {
+		t.Errorf("rank of no applications = %+v", results)
+	}
+}
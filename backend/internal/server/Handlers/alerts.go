@@ -0,0 +1,143 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/hridaya14/Web-Tech-Project/internal/alerts"
+	"github.com/hridaya14/Web-Tech-Project/internal/database"
+	"github.com/hridaya14/Web-Tech-Project/internal/models"
+)
+
+// bindJobAlert reads and normalizes a job alert from the request body, replying with 400 when it is invalid.
+func bindJobAlert(c *gin.Context) (models.JobAlertRequest, bool) {
+	var input models.JobAlertRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return models.JobAlertRequest{}, false
+	}
+
+	input.Name = strings.TrimSpace(input.Name)
+	input.Query = strings.TrimSpace(input.Query)
+	if input.Name == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Alert name is required"})
+		return models.JobAlertRequest{}, false
+	}
+
+	skills := []string{}
+	for _, skill := range input.RequiredSkills {
+		if skill = strings.TrimSpace(skill); skill != "" {
+			skills = append(skills, skill)
+		}
+	}
+	input.RequiredSkills = skills
+
+	return input, true
+}
+
+// jobAlertID parses the alert named in the URL, replying with 400 when it is malformed.
+func jobAlertID(c *gin.Context) (uuid.UUID, bool) {
+	alertID, err := uuid.Parse(c.Param("alert_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid alert_id format"})
+		return uuid.Nil, false
+	}
+	return alertID, true
+}
+
+func GetJobAlerts(c *gin.Context) {
+	candidateID, _, ok := getCandidateID(c)
+	if !ok {
+		return
+	}
+
+	jobAlerts, err := database.GetJobAlerts(candidateID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to fetch job alerts"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"alerts": jobAlerts})
+}
+
+// CreateJobAlert saves a search. Only listings posted from now on are sent, each one once.
+func CreateJobAlert(c *gin.Context) {
+	candidateID, _, ok := getCandidateID(c)
+	if !ok {
+		return
+	}
+
+	input, ok := bindJobAlert(c)
+	if !ok {
+		return
+	}
+
+	alert, err := database.CreateJobAlert(candidateID, input, alerts.NextRun(input.Frequency, time.Now()))
+	if err != nil {
+		if err.Error() == "job alert limit reached" {
+			c.JSON(http.StatusConflict, gin.H{"error": fmt.Sprintf("You can have at most %d job alerts", database.MaxJobAlerts)})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Could not create job alert"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"message": "Job alert created successfully", "alert": alert})
+}
+
+// UpdateJobAlert replaces the alert's search and frequency. Listings it already sent are not sent again.
+func UpdateJobAlert(c *gin.Context) {
+	candidateID, _, ok := getCandidateID(c)
+	if !ok {
+		return
+	}
+
+	alertID, ok := jobAlertID(c)
+	if !ok {
+		return
+	}
+
+	input, ok := bindJobAlert(c)
+	if !ok {
+		return
+	}
+
+	alert, err := database.UpdateJobAlert(alertID, candidateID, input, alerts.NextRun(input.Frequency, time.Now()))
+	if err != nil {
+		if err.Error() == "job alert not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Job alert not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Could not update job alert"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Job alert updated successfully", "alert": alert})
+}
+
+func DeleteJobAlert(c *gin.Context) {
+	candidateID, _, ok := getCandidateID(c)
+	if !ok {
+		return
+	}
+
+	alertID, ok := jobAlertID(c)
+	if !ok {
+		return
+	}
+
+	if err := database.DeleteJobAlert(alertID, candidateID); err != nil {
+		if err.Error() == "job alert not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Job alert not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Could not delete job alert"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Job alert deleted successfully"})
+}
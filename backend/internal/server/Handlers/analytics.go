@@ -0,0 +1,89 @@
+package handlers
+
+import (
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/hridaya14/Web-Tech-Project/internal/database"
+	"github.com/hridaya14/Web-Tech-Project/internal/models"
+)
+
+const (
+	defaultAnalyticsRange = 30 * 24 * time.Hour
+	maxAnalyticsRange     = 2 * 366 * 24 * time.Hour
+	maxDailyRange         = 366 * 24 * time.Hour
+)
+
+var analyticsIntervals = []string{"day", "week", "month"}
+
+// GetCompanyAnalytics reports views, applications, the hiring funnel, response times and
+// application sources for the caller's company. from and to default to the last 30 days;
+// listing_id narrows everything to one listing.
+func GetCompanyAnalytics(c *gin.Context) {
+	companyID, _, _, ok := GetCompanyMember(c)
+	if !ok {
+		return
+	}
+
+	from, ok := queryTime(c, "from")
+	if !ok {
+		return
+	}
+	to, ok := queryTime(c, "to")
+	if !ok {
+		return
+	}
+
+	r := models.AnalyticsRange{Interval: c.DefaultQuery("interval", "day")}
+	if !slices.Contains(analyticsIntervals, r.Interval) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "interval must be day, week or month"})
+		return
+	}
+
+	// Stored timestamps are UTC without a zone
+	r.To = time.Now().UTC()
+	if to != nil {
+		r.To = to.UTC()
+	}
+	r.From = r.To.Add(-defaultAnalyticsRange)
+	if from != nil {
+		r.From = from.UTC()
+	}
+
+	switch span := r.To.Sub(r.From); {
+	case span <= 0:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "from must be before to"})
+		return
+	case span > maxAnalyticsRange:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "The range can be at most two years"})
+		return
+	case span > maxDailyRange && r.Interval == "day":
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Use a week or month interval for ranges over a year"})
+		return
+	}
+
+	if raw := c.Query("listing_id"); raw != "" {
+		listingID, err := uuid.Parse(raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid listing_id format"})
+			return
+		}
+		listing, err := database.GetJobListingByID(listingID)
+		if err != nil || listing.Company_id != companyID {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Listing not found"})
+			return
+		}
+		r.ListingID = &listingID
+	}
+
+	analytics, err := database.GetCompanyAnalytics(companyID, r)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to compute analytics"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"analytics": analytics})
+}
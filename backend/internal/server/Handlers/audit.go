@@ -0,0 +1,88 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/hridaya14/Web-Tech-Project/internal/database"
+	"github.com/hridaya14/Web-Tech-Project/internal/models"
+)
+
+const (
+	defaultAuditLimit = 50
+	maxAuditLimit     = 500
+)
+
+// GetAuditEvents searches the audit log by actor, action, target and time range (RFC 3339), newest first.
+func GetAuditEvents(c *gin.Context) {
+	if _, ok := getAdmin(c); !ok {
+		return
+	}
+
+	filters := models.AuditFilters{
+		Action:     c.Query("action"),
+		TargetType: c.Query("target_type"),
+		TargetID:   c.Query("target_id"),
+		Limit:      defaultAuditLimit,
+	}
+
+	if raw := c.Query("actor_id"); raw != "" {
+		actorID, err := uuid.Parse(raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid actor_id format"})
+			return
+		}
+		filters.ActorID = &actorID
+	}
+
+	var ok bool
+	if filters.From, ok = queryTime(c, "from"); !ok {
+		return
+	}
+	if filters.To, ok = queryTime(c, "to"); !ok {
+		return
+	}
+
+	limit, ok := queryInt(c, "limit")
+	if !ok {
+		return
+	}
+	if limit != nil && *limit > 0 {
+		filters.Limit = min(*limit, maxAuditLimit)
+	}
+	offset, ok := queryInt(c, "offset")
+	if !ok {
+		return
+	}
+	if offset != nil {
+		filters.Offset = *offset
+	}
+
+	events, err := database.QueryAuditEvents(filters)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to fetch audit events"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"events": events})
+}
+
+// queryTime reads an optional RFC 3339 timestamp or YYYY-MM-DD date (midnight UTC) query
+// parameter, replying 400 when it is malformed.
+func queryTime(c *gin.Context, name string) (*time.Time, bool) {
+	raw := c.Query(name)
+	if raw == "" {
+		return nil, true
+	}
+	value, err := time.Parse(time.RFC3339, raw)
+	if err != nil {
+		value, err = time.Parse(time.DateOnly, raw)
+	}
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid " + name + ", expected an RFC 3339 timestamp or a date"})
+		return nil, false
+	}
+	return &value, true
+}
@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"github.com/gin-gonic/gin"
+	"github.com/hridaya14/Web-Tech-Project/internal/audit"
 	"github.com/hridaya14/Web-Tech-Project/internal/database"
 	"github.com/hridaya14/Web-Tech-Project/internal/models"
 	"github.com/hridaya14/Web-Tech-Project/pkg/auth"
@@ -29,7 +30,7 @@ type RegisterInput struct {
 	Username string `json:"username" binding:"required"`
 	Email    string `json:"email" binding:"required,email"`
 	Password string `json:"password" binding:"required,min=8"`
-	Role     string `json:"role" binding:"required,oneof=candidate company admin"`
+	Role     string `json:"role" binding:"required,oneof=candidate company"` // admins are provisioned out of band
 }
 
 func RegisterHandler(c *gin.Context) {
@@ -76,6 +77,9 @@ func RegisterHandler(c *gin.Context) {
 		return
 	}
 
+	audit.RecordAs(c, &models.AuthenticatedUser{ID: id, Username: user.Username, Role: user.Role},
+		models.AuditRegister, audit.Target{Type: "user", ID: id.String()}, nil, nil, nil)
+
 	c.JSON(http.StatusCreated, gin.H{"Message": "Successfuly Created User",
 		"user_id": id})
 }
@@ -95,6 +99,7 @@ func LoginHandler(c *gin.Context) {
 	exists, err := database.CheckUserExists(input.Email)
 
 	if !exists || err != nil {
+		audit.Record(c, models.AuditLoginFailed, audit.Target{Type: "user"}, nil, nil, map[string]any{"email": input.Email})
 		c.JSON(
 			http.StatusBadRequest,
 			gin.H{"Error": "Unable to login with provided credentials"},
@@ -120,12 +125,19 @@ func LoginHandler(c *gin.Context) {
 	err = auth.CheckPassword(user.PasswordHash, input.Password)
 
 	if err != nil {
+		audit.Record(c, models.AuditLoginFailed, audit.Target{Type: "user", ID: user.ID.String()}, nil, nil, map[string]any{"email": input.Email})
 		c.JSON(
 			http.StatusBadRequest,
 			gin.H{"Error": "Incorrect Password"})
 		return
 	}
 
+	if err := database.RecordLogin(user.ID); err != nil {
+		log.Printf("Unable to record login for user %s, retention rules may treat them as inactive: %v", user.ID, err)
+	}
+	audit.RecordAs(c, &models.AuthenticatedUser{ID: user.ID, Username: user.Username, Role: user.Role},
+		models.AuditLogin, audit.Target{Type: "user", ID: user.ID.String()}, nil, nil, nil)
+
 	// Generate Token
 	signedToken, err := auth.CreateToken(user.ID, user.Username, user.Role)
 
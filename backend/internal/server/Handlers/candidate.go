@@ -1,15 +1,27 @@
 package handlers
 
 import (
+	"database/sql"
+	"errors"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/hridaya14/Web-Tech-Project/internal/audit"
 	"github.com/hridaya14/Web-Tech-Project/internal/database"
 	"github.com/hridaya14/Web-Tech-Project/internal/models"
+	"github.com/hridaya14/Web-Tech-Project/internal/notify"
+	"github.com/hridaya14/Web-Tech-Project/internal/policy"
+	"github.com/hridaya14/Web-Tech-Project/internal/tracking"
 	"net/http"
+	"slices"
 	"strings"
 )
 
 func GetFilteredJobListings(c *gin.Context) {
+	viewer, ok := GetAuthenticatedUser(c)
+	if !ok {
+		return
+	}
+
 	var filters models.JobListingFilters
 
 	if err := c.ShouldBindQuery(&filters); err != nil {
@@ -24,7 +36,16 @@ func GetFilteredJobListings(c *gin.Context) {
 		filters.RequiredSkills = strings.Split(skillsParam, ",")
 	}
 
-	listings, err := database.GetJobListings(filters)
+	// Candidates see which listings they saved or applied to; a candidate without a
+	// profile yet has done neither
+	candidateID := uuid.Nil
+	if viewer.Role == CANDIDATE {
+		if rawID, err := database.GetUserRelatedID(viewer.ID); err == nil {
+			candidateID, _ = rawID.(uuid.UUID)
+		}
+	}
+
+	listings, err := database.GetJobListings(filters, candidateID)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": "Failed to fetch job listings",
@@ -32,6 +53,14 @@ func GetFilteredJobListings(c *gin.Context) {
 		return
 	}
 
+	if viewer.Role == CANDIDATE {
+		jobIDs := make([]uuid.UUID, 0, len(listings))
+		for _, listing := range listings {
+			jobIDs = append(jobIDs, listing.ID)
+		}
+		tracking.Default.Impressions(viewer.ID, jobIDs)
+	}
+
 	c.JSON(http.StatusOK, gin.H{
 		"listings": listings,
 		"count":    len(listings),
@@ -46,7 +75,8 @@ func CreateJobApplication(c *gin.Context) {
 	}
 
 	var requestBody struct {
-		JobID string `json:"jobId"`
+		JobID  string `json:"jobId"`
+		Source string `json:"source"` // where the candidate found the listing, direct when omitted
 	}
 
 	if err := c.BindJSON(&requestBody); err != nil {
@@ -54,6 +84,14 @@ func CreateJobApplication(c *gin.Context) {
 		return
 	}
 
+	if requestBody.Source == "" {
+		requestBody.Source = models.SourceDirect
+	}
+	if !slices.Contains(models.ApplicationSources, requestBody.Source) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid source"})
+		return
+	}
+
 	jobID, err := uuid.Parse(requestBody.JobID)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid job ID"})
@@ -61,12 +99,19 @@ func CreateJobApplication(c *gin.Context) {
 	}
 
 	// Create application
-	err = database.CreateApplication(candidateID, jobID)
+	applicationID, err := database.CreateApplication(candidateID, jobID, requestBody.Source)
 	if err != nil {
+		if err.Error() == "job listing is closed" {
+			c.JSON(http.StatusConflict, gin.H{"error": "This listing is no longer accepting applications"})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create application"})
 		return
 	}
 
+	go notify.ApplicationCreated(applicationID)
+	go scoreApplication(applicationID)
+
 	c.JSON(http.StatusCreated, gin.H{"message": "Application submitted successfully"})
 }
 
@@ -89,6 +134,11 @@ func GetCandidateApplications(c *gin.Context) {
 }
 
 func GetCandidateHandler(c *gin.Context) {
+	viewer, ok := GetAuthenticatedUser(c)
+	if !ok {
+		return
+	}
+
 	idParam := c.Param("id")
 	candidateID, err := uuid.Parse(idParam)
 	if err != nil {
@@ -97,19 +147,83 @@ func GetCandidateHandler(c *gin.Context) {
 	}
 
 	candidate, err := database.GetCandidateByID(candidateID)
+	if errors.Is(err, sql.ErrNoRows) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "candidate not found"})
+		return
+	} else if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "could not fetch candidate"})
+		return
+	}
+
+	policyViewer, facts, err := candidatePolicy(viewer, candidate)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "could not fetch candidate"})
 		return
 	}
 
-	if candidate.ID == uuid.Nil {
+	// Profiles the viewer may not see are indistinguishable from missing ones
+	visibility := policy.CandidateVisibility(policyViewer, facts)
+	audit.Record(c, models.AuditCandidateRead, audit.Target{Type: "candidate", ID: candidateID.String()}, nil, nil,
+		map[string]any{"visibility": visibility.String()})
+
+	switch visibility {
+	case policy.Hidden:
 		c.JSON(http.StatusNotFound, gin.H{"error": "candidate not found"})
 		return
+	case policy.Redacted:
+		policy.RedactCandidate(&candidate)
+	case policy.Full:
+		if reason := policy.ResumeAccess(policyViewer, facts); reason != "" {
+			if link, err := mintResumeLink(c, candidate, viewer, companyIDOf(policyViewer), reason); err == nil {
+				candidate.ResumeURL = link.URL
+			}
+		}
 	}
 
 	c.JSON(http.StatusOK, candidate)
 }
 
+// candidatePolicy gathers what the visibility policy needs to know about viewer and candidate.
+func candidatePolicy(viewer *models.AuthenticatedUser, candidate models.Candidate) (policy.Viewer, policy.CandidateFacts, error) {
+	policyViewer := policy.Viewer{UserID: viewer.ID, Role: viewer.Role}
+	facts := policy.CandidateFacts{
+		UserID:              candidate.UserID,
+		OpenToOpportunities: candidate.OpenToOpportunities,
+	}
+
+	if viewer.Role != COMPANY {
+		return policyViewer, facts, nil
+	}
+
+	rawID, err := database.GetUserRelatedID(viewer.ID)
+	if errors.Is(err, sql.ErrNoRows) {
+		// A company user who has not joined a company yet sees nobody
+		return policyViewer, facts, nil
+	} else if err != nil {
+		return policyViewer, facts, err
+	}
+	companyID, ok := rawID.(uuid.UUID)
+	if !ok {
+		return policyViewer, facts, nil
+	}
+	policyViewer.CompanyID = companyID
+
+	facts.AppliedToViewer, err = database.CandidateAppliedToCompany(candidate.ID, companyID)
+	if err != nil {
+		return policyViewer, facts, err
+	}
+	facts.InvitedByViewer, err = database.CandidateInvitedByCompany(candidate.ID, companyID)
+	return policyViewer, facts, err
+}
+
+// companyIDOf returns the viewer's company for access logs, nil for other users.
+func companyIDOf(viewer policy.Viewer) *uuid.UUID {
+	if viewer.CompanyID == uuid.Nil {
+		return nil
+	}
+	return &viewer.CompanyID
+}
+
 type deleteApplicationRequest struct {
 	ApplicationID string `json:"application_id" binding:"required"`
 }
@@ -132,7 +246,7 @@ func DeleteApplication(c *gin.Context) {
 		return
 	}
 
-	err = database.DeleteApplicationByID(applicationID, candidateID)
+	application, err := database.DeleteApplicationByID(applicationID, candidateID)
 	if err != nil {
 		switch err.Error() {
 		case "unauthorized: candidate does not own this application or it does not exist":
@@ -146,5 +260,7 @@ func DeleteApplication(c *gin.Context) {
 		return
 	}
 
+	audit.Record(c, models.AuditApplicationDeleted, audit.Target{Type: "application", ID: applicationID.String()}, application, nil, nil)
+
 	c.JSON(http.StatusOK, gin.H{"message": "Application deleted successfully"})
 }
@@ -3,15 +3,22 @@ package handlers
 import (
 	"log"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/hridaya14/Web-Tech-Project/internal/alerts"
+	"github.com/hridaya14/Web-Tech-Project/internal/audit"
 	"github.com/hridaya14/Web-Tech-Project/internal/database"
 	"github.com/hridaya14/Web-Tech-Project/internal/models"
+	"github.com/hridaya14/Web-Tech-Project/internal/notify"
+	"github.com/hridaya14/Web-Tech-Project/internal/tracking"
 )
 
 func CreateJob(c *gin.Context) {
-	companyID, _, ok := GetAuthenticatedID(c)
+	companyID, _, _, ok := GetCompanyMember(c, recruiterRoles...)
 	if !ok {
 		return
 	}
@@ -21,6 +28,10 @@ func CreateJob(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
+	if input.Closes_at != nil && !input.Closes_at.After(time.Now()) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "closes_at must be in the future"})
+		return
+	}
 
 	listing, err := database.CreateJobListing(input, companyID)
 	if err != nil {
@@ -28,6 +39,9 @@ func CreateJob(c *gin.Context) {
 		return
 	}
 
+	// Instant job alerts go out right away instead of on the next tick
+	alerts.Wake()
+
 	c.JSON(http.StatusCreated, gin.H{
 		"Message": "Successfully created listing",
 		"Listing Details": gin.H{
@@ -37,7 +51,7 @@ func CreateJob(c *gin.Context) {
 }
 
 func GetJobListings(c *gin.Context) {
-	companyID, _, ok := GetAuthenticatedID(c)
+	companyID, _, _, ok := GetCompanyMember(c)
 	if !ok {
 		return
 	}
@@ -59,6 +73,11 @@ type getJobDetailsRequest struct {
 }
 
 func GetJobDetailsHandler(c *gin.Context) {
+	viewer, ok := GetAuthenticatedUser(c)
+	if !ok {
+		return
+	}
+
 	jobIDParam := c.Param("job_id")
 	jobID, err := uuid.Parse(jobIDParam)
 	if err != nil {
@@ -76,16 +95,44 @@ func GetJobDetailsHandler(c *gin.Context) {
 		return
 	}
 
+	// Only candidates count towards a listing's views
+	if viewer.Role == CANDIDATE {
+		tracking.Default.Detail(viewer.ID, job.ID)
+	}
+
 	c.JSON(http.StatusOK, gin.H{"job": job})
 }
 
 func GetCompanyApplicants(c *gin.Context) {
 
-	companyID, _, ok := GetAuthenticatedID(c)
+	companyID, _, _, ok := GetCompanyMember(c)
 	if !ok {
 		return
 	}
-	applications, err := database.GetApplicantPoolsByCompanyID(companyID)
+
+	filters := models.ApplicantFilters{
+		Status: c.Query("status"),
+	}
+	if tags := c.Query("tags"); tags != "" {
+		filters.Tags = database.NormalizeTags(strings.Split(tags, ","))
+	}
+	if minRating := c.Query("min_rating"); minRating != "" {
+		rating, err := strconv.ParseFloat(minRating, 64)
+		if err != nil || rating < 1 || rating > 5 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "min_rating must be between 1 and 5"})
+			return
+		}
+		filters.MinRating = rating
+	}
+	switch sort := c.Query("sort"); sort {
+	case "", "applied_at", "score":
+		filters.Sort = sort
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "sort must be score or applied_at"})
+		return
+	}
+
+	applications, err := database.GetApplicantPoolsByCompanyID(companyID, filters)
 	if err != nil {
 		log.Printf("Error %v", err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to process request"})
@@ -101,7 +148,7 @@ type deleteListingRequest struct {
 
 func DeleteCompanyListing(c *gin.Context) {
 
-	companyID, _, ok := GetAuthenticatedID(c)
+	companyID, _, _, ok := GetCompanyMember(c, teamManagerRoles...)
 	if !ok {
 		return
 	}
@@ -119,7 +166,7 @@ func DeleteCompanyListing(c *gin.Context) {
 	}
 
 	// Call your delete logic
-	err = database.DeleteJobListingByID(listingID, companyID)
+	listing, err := database.DeleteJobListingByID(listingID, companyID)
 	if err != nil {
 		if err.Error() == "unauthorized: this company does not own the job listing" {
 			c.JSON(http.StatusForbidden, gin.H{"error": "You are not authorized to delete this listing"})
@@ -133,5 +180,83 @@ func DeleteCompanyListing(c *gin.Context) {
 		return
 	}
 
+	audit.Record(c, models.AuditListingDeleted, audit.Target{Type: "listing", ID: listingID.String()}, listing, nil, nil)
+
 	c.JSON(http.StatusOK, gin.H{"message": "Listing deleted successfully"})
 }
+
+func CloseCompanyListing(c *gin.Context) {
+	companyID, _, _, ok := GetCompanyMember(c, recruiterRoles...)
+	if !ok {
+		return
+	}
+
+	var req deleteListingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Missing or invalid listing_id"})
+		return
+	}
+
+	listingID, err := uuid.Parse(req.ListingID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid listing_id format"})
+		return
+	}
+
+	listing, err := database.CloseJobListingByID(listingID, companyID)
+	if err != nil {
+		if err.Error() == "job listing not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Open listing not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Could not close listing"})
+		return
+	}
+
+	audit.Record(c, models.AuditListingClosed, audit.Target{Type: "listing", ID: listingID.String()},
+		map[string]any{"closed_at": nil}, map[string]any{"closed_at": listing.ClosedAt}, nil)
+
+	c.JSON(http.StatusOK, gin.H{"message": "Listing closed successfully", "listing": listing})
+}
+
+func UpdateApplicationStatus(c *gin.Context) {
+	companyID, userContext, _, ok := GetCompanyMember(c, recruiterRoles...)
+	if !ok {
+		return
+	}
+
+	var req models.ApplicationStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Missing or invalid application_id or status"})
+		return
+	}
+
+	applicationID, err := uuid.Parse(req.ApplicationID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid application_id format"})
+		return
+	}
+
+	oldStatus, err := database.UpdateApplicationStatus(applicationID, companyID, userContext.ID, req.Status)
+	if err != nil {
+		if err.Error() == "application not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Application not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Could not update application status"})
+		return
+	}
+
+	audit.Record(c, models.AuditApplicationStatus, audit.Target{Type: "application", ID: applicationID.String()},
+		map[string]any{"status": oldStatus}, map[string]any{"status": req.Status}, nil)
+
+	if oldStatus != req.Status {
+		go notify.ApplicationStatusChanged(applicationID, oldStatus, req.Status)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":    "Application status updated",
+		"old_status": oldStatus,
+		"status":     req.Status,
+	})
+}
@@ -0,0 +1,61 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hridaya14/Web-Tech-Project/pkg/bucket"
+)
+
+// ServeStoredFile serves objects of the local disk storage, which has no server of its own.
+// Every object is private, so only presigned URLs with a valid expiry and signature are
+// served. Files are always sent as downloads, so uploaded HTML or SVG never renders on the
+// API origin.
+func ServeStoredFile(c *gin.Context) {
+	local, ok := services.Storage.(*bucket.Local)
+	if !ok {
+		c.JSON(http.StatusNotFound, gin.H{"error": "File not found"})
+		return
+	}
+
+	key := strings.TrimPrefix(c.Param("key"), "/")
+	signature := c.Query("signature")
+	if signature == "" {
+		c.JSON(http.StatusNotFound, gin.H{"error": "File not found"})
+		return
+	}
+	if !local.Verify(key, c.Query("expires"), signature) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Link is invalid or has expired"})
+		return
+	}
+
+	body, object, err := local.Get(c.Request.Context(), key)
+	if errors.Is(err, bucket.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "File not found"})
+		return
+	} else if err != nil {
+		log.Printf("Error reading stored file: %v", err)
+		c.JSON(http.StatusNotFound, gin.H{"error": "File not found"})
+		return
+	}
+	defer body.Close()
+
+	contentType := object.ContentType
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	c.Header("Content-Type", contentType)
+	c.Header("Content-Length", strconv.FormatInt(object.Size, 10))
+	c.Header("X-Content-Type-Options", "nosniff")
+	c.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": path.Base(key)}))
+	c.Header("Content-Security-Policy", "sandbox")
+	c.Status(http.StatusOK)
+	io.Copy(c.Writer, body)
+}
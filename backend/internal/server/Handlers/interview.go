@@ -0,0 +1,363 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/hridaya14/Web-Tech-Project/internal/database"
+	"github.com/hridaya14/Web-Tech-Project/internal/models"
+	"github.com/hridaya14/Web-Tech-Project/internal/notify"
+	"github.com/hridaya14/Web-Tech-Project/pkg/auth"
+	"github.com/hridaya14/Web-Tech-Project/pkg/ics"
+)
+
+func CreateInterview(c *gin.Context) {
+	companyID, userContext, _, ok := GetCompanyMember(c, recruiterRoles...)
+	if !ok {
+		return
+	}
+
+	var input models.InterviewRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	applicationID, err := uuid.Parse(input.ApplicationID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid application_id format"})
+		return
+	}
+
+	interviewerIDs := make([]uuid.UUID, 0, len(input.InterviewerIDs))
+	for _, raw := range input.InterviewerIDs {
+		id, err := uuid.Parse(raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid interviewer id format"})
+			return
+		}
+		// Listing someone twice would fail the company membership count
+		if !slices.Contains(interviewerIDs, id) {
+			interviewerIDs = append(interviewerIDs, id)
+		}
+	}
+
+	now := time.Now()
+	for _, start := range input.Slots {
+		if start.Before(now) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Proposed slots must be in the future"})
+			return
+		}
+	}
+
+	createdBy := userContext.ID
+	interview, conflicts, err := database.CreateInterview(models.Interview{
+		ApplicationID:   applicationID,
+		CompanyID:       companyID,
+		Title:           input.Title,
+		Location:        input.Location,
+		VideoLink:       input.VideoLink,
+		DurationMinutes: input.DurationMinutes,
+		CreatedBy:       &createdBy,
+	}, interviewerIDs, input.Slots)
+	if err != nil {
+		switch err.Error() {
+		case "application not found":
+			c.JSON(http.StatusNotFound, gin.H{"error": "Application not found"})
+		case "application is not in interview stage":
+			c.JSON(http.StatusConflict, gin.H{"error": "Move the application to the interview stage first"})
+		case "interviewer is not a company member":
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Every interviewer must be a member of your company"})
+		case "interviewer conflict":
+			c.JSON(http.StatusConflict, gin.H{"error": "Some proposed slots clash with scheduled interviews", "conflicts": conflicts})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Could not create interview"})
+		}
+		return
+	}
+
+	go notify.InterviewProposed(interview)
+
+	c.JSON(http.StatusCreated, gin.H{"message": "Interview proposed successfully", "interview": interview})
+}
+
+func GetCompanyInterviews(c *gin.Context) {
+	companyID, _, _, ok := GetCompanyMember(c)
+	if !ok {
+		return
+	}
+
+	interviews, err := database.GetInterviewsByCompanyID(companyID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to fetch interviews"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"interviews": interviews})
+}
+
+func CancelInterview(c *gin.Context) {
+	companyID, _, _, ok := GetCompanyMember(c, recruiterRoles...)
+	if !ok {
+		return
+	}
+
+	interviewID, err := uuid.Parse(c.Param("interview_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid interview_id format"})
+		return
+	}
+
+	interview, err := database.CancelInterview(interviewID, companyID)
+	if err != nil {
+		if err.Error() == "interview not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Interview not found or already finished"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Could not cancel interview"})
+		return
+	}
+
+	go notify.InterviewCancelled(interview)
+
+	c.JSON(http.StatusOK, gin.H{"message": "Interview cancelled", "interview": interview})
+}
+
+func GetCandidateInterviews(c *gin.Context) {
+	candidateID, _, ok := GetAuthenticatedID(c)
+	if !ok {
+		return
+	}
+
+	interviews, err := database.GetInterviewsByCandidateID(candidateID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to fetch interviews"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"interviews": interviews})
+}
+
+func SelectInterviewSlot(c *gin.Context) {
+	candidateID, _, ok := GetAuthenticatedID(c)
+	if !ok {
+		return
+	}
+
+	interviewID, err := uuid.Parse(c.Param("interview_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid interview_id format"})
+		return
+	}
+
+	var input models.SelectSlotRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Missing or invalid slot_id"})
+		return
+	}
+
+	slotID, err := uuid.Parse(input.SlotID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid slot_id format"})
+		return
+	}
+
+	interview, _, err := database.SelectInterviewSlot(interviewID, candidateID, slotID)
+	if err != nil {
+		switch err.Error() {
+		case "interview not found", "slot not found":
+			c.JSON(http.StatusNotFound, gin.H{"error": "Interview slot not found"})
+		case "interview is not awaiting a slot":
+			c.JSON(http.StatusConflict, gin.H{"error": "This interview is no longer open for scheduling"})
+		case "slot is in the past":
+			c.JSON(http.StatusConflict, gin.H{"error": "This slot has already passed"})
+		case "interviewer conflict":
+			// Do not leak other interviews to the candidate
+			c.JSON(http.StatusConflict, gin.H{"error": "This slot is no longer available, please pick another"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Could not schedule interview"})
+		}
+		return
+	}
+
+	go notify.InterviewScheduled(interview)
+
+	c.JSON(http.StatusOK, gin.H{"message": "Interview scheduled", "interview": interview})
+}
+
+// DownloadInterviewInvite returns the .ics invite for an interview to the candidate or the company.
+func DownloadInterviewInvite(c *gin.Context) {
+	relatedID, userContext, ok := GetAuthenticatedID(c)
+	if !ok {
+		return
+	}
+
+	interviewID, err := uuid.Parse(c.Param("interview_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid interview_id format"})
+		return
+	}
+
+	interview, err := database.GetInterviewByID(interviewID)
+	if err != nil {
+		if err.Error() == "interview not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Interview not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to process request"})
+		return
+	}
+
+	parties, err := database.GetInterviewParties(interviewID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to process request"})
+		return
+	}
+
+	allowed := (userContext.Role == CANDIDATE && parties.CandidateID == relatedID) ||
+		(userContext.Role == COMPANY && interview.CompanyID == relatedID)
+	if !allowed {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Interview not found"})
+		return
+	}
+
+	if interview.StartsAt == nil {
+		c.JSON(http.StatusConflict, gin.H{"error": "Interview has not been scheduled yet"})
+		return
+	}
+
+	method := ics.MethodRequest
+	if interview.Status == models.InterviewCancelled {
+		method = ics.MethodCancel
+	}
+
+	cal := ics.Calendar{
+		Method: method,
+		Events: []ics.Event{InterviewEvent(interview, parties)},
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="interview-%s.ics"`, interview.ID))
+	c.Data(http.StatusOK, ics.ContentType, cal.Bytes())
+}
+
+// CreateCalendarFeedToken issues a new secret feed URL; any earlier URL stops working.
+func CreateCalendarFeedToken(c *gin.Context) {
+	userContext, ok := GetAuthenticatedUser(c)
+	if !ok {
+		return
+	}
+
+	token, err := auth.GenerateRandomToken(32)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to process request"})
+		return
+	}
+
+	if err := database.SetCalendarFeedToken(userContext.ID, auth.HashToken(token)); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Could not create calendar feed"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message":  "Calendar feed created",
+		"feed_url": "/interviews/feed.ics?token=" + token,
+	})
+}
+
+// GetInterviewFeed serves a subscribable calendar of the token owner's scheduled interviews.
+// Calendar clients cannot send the auth cookie, so the secret token authenticates instead.
+func GetInterviewFeed(c *gin.Context) {
+	token := c.Query("token")
+	if token == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Missing feed token"})
+		return
+	}
+
+	userID, err := database.GetUserIDByCalendarFeedToken(auth.HashToken(token))
+	if err != nil {
+		if err.Error() == "calendar feed not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Calendar feed not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to process request"})
+		return
+	}
+
+	role, err := database.GetUserRole(userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to process request"})
+		return
+	}
+
+	var interviews []models.Interview
+	switch role {
+	case CANDIDATE:
+		rawID, err := database.GetUserRelatedID(userID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to process request"})
+			return
+		}
+		candidateID, _ := rawID.(uuid.UUID)
+		interviews, err = database.GetInterviewsByCandidateID(candidateID)
+	case COMPANY:
+		interviews, err = database.GetInterviewsByInterviewer(userID)
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to fetch interviews"})
+		return
+	}
+
+	cal := ics.Calendar{Method: ics.MethodPublish, Name: "Interviews"}
+	for _, interview := range interviews {
+		if interview.StartsAt == nil {
+			continue
+		}
+		parties, err := database.GetInterviewParties(interview.ID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to process request"})
+			return
+		}
+		cal.Events = append(cal.Events, InterviewEvent(interview, parties))
+	}
+
+	c.Data(http.StatusOK, ics.ContentType, cal.Bytes())
+}
+
+// InterviewEvent converts a scheduled interview into a calendar event.
+func InterviewEvent(interview models.Interview, parties models.InterviewParties) ics.Event {
+	description := []string{fmt.Sprintf("Interview for %s at %s", parties.JobTitle, parties.CompanyName)}
+	if interview.VideoLink != "" {
+		description = append(description, "Join: "+interview.VideoLink)
+	}
+
+	event := ics.Event{
+		UID:         interview.ID.String() + "@jobhunt-ai",
+		Sequence:    interview.Sequence,
+		Start:       *interview.StartsAt,
+		End:         *interview.EndsAt,
+		Summary:     fmt.Sprintf("%s: %s with %s", interview.Title, parties.CandidateName, parties.CompanyName),
+		Description: strings.Join(description, "\n"),
+		Location:    interview.Location,
+		URL:         interview.VideoLink,
+		Cancelled:   interview.Status == models.InterviewCancelled,
+		Updated:     interview.UpdatedAt,
+		Attendees:   []ics.Attendee{{Name: parties.CandidateName, Email: parties.CandidateEmail}},
+	}
+	if event.Location == "" {
+		event.Location = interview.VideoLink
+	}
+
+	for i, p := range interview.Interviewers {
+		if i == 0 {
+			event.Organizer = &ics.Attendee{Name: p.Name, Email: p.Email}
+		}
+		event.Attendees = append(event.Attendees, ics.Attendee{Name: p.Name, Email: p.Email})
+	}
+
+	return event
+}
@@ -0,0 +1,251 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/hridaya14/Web-Tech-Project/internal/database"
+	"github.com/hridaya14/Web-Tech-Project/internal/models"
+	"github.com/hridaya14/Web-Tech-Project/pkg/auth"
+)
+
+const invitationTTL = 7 * 24 * time.Hour
+
+// Roles allowed to manage the company team and to work on applicants.
+var (
+	teamManagerRoles = []string{models.MemberRoleOwner, models.MemberRoleAdmin}
+	recruiterRoles   = []string{models.MemberRoleOwner, models.MemberRoleAdmin, models.MemberRoleRecruiter}
+)
+
+func GetCompanyMembers(c *gin.Context) {
+	companyID, _, _, ok := GetCompanyMember(c)
+	if !ok {
+		return
+	}
+
+	members, err := database.GetCompanyMembers(companyID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to fetch members"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"members": members})
+}
+
+func InviteCompanyMember(c *gin.Context) {
+	companyID, userContext, role, ok := GetCompanyMember(c, teamManagerRoles...)
+	if !ok {
+		return
+	}
+
+	var input models.InviteMemberRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Only the owner can hand out admin seats
+	if input.Role == models.MemberRoleAdmin && role != models.MemberRoleOwner {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Only the company owner can invite admins"})
+		return
+	}
+
+	token, err := auth.GenerateRandomToken(32)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to process request"})
+		return
+	}
+
+	invitation, err := database.CreateCompanyInvitation(companyID, userContext.ID, input.Email, input.Role, token, time.Now().Add(invitationTTL))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Could not create invitation"})
+		return
+	}
+
+	log.Printf("Created company invitation %s for %s", invitation.ID, invitation.Email)
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message":    "Invitation sent successfully",
+		"invitation": invitation,
+	})
+}
+
+func GetCompanyInvitations(c *gin.Context) {
+	companyID, _, _, ok := GetCompanyMember(c, teamManagerRoles...)
+	if !ok {
+		return
+	}
+
+	invitations, err := database.GetPendingCompanyInvitations(companyID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to fetch invitations"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"invitations": invitations})
+}
+
+func RevokeCompanyInvitation(c *gin.Context) {
+	companyID, _, _, ok := GetCompanyMember(c, teamManagerRoles...)
+	if !ok {
+		return
+	}
+
+	invitationID, err := uuid.Parse(c.Param("invitation_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid invitation_id format"})
+		return
+	}
+
+	err = database.RevokeCompanyInvitation(invitationID, companyID)
+	if err != nil {
+		if err.Error() == "invitation not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Invitation not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Could not revoke invitation"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Invitation revoked successfully"})
+}
+
+// AcceptCompanyInvitation joins the logged in company user to the inviting company.
+// It replaces company onboarding for invited users.
+func AcceptCompanyInvitation(c *gin.Context) {
+	userContext, ok := GetAuthenticatedUser(c)
+	if !ok {
+		return
+	}
+
+	if userContext.Role != COMPANY {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Only company accounts can join a company"})
+		return
+	}
+
+	var input models.AcceptInvitationRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	user, err := database.GetUserByUsername(userContext.Username)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to process request"})
+		return
+	}
+
+	invitation, err := database.AcceptCompanyInvitation(auth.HashToken(input.Token), userContext.ID, user.Email)
+	if err != nil {
+		switch err.Error() {
+		case "invitation not found":
+			c.JSON(http.StatusNotFound, gin.H{"error": "Invitation not found or expired"})
+		case "invitation email mismatch":
+			c.JSON(http.StatusForbidden, gin.H{"error": "This invitation was sent to a different email"})
+		case "user already belongs to a company":
+			c.JSON(http.StatusConflict, gin.H{"error": "You already belong to a company"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Could not accept invitation"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":    "Joined company successfully",
+		"company_id": invitation.CompanyID,
+		"role":       invitation.Role,
+	})
+}
+
+func UpdateCompanyMemberRole(c *gin.Context) {
+	companyID, userContext, role, ok := GetCompanyMember(c, teamManagerRoles...)
+	if !ok {
+		return
+	}
+
+	var input models.UpdateMemberRoleRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	memberID, err := uuid.Parse(input.UserID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user_id format"})
+		return
+	}
+
+	if memberID == userContext.ID {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "You cannot change your own role"})
+		return
+	}
+
+	if role != models.MemberRoleOwner {
+		memberRole, err := database.GetCompanyMemberRole(companyID, memberID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to process request"})
+			return
+		}
+		if memberRole == models.MemberRoleAdmin || input.Role == models.MemberRoleAdmin {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Only the company owner can manage admins"})
+			return
+		}
+	}
+
+	err = database.UpdateCompanyMemberRole(companyID, memberID, input.Role)
+	if err != nil {
+		if err.Error() == "member not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Member not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Could not update member role"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Member role updated successfully"})
+}
+
+func RemoveCompanyMember(c *gin.Context) {
+	companyID, userContext, role, ok := GetCompanyMember(c, teamManagerRoles...)
+	if !ok {
+		return
+	}
+
+	memberID, err := uuid.Parse(c.Param("user_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user_id format"})
+		return
+	}
+
+	if memberID == userContext.ID {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "You cannot remove yourself"})
+		return
+	}
+
+	if role != models.MemberRoleOwner {
+		memberRole, err := database.GetCompanyMemberRole(companyID, memberID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to process request"})
+			return
+		}
+		if memberRole == models.MemberRoleAdmin {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Only the company owner can remove admins"})
+			return
+		}
+	}
+
+	err = database.RemoveCompanyMember(companyID, memberID)
+	if err != nil {
+		if err.Error() == "member not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Member not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Could not remove member"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Member removed successfully"})
+}
@@ -0,0 +1,356 @@
+package handlers
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/hridaya14/Web-Tech-Project/internal/database"
+	"github.com/hridaya14/Web-Tech-Project/internal/models"
+	"github.com/hridaya14/Web-Tech-Project/internal/notify"
+	"github.com/hridaya14/Web-Tech-Project/pkg/document"
+)
+
+const (
+	maxMessageAttachments = 5
+	maxMessageFormSize    = 20 << 20
+	attachmentLinkTTL     = 15 * time.Minute
+)
+
+// Sniffed attachment types that are stored as themselves; anything else is kept as opaque bytes
+var attachmentExtensions = map[string]string{
+	"application/pdf":           ".pdf",
+	"application/zip":           ".zip",
+	"image/gif":                 ".gif",
+	"image/jpeg":                ".jpg",
+	"image/png":                 ".png",
+	"image/webp":                ".webp",
+	"text/plain; charset=utf-8": ".txt",
+}
+
+// messageViewer is the caller taking part in a conversation
+type messageViewer struct {
+	user      *models.AuthenticatedUser
+	relatedID uuid.UUID // candidate ID or company ID
+	canSend   bool
+}
+
+func getMessageViewer(c *gin.Context) (messageViewer, bool) {
+	relatedID, userContext, ok := GetAuthenticatedID(c)
+	if !ok {
+		return messageViewer{}, false
+	}
+
+	viewer := messageViewer{user: userContext, relatedID: relatedID, canSend: true}
+	if userContext.Role == COMPANY {
+		role, err := database.GetCompanyMemberRole(relatedID, userContext.ID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to process request"})
+			return messageViewer{}, false
+		}
+		viewer.canSend = role != models.MemberRoleViewer
+	} else if userContext.Role != CANDIDATE {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Messaging is only available to candidates and companies"})
+		return messageViewer{}, false
+	}
+
+	return viewer, true
+}
+
+// canAccess reports whether the viewer is the applicant or a member of the hiring company.
+func (v messageViewer) canAccess(parties models.ApplicationParties) bool {
+	if v.user.Role == CANDIDATE {
+		return parties.CandidateID == v.relatedID
+	}
+	return parties.CompanyID == v.relatedID
+}
+
+// getAccessibleThread loads the :thread_id thread, answering 404 when the viewer may not read it.
+func getAccessibleThread(c *gin.Context, viewer messageViewer) (models.MessageThread, bool) {
+	threadID, err := uuid.Parse(c.Param("thread_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid thread_id format"})
+		return models.MessageThread{}, false
+	}
+
+	thread, err := database.GetMessageThreadByID(threadID, viewer.user.ID, viewer.user.Role)
+	if err != nil {
+		if err.Error() == "thread not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Thread not found"})
+			return models.MessageThread{}, false
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to process request"})
+		return models.MessageThread{}, false
+	}
+
+	parties, err := database.GetApplicationParties(thread.ApplicationID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to process request"})
+		return models.MessageThread{}, false
+	}
+	if !viewer.canAccess(parties) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Thread not found"})
+		return models.MessageThread{}, false
+	}
+
+	return thread, true
+}
+
+// uploadMessageAttachments screens and stores the "attachments" files of the multipart form.
+func uploadMessageAttachments(c *gin.Context, userID uuid.UUID) ([]models.MessageAttachment, error) {
+	if c.Request.MultipartForm == nil || len(c.Request.MultipartForm.File["attachments"]) == 0 {
+		return nil, nil
+	}
+
+	headers := c.Request.MultipartForm.File["attachments"]
+	if len(headers) > maxMessageAttachments {
+		return nil, &uploadError{http.StatusBadRequest, fmt.Sprintf("at most %d attachments are allowed", maxMessageAttachments)}
+	}
+
+	attachments := make([]models.MessageAttachment, 0, len(headers))
+	for _, header := range headers {
+		data, err := screenUpload(c.Request.Context(), header, userID, "message_attachment")
+		if err != nil {
+			return nil, err
+		}
+
+		ext, contentType := attachmentFormat(data)
+		key, _, err := storeUpload(c.Request.Context(), data, "messages", ext, contentType)
+		if err != nil {
+			return nil, &uploadError{http.StatusBadGateway, "could not upload attachment " + header.Filename}
+		}
+
+		attachments = append(attachments, models.MessageAttachment{
+			FileName:    header.Filename,
+			FileKey:     key,
+			ContentType: contentType,
+			SizeBytes:   int64(len(data)),
+		})
+	}
+	return attachments, nil
+}
+
+// attachmentFormat picks the extension and content type of an attachment from its content,
+// never from the name the client sent.
+func attachmentFormat(data []byte) (string, string) {
+	if info, err := document.Validate(data, services.UploadLimits); err == nil {
+		return info.Extension(), info.ContentType()
+	}
+	contentType := http.DetectContentType(data)
+	if ext, ok := attachmentExtensions[contentType]; ok {
+		return ext, contentType
+	}
+	return ".bin", "application/octet-stream"
+}
+
+// presignAttachments gives every attachment a short-lived download link. Callers must have
+// checked that the viewer takes part in the thread.
+func presignAttachments(c *gin.Context, messages ...*models.Message) bool {
+	for _, message := range messages {
+		for i := range message.Attachments {
+			attachment := &message.Attachments[i]
+			url, err := services.Storage.Presign(c.Request.Context(), attachment.FileKey, attachmentLinkTTL)
+			if err != nil {
+				log.Printf("Error presigning message attachment %s: %v", attachment.ID, err)
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to process request"})
+				return false
+			}
+			attachment.DownloadURL = url
+		}
+	}
+	return true
+}
+
+func GetMessageThreads(c *gin.Context) {
+	viewer, ok := getMessageViewer(c)
+	if !ok {
+		return
+	}
+
+	applicationID := uuid.Nil
+	if raw := c.Query("application_id"); raw != "" {
+		id, err := uuid.Parse(raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid application_id format"})
+			return
+		}
+		applicationID = id
+	}
+
+	var threads []models.MessageThread
+	var err error
+	if viewer.user.Role == CANDIDATE {
+		threads, err = database.GetMessageThreadsByCandidateID(viewer.relatedID, viewer.user.ID, applicationID)
+	} else {
+		threads, err = database.GetMessageThreadsByCompanyID(viewer.relatedID, viewer.user.ID, applicationID)
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to fetch threads"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"threads": threads})
+}
+
+func CreateMessageThread(c *gin.Context) {
+	viewer, ok := getMessageViewer(c)
+	if !ok {
+		return
+	}
+	if !viewer.canSend {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Your company role does not allow sending messages"})
+		return
+	}
+
+	limitRequestBody(c, maxMessageAttachments)
+	if err := c.Request.ParseMultipartForm(maxMessageFormSize); err != nil && err != http.ErrNotMultipart {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to parse form"})
+		return
+	}
+
+	applicationID, err := uuid.Parse(c.PostForm("application_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Missing or invalid application_id"})
+		return
+	}
+
+	subject := strings.TrimSpace(c.PostForm("subject"))
+	body := strings.TrimSpace(c.PostForm("body"))
+	if subject == "" || body == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "subject and body are required"})
+		return
+	}
+
+	parties, err := database.GetApplicationParties(applicationID)
+	if err != nil {
+		if err.Error() == "application not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Application not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to process request"})
+		return
+	}
+	if !viewer.canAccess(parties) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Application not found"})
+		return
+	}
+
+	attachments, err := uploadMessageAttachments(c, viewer.user.ID)
+	if err != nil {
+		replyUploadError(c, err)
+		return
+	}
+
+	thread, message, err := database.CreateMessageThread(applicationID, subject, viewer.user.ID, viewer.user.Role, body, attachments)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Could not start conversation"})
+		return
+	}
+	message.SenderName = viewer.user.Username
+
+	go notify.MessageReceived(thread, message)
+
+	if !presignAttachments(c, &message) {
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{"thread": thread, "message": message})
+}
+
+func GetMessageThread(c *gin.Context) {
+	viewer, ok := getMessageViewer(c)
+	if !ok {
+		return
+	}
+
+	thread, ok := getAccessibleThread(c, viewer)
+	if !ok {
+		return
+	}
+
+	messages, err := database.GetThreadMessages(thread.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to fetch messages"})
+		return
+	}
+
+	if err := database.MarkThreadRead(thread.ID, viewer.user.ID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to process request"})
+		return
+	}
+	thread.UnreadCount = 0
+
+	for i := range messages {
+		if !presignAttachments(c, &messages[i]) {
+			return
+		}
+	}
+	c.JSON(http.StatusOK, gin.H{"thread": thread, "messages": messages})
+}
+
+func ReplyToMessageThread(c *gin.Context) {
+	viewer, ok := getMessageViewer(c)
+	if !ok {
+		return
+	}
+	if !viewer.canSend {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Your company role does not allow sending messages"})
+		return
+	}
+
+	// Strangers to the thread are turned away before their upload is read
+	thread, ok := getAccessibleThread(c, viewer)
+	if !ok {
+		return
+	}
+
+	limitRequestBody(c, maxMessageAttachments)
+	if err := c.Request.ParseMultipartForm(maxMessageFormSize); err != nil && err != http.ErrNotMultipart {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to parse form"})
+		return
+	}
+
+	body := strings.TrimSpace(c.PostForm("body"))
+	if body == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "body is required"})
+		return
+	}
+
+	attachments, err := uploadMessageAttachments(c, viewer.user.ID)
+	if err != nil {
+		replyUploadError(c, err)
+		return
+	}
+
+	message, err := database.CreateMessage(thread.ID, viewer.user.ID, viewer.user.Role, body, attachments)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Could not send message"})
+		return
+	}
+	message.SenderName = viewer.user.Username
+
+	go notify.MessageReceived(thread, message)
+
+	if !presignAttachments(c, &message) {
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{"message": message})
+}
+
+func GetUnreadMessageCount(c *gin.Context) {
+	viewer, ok := getMessageViewer(c)
+	if !ok {
+		return
+	}
+
+	count, err := database.GetUnreadMessageCount(viewer.user.ID, viewer.user.Role, viewer.relatedID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to process request"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"unread": count})
+}
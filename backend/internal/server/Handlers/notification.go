@@ -0,0 +1,253 @@
+package handlers
+
+import (
+	"bytes"
+	"html/template"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/hridaya14/Web-Tech-Project/internal/database"
+	"github.com/hridaya14/Web-Tech-Project/internal/email"
+	"github.com/hridaya14/Web-Tech-Project/internal/models"
+	"github.com/hridaya14/Web-Tech-Project/internal/notify"
+)
+
+const streamHeartbeat = 25 * time.Second
+
+func GetNotifications(c *gin.Context) {
+	userContext, ok := GetAuthenticatedUser(c)
+	if !ok {
+		return
+	}
+
+	limit := 50
+	if raw := c.Query("limit"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n < 1 || n > 200 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "limit must be between 1 and 200"})
+			return
+		}
+		limit = n
+	}
+
+	notifications, err := database.GetNotifications(userContext.ID, c.Query("unread") == "true", limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to fetch notifications"})
+		return
+	}
+
+	unread, err := database.CountUnreadNotifications(userContext.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to fetch notifications"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"notifications": notifications, "unread": unread})
+}
+
+func MarkNotificationRead(c *gin.Context) {
+	userContext, ok := GetAuthenticatedUser(c)
+	if !ok {
+		return
+	}
+
+	notificationID, err := uuid.Parse(c.Param("notification_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid notification_id format"})
+		return
+	}
+
+	err = database.MarkNotificationRead(notificationID, userContext.ID)
+	if err != nil {
+		if err.Error() == "notification not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Notification not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Could not update notification"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Notification marked as read"})
+}
+
+func MarkAllNotificationsRead(c *gin.Context) {
+	userContext, ok := GetAuthenticatedUser(c)
+	if !ok {
+		return
+	}
+
+	if err := database.MarkAllNotificationsRead(userContext.ID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Could not update notifications"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "All notifications marked as read"})
+}
+
+// GetNotificationPreferences returns the in-app setting for every notification type.
+func GetNotificationPreferences(c *gin.Context) {
+	userContext, ok := GetAuthenticatedUser(c)
+	if !ok {
+		return
+	}
+
+	stored, err := database.GetNotificationPreferences(userContext.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to fetch preferences"})
+		return
+	}
+
+	overrides := make(map[string]models.NotificationPreference, len(stored))
+	for _, p := range stored {
+		overrides[p.Type] = p
+	}
+
+	prefs := make([]models.NotificationPreference, 0, len(models.NotificationTypes))
+	for _, t := range models.NotificationTypes {
+		pref, ok := overrides[t]
+		if !ok {
+			pref = models.NotificationPreference{Type: t, InApp: true, Email: true}
+		}
+		prefs = append(prefs, pref)
+	}
+
+	c.JSON(http.StatusOK, gin.H{"preferences": prefs})
+}
+
+func UpdateNotificationPreference(c *gin.Context) {
+	userContext, ok := GetAuthenticatedUser(c)
+	if !ok {
+		return
+	}
+
+	var input models.NotificationPreferenceRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if !slices.Contains(models.NotificationTypes, input.Type) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Unknown notification type"})
+		return
+	}
+
+	if input.InApp == nil && input.Email == nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Set in_app or email"})
+		return
+	}
+
+	if err := database.SetNotificationPreference(userContext.ID, input.Type, input.InApp, input.Email); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Could not save preference"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Preference updated"})
+}
+
+// StreamNotifications pushes new notifications to the browser as Server-Sent Events.
+// The connection is authenticated by the same token cookie as every other route.
+func StreamNotifications(c *gin.Context) {
+	userContext, ok := GetAuthenticatedUser(c)
+	if !ok {
+		return
+	}
+
+	unread, err := database.CountUnreadNotifications(userContext.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to process request"})
+		return
+	}
+
+	events, cancel := notify.DefaultHub.Subscribe(userContext.ID)
+	defer cancel()
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no") // keep proxies from buffering the stream
+
+	c.SSEvent("unread", gin.H{"unread": unread})
+	c.Writer.Flush()
+
+	heartbeat := time.NewTicker(streamHeartbeat)
+	defer heartbeat.Stop()
+
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case n, ok := <-events:
+			if !ok {
+				return false
+			}
+			c.SSEvent("notification", n)
+			return true
+		case <-heartbeat.C:
+			c.SSEvent("ping", time.Now().Unix())
+			return true
+		case <-c.Request.Context().Done():
+			return false
+		}
+	})
+}
+
+// unsubscribeConfirmation asks before unsubscribing, so link scanners and prefetchers that
+// follow the GET link change nothing
+var unsubscribeConfirmation = template.Must(template.New("unsubscribe").Parse(`<!DOCTYPE html>
+<html>
+<head><meta charset="utf-8"><title>Unsubscribe</title></head>
+<body>
+<form method="post" action="{{.}}">
+<p>Stop receiving these emails?</p>
+<button type="submit">Unsubscribe</button>
+</form>
+</body>
+</html>
+`))
+
+// verifyUnsubscribeLink reads the signed user and notification type of an unsubscribe link,
+// replying 400 when the signature does not match.
+func verifyUnsubscribeLink(c *gin.Context) (uuid.UUID, string, bool) {
+	userID, err := uuid.Parse(c.Query("user"))
+	notificationType := c.Query("type")
+	if err != nil || !email.VerifyUnsubscribe(userID, notificationType, c.Query("sig")) {
+		c.String(http.StatusBadRequest, "This unsubscribe link is invalid.")
+		return uuid.Nil, "", false
+	}
+	return userID, notificationType, true
+}
+
+// ConfirmUnsubscribe renders the page behind the unsubscribe links in notification emails.
+// It needs no login so it works straight from the mail client.
+func ConfirmUnsubscribe(c *gin.Context) {
+	if _, _, ok := verifyUnsubscribeLink(c); !ok {
+		return
+	}
+
+	var page bytes.Buffer
+	if err := unsubscribeConfirmation.Execute(&page, c.Request.URL.RequestURI()); err != nil {
+		c.String(http.StatusInternalServerError, "We could not load this page, please try again later.")
+		return
+	}
+	c.Data(http.StatusOK, "text/html; charset=utf-8", page.Bytes())
+}
+
+// UnsubscribeEmail turns the email off, from the confirmation page or from a mail client's
+// one-click unsubscribe (List-Unsubscribe-Post).
+func UnsubscribeEmail(c *gin.Context) {
+	userID, notificationType, ok := verifyUnsubscribeLink(c)
+	if !ok {
+		return
+	}
+
+	disabled := false
+	if err := database.SetNotificationPreference(userID, notificationType, nil, &disabled); err != nil {
+		c.String(http.StatusInternalServerError, "We could not update your preferences, please try again later.")
+		return
+	}
+
+	c.String(http.StatusOK, "You have been unsubscribed from these emails.")
+}
@@ -0,0 +1,138 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hridaya14/Web-Tech-Project/internal/database"
+	"github.com/hridaya14/Web-Tech-Project/internal/models"
+	"github.com/hridaya14/Web-Tech-Project/internal/privacy"
+	"github.com/hridaya14/Web-Tech-Project/pkg/auth"
+)
+
+const exportLinkTTL = 15 * time.Minute
+
+// RequestDataExport queues a ZIP archive of everything stored about the caller.
+// The archive is built in the background; poll GetDataExport for the download link.
+func RequestDataExport(c *gin.Context) {
+	userContext, ok := GetAuthenticatedUser(c)
+	if !ok {
+		return
+	}
+
+	export, err := database.CreateDataExport(userContext.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to request data export"})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{"message": "Your data export is being prepared", "export": export})
+}
+
+// GetDataExport reports the caller's latest export, with a short-lived download link once it is ready.
+func GetDataExport(c *gin.Context) {
+	userContext, ok := GetAuthenticatedUser(c)
+	if !ok {
+		return
+	}
+
+	export, err := database.GetLatestDataExport(userContext.ID)
+	if err != nil {
+		switch err.Error() {
+		case "data export not found":
+			c.JSON(http.StatusNotFound, gin.H{"error": "No data export requested"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to fetch data export"})
+		}
+		return
+	}
+
+	if export.Status == models.DataExportReady && export.ObjectKey != nil && export.ExpiresAt != nil && time.Now().Before(*export.ExpiresAt) {
+		url, err := services.Storage.Presign(c.Request.Context(), *export.ObjectKey, exportLinkTTL)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to create download link"})
+			return
+		}
+		export.DownloadURL = url
+	}
+
+	c.JSON(http.StatusOK, gin.H{"export": export})
+}
+
+// RequestAccountDeletion schedules the caller's account for erasure after a grace period,
+// during which it can still be cancelled. The password is asked again to confirm.
+func RequestAccountDeletion(c *gin.Context) {
+	userContext, ok := GetAuthenticatedUser(c)
+	if !ok {
+		return
+	}
+
+	var input models.DeleteAccountRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Password is required to delete your account"})
+		return
+	}
+
+	user, err := database.GetUserByID(userContext.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to process request"})
+		return
+	}
+	if err := auth.CheckPassword(user.PasswordHash, input.Password); err != nil {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Incorrect password"})
+		return
+	}
+
+	deletion, err := database.ScheduleAccountDeletion(userContext.ID, time.Now().Add(privacy.DeletionGracePeriod))
+	if err != nil {
+		switch err.Error() {
+		case "account deletion already scheduled":
+			c.JSON(http.StatusConflict, gin.H{"error": "Account deletion is already scheduled"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to schedule account deletion"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{"message": "Your account will be deleted after the grace period", "deletion": deletion})
+}
+
+func GetAccountDeletion(c *gin.Context) {
+	userContext, ok := GetAuthenticatedUser(c)
+	if !ok {
+		return
+	}
+
+	deletion, err := database.GetAccountDeletion(userContext.ID)
+	if err != nil {
+		switch err.Error() {
+		case "account deletion not found":
+			c.JSON(http.StatusNotFound, gin.H{"error": "No account deletion requested"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to fetch account deletion"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"deletion": deletion})
+}
+
+func CancelAccountDeletion(c *gin.Context) {
+	userContext, ok := GetAuthenticatedUser(c)
+	if !ok {
+		return
+	}
+
+	if err := database.CancelAccountDeletion(userContext.ID); err != nil {
+		switch err.Error() {
+		case "account deletion not found":
+			c.JSON(http.StatusNotFound, gin.H{"error": "No pending account deletion"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to cancel account deletion"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Account deletion cancelled"})
+}
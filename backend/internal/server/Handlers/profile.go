@@ -1,15 +1,19 @@
 package handlers
 
 import (
+	"context"
 	"database/sql"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/hridaya14/Web-Tech-Project/internal/database"
 	"github.com/hridaya14/Web-Tech-Project/internal/models"
-	"github.com/hridaya14/Web-Tech-Project/pkg/bucket"
-	"net/http"
-	"strconv"
-	"strings"
+	"github.com/hridaya14/Web-Tech-Project/pkg/ai"
 )
 
 func GetProfile(c *gin.Context) {
@@ -62,13 +66,18 @@ func GetProfile(c *gin.Context) {
 				c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch candidate profile"})
 				return
 			}
+
+			var resumeURL string
+			if link, err := mintResumeLink(c, candidate, userContext, nil, models.ResumeAccessOwner); err == nil {
+				resumeURL = link.URL
+			}
 			profile = gin.H{
 				"full_name":      candidate.FullName,
 				"location":       candidate.Location,
 				"phone_number":   candidate.PhoneNumber,
 				"linkedin_url":   candidate.LinkedInURL,
 				"portfolio_url":  candidate.PortfolioURL,
-				"resume_url":     candidate.ResumeURL,
+				"resume_url":     resumeURL,
 				"skills":         candidate.Skills,
 				"experience":     candidate.Experience,
 				"expected_roles": candidate.ExpectedRoles,
@@ -118,7 +127,8 @@ func CreateCandidateProfile(c *gin.Context) {
 
 	database.UpdateOnboardingStatus(userContext.ID, "IN_PROGRESS")
 
-	// Parse multipart form (10 MB max)
+	// Parse multipart form, refusing bodies far beyond the upload limit
+	limitRequestBody(c, 1)
 	if err := c.Request.ParseMultipartForm(10 << 20); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"Message": "Failed to parse form", "Error": err.Error()})
 		return
@@ -149,26 +159,26 @@ func CreateCandidateProfile(c *gin.Context) {
 	}
 
 	// Handle resume file upload
-	file, fileHeader, err := c.Request.FormFile("resume_file")
+	fileHeader, err := c.FormFile("resume_file")
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"Message": "Resume file required", "Error": err.Error()})
 		return
 	}
-	defer file.Close()
 
-	// Initialize S3 uploader
-	uploader, err := bucket.NewUploader() // Replace with your S3 bucket
+	// The stored file's type comes from its content, never from the name it was uploaded with
+	data, info, err := screenDocument(c.Request.Context(), fileHeader, userContext.ID, "resume")
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to initialize S3 uploader"})
+		replyUploadError(c, err)
 		return
 	}
 
-	uploadedURL, err := uploader.UploadFile(file, fileHeader, "resumes")
+	// Resumes are private, only the key is kept and links are minted on request
+	resumeKey, _, err := storeUpload(c.Request.Context(), data, "resumes", info.Extension(), info.ContentType())
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"Message": "Failed to upload resume", "Error": err.Error()})
 		return
 	}
-	input.ResumeURL = uploadedURL
+	input.ResumeKey = resumeKey
 
 	// Save candidate to DB
 	candidate, err := database.CreateCandidate(input, userContext.ID)
@@ -177,43 +187,10 @@ func CreateCandidateProfile(c *gin.Context) {
 		return
 	}
 
-	// postPayload := map[string]interface{}{
-	// 	"user_id":      userContext.ID,
-	// 	"resume_url":   uploadedURL,
-	// 	"query_skills": input.Skills,
-	// }
-
-	// // Marshal to JSON
-	// jsonData, err := json.Marshal(postPayload)
-	// if err != nil {
-	// 	c.JSON(http.StatusInternalServerError, gin.H{"Message": "Failed to marshal JSON", "Error": err.Error()})
-	// 	return
-	// }
-	//
-	// // Send POST request to external service
-	// postURL := "http://0.0.0.0:8000/onboarding/candidate"
-	// // Replace with actual endpoint
-	// req, err := http.NewRequest("POST", postURL, bytes.NewBuffer(jsonData))
-	// if err != nil {
-	// 	c.JSON(http.StatusInternalServerError, gin.H{"Message": "Failed to create request", "Error": err.Error()})
-	// 	return
-	// }
-	// req.Header.Set("Content-Type", "application/json")
-	//
-	// client := &http.Client{}
-	// resp, err := client.Do(req)
-	// if err != nil {
-	// 	c.JSON(http.StatusInternalServerError, gin.H{"Message": "Request failed", "Error": err.Error()})
-	// 	return
-	// }
-	// defer resp.Body.Close()
-	//
-	// if resp.StatusCode != http.StatusOK {
-	// 	body, _ := io.ReadAll(resp.Body)
-	// 	c.JSON(http.StatusBadGateway, gin.H{"Message": "External request failed", "Response": string(body)})
-	// 	return
-	// }
-	//
+	// Embedding the resume is slow and must not block onboarding
+	go ingestResume(candidate)
+	go indexResumeText(candidate.ID, data)
+
 	database.UpdateOnboardingStatus(userContext.ID, "COMPLETED")
 
 	c.JSON(http.StatusOK, gin.H{"Message": "Candidate created successfully", "Candidate": candidate})
@@ -232,6 +209,16 @@ func CreateCompanyProfile(c *gin.Context) {
 		return
 	}
 
+	member, err := database.IsCompanyMember(userContext.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"Message": "Unable to create company"})
+		return
+	}
+	if member {
+		c.JSON(http.StatusConflict, gin.H{"Message": "You already belong to a company"})
+		return
+	}
+
 	database.UpdateOnboardingStatus(userContext.ID, "IN_PROGRESS")
 
 	var input models.CompanyRequest
@@ -242,6 +229,10 @@ func CreateCompanyProfile(c *gin.Context) {
 
 	company, err := database.CreateCompany(input, userContext.ID)
 	if err != nil {
+		if err.Error() == "user already belongs to a company" {
+			c.JSON(http.StatusConflict, gin.H{"Message": "You already belong to a company"})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"Message": "Unable to create company"})
 		return
 	}
@@ -253,3 +244,40 @@ func CreateCompanyProfile(c *gin.Context) {
 		"name": company.CompanyName})
 
 }
+
+const resumeIngestTimeout = 2 * time.Minute
+
+// ingestResume hands a new profile to the scoring service so it can be matched against listings.
+func ingestResume(candidate models.Candidate) {
+	ctx, cancel := context.WithTimeout(context.Background(), resumeIngestTimeout)
+	defer cancel()
+
+	var expectedRoles []string
+	if candidate.ExpectedRoles != "" {
+		expectedRoles = []string{candidate.ExpectedRoles}
+	}
+
+	// The scoring service fetches the file itself, within the ingest timeout
+	resumeURL, err := services.Storage.Presign(ctx, candidate.ResumeKey, resumeIngestTimeout)
+	if err != nil {
+		log.Printf("Error presigning resume for candidate %s: %v", candidate.ID, err)
+		return
+	}
+	// As with links handed to people, an unlogged link is never given to the scoring service
+	if err := database.LogResumeAccess(models.ResumeAccess{CandidateID: candidate.ID, Reason: models.ResumeAccessScoring}); err != nil {
+		log.Printf("Error logging resume access for candidate %s: %v", candidate.ID, err)
+		return
+	}
+
+	err = services.AI.IngestResume(ctx, ai.Resume{
+		UserID:          candidate.UserID,
+		CandidateID:     candidate.ID,
+		ResumeURL:       resumeURL,
+		Skills:          candidate.Skills,
+		ExpectedRoles:   expectedRoles,
+		ExperienceYears: candidate.Experience,
+	})
+	if err != nil {
+		log.Printf("Error ingesting resume for candidate %s: %v", candidate.ID, err)
+	}
+}
@@ -0,0 +1,93 @@
+package handlers
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hridaya14/Web-Tech-Project/internal/database"
+	"github.com/hridaya14/Web-Tech-Project/internal/matching"
+	"github.com/hridaya14/Web-Tech-Project/internal/models"
+	"github.com/hridaya14/Web-Tech-Project/internal/scoring"
+)
+
+const (
+	defaultRecommendationLimit = 20
+	maxRecommendationLimit     = 100
+)
+
+// GetJobRecommendations ranks the open listings the candidate has not applied to by how well they suit them.
+func GetJobRecommendations(c *gin.Context) {
+	candidateID, _, ok := getCandidateID(c)
+	if !ok {
+		return
+	}
+
+	limit := defaultRecommendationLimit
+	if raw := c.Query("limit"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed < 1 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid limit"})
+			return
+		}
+		limit = min(parsed, maxRecommendationLimit)
+	}
+
+	candidate, err := database.GetCandidateByID(candidateID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to process request"})
+		return
+	}
+	applied, err := database.GetAppliedListings(candidateID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to process request"})
+		return
+	}
+	listings, err := database.GetUnappliedOpenListings(candidateID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch job listings"})
+		return
+	}
+
+	seeker := matching.Seeker{
+		Profile: matching.Profile{
+			Skills:          candidate.Skills,
+			ExperienceYears: candidate.Experience,
+		},
+		Location: candidate.Location,
+	}
+	if candidate.ExpectedRoles != "" {
+		seeker.ExpectedRoles = []string{candidate.ExpectedRoles}
+	}
+	for _, listing := range applied {
+		seeker.Applied = append(seeker.Applied, scoring.Requirements(listing))
+	}
+
+	recommendations := make([]models.JobRecommendation, 0, len(listings))
+	for _, listing := range listings {
+		r := matching.Recommend(seeker, matching.Opening{
+			Requirements: scoring.Requirements(listing),
+			Location:     listing.Location,
+			WorkType:     listing.Work_type,
+		})
+		recommendations = append(recommendations, models.JobRecommendation{
+			Listing: listing,
+			Score:   r.Score,
+			Reason:  r.Reason,
+		})
+	}
+
+	// Listings come newest first, so equal scores keep the newer listing ahead
+	sort.SliceStable(recommendations, func(i, j int) bool {
+		return recommendations[i].Score > recommendations[j].Score
+	})
+	if len(recommendations) > limit {
+		recommendations = recommendations[:limit]
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"recommendations": recommendations,
+		"count":           len(recommendations),
+	})
+}
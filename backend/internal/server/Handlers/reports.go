@@ -0,0 +1,132 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hridaya14/Web-Tech-Project/internal/database"
+)
+
+// GetReports lists the admin reports and when each was last refreshed.
+func GetReports(c *gin.Context) {
+	if _, ok := getAdmin(c); !ok {
+		return
+	}
+
+	reports, err := database.GetReports()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to fetch reports"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"reports": reports})
+}
+
+// GetReport returns one report as of its last refresh, as JSON or, with format=csv, as a
+// CSV download. Dated reports take from and to (inclusive days).
+func GetReport(c *gin.Context) {
+	if _, ok := getAdmin(c); !ok {
+		return
+	}
+
+	format := c.DefaultQuery("format", "json")
+	if format != "json" && format != "csv" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid format, expected json or csv"})
+		return
+	}
+
+	from, ok := queryTime(c, "from")
+	if !ok {
+		return
+	}
+	to, ok := queryTime(c, "to")
+	if !ok {
+		return
+	}
+
+	report, err := database.QueryReport(c.Param("report"), from, to)
+	if err != nil {
+		switch err.Error() {
+		case "report not found":
+			c.JSON(http.StatusNotFound, gin.H{"error": "Report not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to fetch report"})
+		}
+		return
+	}
+
+	if format == "csv" {
+		var buf bytes.Buffer
+		w := csv.NewWriter(&buf)
+		w.Write(report.Columns)
+		for _, row := range report.Rows {
+			record := make([]string, len(row))
+			for i, value := range row {
+				record[i] = csvValue(value)
+			}
+			w.Write(record)
+		}
+		w.Flush()
+		if err := w.Error(); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to export report"})
+			return
+		}
+
+		c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.csv"`, report.Name))
+		c.Data(http.StatusOK, "text/csv; charset=utf-8", buf.Bytes())
+		return
+	}
+
+	rows := make([]gin.H, 0, len(report.Rows))
+	for _, row := range report.Rows {
+		entry := make(gin.H, len(row))
+		for i, value := range row {
+			entry[report.Columns[i]] = value
+		}
+		rows = append(rows, entry)
+	}
+
+	c.JSON(http.StatusOK, gin.H{"report": report.ReportInfo, "rows": rows})
+}
+
+// RefreshReports rebuilds every report now rather than waiting for the scheduler.
+func RefreshReports(c *gin.Context) {
+	if _, ok := getAdmin(c); !ok {
+		return
+	}
+
+	if err := database.RefreshReportViews(c.Request.Context()); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to refresh reports"})
+		return
+	}
+
+	reports, err := database.GetReports()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to fetch reports"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Reports refreshed", "reports": reports})
+}
+
+// csvValue renders a report cell. Text that a spreadsheet would read as a formula is prefixed
+// with a quote, since titles, company names and skills come from users.
+func csvValue(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case string:
+		if v != "" && strings.ContainsRune("=+-@\t\r", rune(v[0])) {
+			return "'" + v
+		}
+		return v
+	}
+	return fmt.Sprint(value)
+}
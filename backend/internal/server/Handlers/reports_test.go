@@ -0,0 +1,29 @@
+package handlers
+
+import "testing"
+
+func TestCSVValue(t *testing.T) {
+	tests := []struct {
+		value any
+		want  string
+	}{
+		{nil, ""},
+		{int64(-3), "-3"},
+		{0.25, "0.25"},
+		{"Go", "Go"},
+		{"", ""},
+		{"2026-01-02", "2026-01-02"},
+		{"=HYPERLINK(\"http://evil\")", "'=HYPERLINK(\"http://evil\")"},
+		{"+1 555", "'+1 555"},
+		{"-cmd", "'-cmd"},
+		{"@SUM(A1)", "'@SUM(A1)"},
+		{"\tlead", "'\tlead"},
+		{"\rlead", "'\rlead"},
+	}
+
+	for _, tt := range tests {
+		if got := csvValue(tt.value); got != tt.want {
+			t.Errorf("csvValue(%q) = %q, want %q", tt.value, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,161 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/hridaya14/Web-Tech-Project/internal/audit"
+	"github.com/hridaya14/Web-Tech-Project/internal/database"
+	"github.com/hridaya14/Web-Tech-Project/internal/models"
+	"github.com/hridaya14/Web-Tech-Project/internal/policy"
+	"github.com/hridaya14/Web-Tech-Project/internal/resume"
+	"github.com/hridaya14/Web-Tech-Project/pkg/document"
+)
+
+const (
+	maxStoredResumeText = 100_000
+	resumeLinkTTL       = 5 * time.Minute
+	maxResumeAccessLog  = 200
+)
+
+// ParseResume extracts the text of an uploaded resume and guesses profile fields from it,
+// so the onboarding form can be filled in before the profile exists.
+func ParseResume(c *gin.Context) {
+	userContext, ok := GetAuthenticatedUser(c)
+	if !ok {
+		return
+	}
+
+	limitRequestBody(c, 1)
+	fileHeader, err := c.FormFile("resume_file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Resume file required"})
+		return
+	}
+
+	data, _, err := screenDocument(c.Request.Context(), fileHeader, userContext.ID, "resume")
+	if err != nil {
+		replyUploadError(c, err)
+		return
+	}
+
+	text, err := document.ExtractText(data)
+	if err != nil {
+		switch {
+		case errors.Is(err, document.ErrNoText):
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "No text could be read from the resume"})
+		default:
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "Unable to read resume: " + err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"parsed": resume.Parse(text)})
+}
+
+// indexResumeText stores the text of a candidate's resume so it can be searched.
+// Documents the extractor cannot read are skipped.
+func indexResumeText(candidateID uuid.UUID, data []byte) {
+	text, err := document.ExtractText(data)
+	if err != nil {
+		log.Printf("Could not extract resume text for candidate %s: %v", candidateID, err)
+		return
+	}
+	if len(text) > maxStoredResumeText {
+		text = strings.ToValidUTF8(text[:maxStoredResumeText], "")
+	}
+	if err := database.SetCandidateResumeText(candidateID, text); err != nil {
+		log.Printf("Error indexing resume of candidate %s: %v", candidateID, err)
+	}
+}
+
+// mintResumeLink presigns a short-lived download link for the candidate's resume and logs who got it.
+func mintResumeLink(c *gin.Context, candidate models.Candidate, viewer *models.AuthenticatedUser, companyID *uuid.UUID, reason string) (models.ResumeLink, error) {
+	if candidate.ResumeKey == "" {
+		return models.ResumeLink{}, errors.New("candidate has no resume")
+	}
+
+	url, err := services.Storage.Presign(c.Request.Context(), candidate.ResumeKey, resumeLinkTTL)
+	if err != nil {
+		log.Printf("Error presigning resume for candidate %s: %v", candidate.ID, err)
+		return models.ResumeLink{}, err
+	}
+
+	// A link nobody can account for is never handed out
+	err = database.LogResumeAccess(models.ResumeAccess{
+		CandidateID: candidate.ID,
+		UserID:      &viewer.ID,
+		CompanyID:   companyID,
+		Reason:      reason,
+		IPAddress:   c.ClientIP(),
+		UserAgent:   c.Request.UserAgent(),
+	})
+	if err != nil {
+		return models.ResumeLink{}, err
+	}
+	audit.Record(c, models.AuditResumeLink, audit.Target{Type: "candidate", ID: candidate.ID.String()}, nil, nil,
+		map[string]any{"reason": reason})
+
+	return models.ResumeLink{URL: url, ExpiresAt: time.Now().Add(resumeLinkTTL)}, nil
+}
+
+// GetCandidateResume hands the owner, or a company the candidate applied to, a short-lived
+// link to the resume. Everyone else is told there is no such resume.
+func GetCandidateResume(c *gin.Context) {
+	viewer, ok := GetAuthenticatedUser(c)
+	if !ok {
+		return
+	}
+
+	candidateID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid UUID"})
+		return
+	}
+
+	candidate, err := database.GetCandidateByID(candidateID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Resume not found"})
+		return
+	}
+
+	policyViewer, facts, err := candidatePolicy(viewer, candidate)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to process request"})
+		return
+	}
+	reason := policy.ResumeAccess(policyViewer, facts)
+	if reason == "" || candidate.ResumeKey == "" {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Resume not found"})
+		return
+	}
+
+	link, err := mintResumeLink(c, candidate, viewer, companyIDOf(policyViewer), reason)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to create resume link"})
+		return
+	}
+
+	c.JSON(http.StatusOK, link)
+}
+
+// GetResumeAccessLog shows candidates who was handed links to their resume.
+func GetResumeAccessLog(c *gin.Context) {
+	candidateID, _, ok := getCandidateID(c)
+	if !ok {
+		return
+	}
+
+	entries, err := database.GetResumeAccessLog(candidateID, maxResumeAccessLog)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to fetch resume access log"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"access_log": entries})
+}
@@ -0,0 +1,68 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/hridaya14/Web-Tech-Project/internal/database"
+	"github.com/hridaya14/Web-Tech-Project/internal/retention"
+)
+
+const defaultRetentionRunsLimit = 50
+
+// RetentionDryRun reports what the configured retention rules would remove right now,
+// without removing anything. The report is kept like any other run.
+func RetentionDryRun(c *gin.Context) {
+	if _, ok := getAdmin(c); !ok {
+		return
+	}
+
+	run, err := retention.Run(c.Request.Context(), services.Storage, services.Retention, true)
+	if err != nil && run.ID == uuid.Nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to run retention rules"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"run": run})
+}
+
+func GetRetentionRuns(c *gin.Context) {
+	if _, ok := getAdmin(c); !ok {
+		return
+	}
+
+	runs, err := database.GetRetentionRuns(defaultRetentionRunsLimit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to fetch retention runs"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"runs": runs})
+}
+
+// GetRetentionRun shows one run with every candidate it matched and what happened to them.
+func GetRetentionRun(c *gin.Context) {
+	if _, ok := getAdmin(c); !ok {
+		return
+	}
+
+	runID, err := uuid.Parse(c.Param("run_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid run_id format"})
+		return
+	}
+
+	run, err := database.GetRetentionRun(runID)
+	if err != nil {
+		switch err.Error() {
+		case "retention run not found":
+			c.JSON(http.StatusNotFound, gin.H{"error": "Retention run not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to fetch retention run"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"run": run})
+}
@@ -0,0 +1,156 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/hridaya14/Web-Tech-Project/internal/database"
+	"github.com/hridaya14/Web-Tech-Project/internal/models"
+)
+
+// getCompanyApplication resolves the :application_id param and checks it belongs to the caller's company.
+func getCompanyApplication(c *gin.Context, allowedRoles ...string) (uuid.UUID, uuid.UUID, *models.AuthenticatedUser, bool) {
+	companyID, userContext, _, ok := GetCompanyMember(c, allowedRoles...)
+	if !ok {
+		return uuid.UUID{}, uuid.UUID{}, nil, false
+	}
+
+	applicationID, err := uuid.Parse(c.Param("application_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid application_id format"})
+		return uuid.UUID{}, uuid.UUID{}, nil, false
+	}
+
+	belongs, err := database.ApplicationBelongsToCompany(applicationID, companyID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to process request"})
+		return uuid.UUID{}, uuid.UUID{}, nil, false
+	}
+	if !belongs {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Application not found"})
+		return uuid.UUID{}, uuid.UUID{}, nil, false
+	}
+
+	return applicationID, companyID, userContext, true
+}
+
+func CreateApplicationNote(c *gin.Context) {
+	applicationID, companyID, userContext, ok := getCompanyApplication(c, recruiterRoles...)
+	if !ok {
+		return
+	}
+
+	var input models.ApplicationNoteRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	note, err := database.CreateApplicationNote(applicationID, companyID, userContext.ID, input.Body)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Could not save note"})
+		return
+	}
+	note.AuthorName = userContext.Username
+
+	c.JSON(http.StatusCreated, gin.H{"note": note})
+}
+
+func DeleteApplicationNote(c *gin.Context) {
+	_, companyID, userContext, ok := getCompanyApplication(c, recruiterRoles...)
+	if !ok {
+		return
+	}
+
+	noteID, err := uuid.Parse(c.Param("note_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid note_id format"})
+		return
+	}
+
+	err = database.DeleteApplicationNote(noteID, companyID, userContext.ID)
+	if err != nil {
+		if err.Error() == "note not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Note not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Could not delete note"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Note deleted successfully"})
+}
+
+func RateApplication(c *gin.Context) {
+	applicationID, companyID, userContext, ok := getCompanyApplication(c, recruiterRoles...)
+	if !ok {
+		return
+	}
+
+	var input models.ApplicationRatingRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "rating must be between 1 and 5"})
+		return
+	}
+
+	rating, err := database.UpsertApplicationRating(applicationID, companyID, userContext.ID, input.Rating)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Could not save rating"})
+		return
+	}
+	rating.ReviewerName = userContext.Username
+
+	c.JSON(http.StatusOK, gin.H{"rating": rating})
+}
+
+func AddApplicationTags(c *gin.Context) {
+	applicationID, companyID, userContext, ok := getCompanyApplication(c, recruiterRoles...)
+	if !ok {
+		return
+	}
+
+	var input models.ApplicationTagsRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	tags := database.NormalizeTags(input.Tags)
+	if len(tags) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "At least one non-empty tag is required"})
+		return
+	}
+
+	if err := database.AddApplicationTags(applicationID, companyID, userContext.ID, tags); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Could not save tags"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Tags added successfully", "tags": tags})
+}
+
+func RemoveApplicationTag(c *gin.Context) {
+	applicationID, companyID, _, ok := getCompanyApplication(c, recruiterRoles...)
+	if !ok {
+		return
+	}
+
+	tags := database.NormalizeTags([]string{c.Param("tag")})
+	if len(tags) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid tag"})
+		return
+	}
+
+	err := database.RemoveApplicationTag(applicationID, companyID, tags[0])
+	if err != nil {
+		if err.Error() == "tag not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Tag not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Could not remove tag"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Tag removed successfully"})
+}
@@ -0,0 +1,93 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/hridaya14/Web-Tech-Project/internal/alerts"
+	"github.com/hridaya14/Web-Tech-Project/internal/database"
+	"github.com/hridaya14/Web-Tech-Project/internal/models"
+)
+
+// GetSavedJobs lists the caller's bookmarks, flagging open ones whose deadline is near.
+func GetSavedJobs(c *gin.Context) {
+	candidateID, _, ok := getCandidateID(c)
+	if !ok {
+		return
+	}
+
+	saved, err := database.GetSavedJobs(candidateID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to fetch saved jobs"})
+		return
+	}
+
+	now := time.Now()
+	for i, job := range saved {
+		saved[i].ClosingSoon = job.ClosedAt == nil && job.ClosesAt != nil &&
+			job.ClosesAt.After(now) && job.ClosesAt.Sub(now) <= alerts.ClosingSoonWindow
+	}
+
+	c.JSON(http.StatusOK, gin.H{"saved_jobs": saved})
+}
+
+// SaveJob bookmarks a listing with an optional note. Saving it again replaces the note.
+func SaveJob(c *gin.Context) {
+	candidateID, _, ok := getCandidateID(c)
+	if !ok {
+		return
+	}
+
+	jobID, err := uuid.Parse(c.Param("job_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid job_id format"})
+		return
+	}
+
+	// The body is optional
+	var input models.SaveJobRequest
+	if err := c.ShouldBindJSON(&input); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := database.SaveJob(candidateID, jobID, strings.TrimSpace(input.Note)); err != nil {
+		if err.Error() == "job listing not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Open listing not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Could not save job"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Job saved successfully"})
+}
+
+func UnsaveJob(c *gin.Context) {
+	candidateID, _, ok := getCandidateID(c)
+	if !ok {
+		return
+	}
+
+	jobID, err := uuid.Parse(c.Param("job_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid job_id format"})
+		return
+	}
+
+	if err := database.UnsaveJob(candidateID, jobID); err != nil {
+		if err.Error() == "saved job not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Saved job not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Could not remove saved job"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Job removed from saved jobs"})
+}
@@ -0,0 +1,110 @@
+package handlers
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/hridaya14/Web-Tech-Project/internal/database"
+	"github.com/hridaya14/Web-Tech-Project/internal/models"
+	"github.com/hridaya14/Web-Tech-Project/internal/scoring"
+)
+
+const (
+	applicationScoreTimeout = time.Minute
+	listingRescoreTimeout   = 10 * time.Minute
+)
+
+// scoreApplication computes the match score of a new application in the background.
+func scoreApplication(applicationID uuid.UUID) {
+	ctx, cancel := context.WithTimeout(context.Background(), applicationScoreTimeout)
+	defer cancel()
+
+	if err := scoring.ScoreApplication(ctx, services.AI, applicationID); err != nil {
+		log.Printf("Error scoring application %s: %v", applicationID, err)
+	}
+}
+
+func rescoreListing(listingID uuid.UUID) {
+	ctx, cancel := context.WithTimeout(context.Background(), listingRescoreTimeout)
+	defer cancel()
+
+	scored, err := scoring.RescoreListing(ctx, services.AI, listingID)
+	if err != nil {
+		log.Printf("Error rescoring listing %s, %d applications scored: %v", listingID, scored, err)
+		return
+	}
+	log.Printf("Rescored %d applications of listing %s", scored, listingID)
+}
+
+// RescoreCompanyListing recomputes the match scores of every application to a listing,
+// for when its description or requirements have changed.
+func RescoreCompanyListing(c *gin.Context) {
+	companyID, _, _, ok := GetCompanyMember(c, recruiterRoles...)
+	if !ok {
+		return
+	}
+
+	var req models.RescoreListingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Missing or invalid listing_id"})
+		return
+	}
+
+	listingID, err := uuid.Parse(req.ListingID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid listing_id format"})
+		return
+	}
+
+	listing, err := database.GetJobListingByID(listingID)
+	if err != nil {
+		if err.Error() == "job listing not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Listing not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to process request"})
+		return
+	}
+	if listing.Company_id != companyID {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Listing not found"})
+		return
+	}
+
+	go rescoreListing(listing.ID)
+
+	c.JSON(http.StatusAccepted, gin.H{"message": "Rescoring started"})
+}
+
+// GetApplicationMatch explains the match between an applicant and the listing,
+// with the matched and missing skills and the stored score.
+func GetApplicationMatch(c *gin.Context) {
+	applicationID, _, _, ok := getCompanyApplication(c)
+	if !ok {
+		return
+	}
+
+	input, err := database.GetScoringInput(applicationID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to process request"})
+		return
+	}
+	listing, err := database.GetJobListingByID(input.JobID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to process request"})
+		return
+	}
+	score, err := database.GetApplicationScore(applicationID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to process request"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"score":     score,
+		"breakdown": scoring.Breakdown(input, listing),
+	})
+}
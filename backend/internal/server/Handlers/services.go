@@ -0,0 +1,51 @@
+package handlers
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/hridaya14/Web-Tech-Project/internal/retention"
+	"github.com/hridaya14/Web-Tech-Project/pkg/ai"
+	"github.com/hridaya14/Web-Tech-Project/pkg/bucket"
+	"github.com/hridaya14/Web-Tech-Project/pkg/document"
+	"github.com/hridaya14/Web-Tech-Project/pkg/scan"
+)
+
+// Services are the shared clients handlers depend on, created once at startup
+type Services struct {
+	AI           ai.Client
+	Scanner      scan.Scanner
+	UploadLimits document.Limits
+	Storage      bucket.Storage
+	Retention    retention.Config
+}
+
+var services = Services{
+	AI:           ai.NewFake(),
+	Scanner:      scan.Noop{},
+	UploadLimits: document.Limits{MaxSize: 10 << 20, MaxPages: 20},
+	Storage:      bucket.NewLocal(filepath.Join(os.TempDir(), "uploads"), "http://localhost:5000/files", nil),
+	Retention:    retention.DefaultConfig(),
+}
+
+// Configure replaces the services handlers use; unset fields keep their defaults.
+func Configure(s Services) {
+	if s.AI != nil {
+		services.AI = s.AI
+	}
+	if s.Scanner != nil {
+		services.Scanner = s.Scanner
+	}
+	if s.Storage != nil {
+		services.Storage = s.Storage
+	}
+	if s.Retention.Mode != "" {
+		services.Retention = s.Retention
+	}
+	if s.UploadLimits.MaxSize > 0 {
+		services.UploadLimits.MaxSize = s.UploadLimits.MaxSize
+	}
+	if s.UploadLimits.MaxPages > 0 {
+		services.UploadLimits.MaxPages = s.UploadLimits.MaxPages
+	}
+}
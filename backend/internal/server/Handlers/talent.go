@@ -0,0 +1,182 @@
+package handlers
+
+import (
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/hridaya14/Web-Tech-Project/internal/database"
+	"github.com/hridaya14/Web-Tech-Project/internal/models"
+	"github.com/hridaya14/Web-Tech-Project/internal/notify"
+)
+
+const (
+	defaultTalentSearchLimit = 20
+	maxTalentSearchLimit     = 100
+)
+
+// getCandidateID resolves the caller's candidate profile, rejecting other roles.
+func getCandidateID(c *gin.Context) (uuid.UUID, *models.AuthenticatedUser, bool) {
+	candidateID, userContext, ok := GetAuthenticatedID(c)
+	if !ok {
+		return uuid.UUID{}, nil, false
+	}
+	if userContext.Role != CANDIDATE {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Only candidates can do this"})
+		return uuid.UUID{}, nil, false
+	}
+	return candidateID, userContext, true
+}
+
+// queryInt reads an optional non-negative integer query parameter, replying with 400 when it is invalid.
+func queryInt(c *gin.Context, name string) (*int, bool) {
+	raw := c.Query(name)
+	if raw == "" {
+		return nil, true
+	}
+	value, err := strconv.Atoi(raw)
+	if err != nil || value < 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid " + name})
+		return nil, false
+	}
+	return &value, true
+}
+
+func SetOpenToOpportunities(c *gin.Context) {
+	candidateID, _, ok := getCandidateID(c)
+	if !ok {
+		return
+	}
+
+	var input models.OpenToOpportunitiesRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := database.SetOpenToOpportunities(candidateID, *input.Open); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to update profile"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Profile updated successfully", "open_to_opportunities": *input.Open})
+}
+
+func SearchTalentPool(c *gin.Context) {
+	companyID, _, _, ok := GetCompanyMember(c)
+	if !ok {
+		return
+	}
+
+	filters := models.TalentSearchFilters{
+		Location:     strings.TrimSpace(c.Query("location")),
+		Status:       strings.TrimSpace(c.Query("status")),
+		ExpectedRole: strings.TrimSpace(c.Query("role")),
+		Query:        strings.TrimSpace(c.Query("q")),
+		Limit:        defaultTalentSearchLimit,
+	}
+	if filters.Status != "" && !slices.Contains(models.CandidateStatuses, filters.Status) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid status"})
+		return
+	}
+	for _, skill := range strings.Split(c.Query("skills"), ",") {
+		if skill = strings.TrimSpace(skill); skill != "" {
+			filters.Skills = append(filters.Skills, skill)
+		}
+	}
+
+	if filters.MinExperience, ok = queryInt(c, "min_experience"); !ok {
+		return
+	}
+	if filters.MaxExperience, ok = queryInt(c, "max_experience"); !ok {
+		return
+	}
+	if filters.MinExperience != nil && filters.MaxExperience != nil && *filters.MinExperience > *filters.MaxExperience {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "min_experience cannot exceed max_experience"})
+		return
+	}
+
+	limit, ok := queryInt(c, "limit")
+	if !ok {
+		return
+	}
+	if limit != nil && *limit > 0 {
+		filters.Limit = min(*limit, maxTalentSearchLimit)
+	}
+	offset, ok := queryInt(c, "offset")
+	if !ok {
+		return
+	}
+	if offset != nil {
+		filters.Offset = *offset
+	}
+
+	candidates, err := database.SearchTalentPool(companyID, filters)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to search candidates"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"candidates": candidates, "count": len(candidates)})
+}
+
+func InviteCandidateToApply(c *gin.Context) {
+	companyID, userContext, _, ok := GetCompanyMember(c, recruiterRoles...)
+	if !ok {
+		return
+	}
+
+	candidateID, err := uuid.Parse(c.Param("candidate_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid candidate_id format"})
+		return
+	}
+
+	var input models.TalentInviteRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	jobID, err := uuid.Parse(input.JobID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid job_id format"})
+		return
+	}
+
+	invitation, err := database.CreateTalentInvitation(companyID, candidateID, jobID, userContext.ID, strings.TrimSpace(input.Message))
+	if err != nil {
+		switch err.Error() {
+		case "candidate not found":
+			c.JSON(http.StatusNotFound, gin.H{"error": "Candidate not found"})
+		case "job listing not found":
+			c.JSON(http.StatusNotFound, gin.H{"error": "Open listing not found"})
+		case "candidate already invited":
+			c.JSON(http.StatusConflict, gin.H{"error": "Candidate was already invited to this listing"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Could not send invitation"})
+		}
+		return
+	}
+
+	go notify.TalentInvited(invitation)
+
+	c.JSON(http.StatusCreated, gin.H{"message": "Invitation sent successfully", "invitation": invitation})
+}
+
+func GetTalentInvitations(c *gin.Context) {
+	candidateID, _, ok := getCandidateID(c)
+	if !ok {
+		return
+	}
+
+	invitations, err := database.GetTalentInvitationsByCandidateID(candidateID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to fetch invitations"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"invitations": invitations})
+}
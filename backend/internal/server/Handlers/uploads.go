@@ -0,0 +1,148 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"mime/multipart"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/hridaya14/Web-Tech-Project/internal/database"
+	"github.com/hridaya14/Web-Tech-Project/internal/models"
+	"github.com/hridaya14/Web-Tech-Project/pkg/bucket"
+	"github.com/hridaya14/Web-Tech-Project/pkg/document"
+)
+
+const scanTimeout = time.Minute
+
+// uploadError is an upload the handler must refuse, with the status to answer with
+type uploadError struct {
+	status  int
+	message string
+}
+
+func (e *uploadError) Error() string {
+	return e.message
+}
+
+// replyUploadError answers with the status of an uploadError, or 500 for anything else.
+func replyUploadError(c *gin.Context, err error) {
+	var uploadErr *uploadError
+	if errors.As(err, &uploadErr) {
+		c.JSON(uploadErr.status, gin.H{"error": uploadErr.message})
+		return
+	}
+	c.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to process upload"})
+}
+
+// limitRequestBody stops reading multipart bodies much larger than the biggest upload allowed.
+func limitRequestBody(c *gin.Context, files int) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, int64(files)*services.UploadLimits.MaxSize+1<<20)
+}
+
+// screenUpload reads an uploaded file within the size limit and runs it past the malware scanner.
+// Flagged files are quarantined and refused.
+func screenUpload(ctx context.Context, fileHeader *multipart.FileHeader, userID uuid.UUID, purpose string) ([]byte, error) {
+	limit := services.UploadLimits.MaxSize
+	if fileHeader.Size > limit {
+		return nil, &uploadError{http.StatusRequestEntityTooLarge, fmt.Sprintf("%s exceeds the %d MB limit", fileHeader.Filename, limit>>20)}
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return nil, &uploadError{http.StatusBadRequest, "Unable to read " + fileHeader.Filename}
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(io.LimitReader(file, limit+1))
+	if err != nil {
+		return nil, &uploadError{http.StatusBadRequest, "Unable to read " + fileHeader.Filename}
+	}
+	if int64(len(data)) > limit {
+		return nil, &uploadError{http.StatusRequestEntityTooLarge, fmt.Sprintf("%s exceeds the %d MB limit", fileHeader.Filename, limit>>20)}
+	}
+
+	scanCtx, cancel := context.WithTimeout(ctx, scanTimeout)
+	defer cancel()
+
+	result, err := services.Scanner.Scan(scanCtx, bytes.NewReader(data))
+	if err != nil {
+		// Unscanned files are never accepted
+		log.Printf("Error scanning upload: %v", err)
+		return nil, &uploadError{http.StatusServiceUnavailable, "Unable to scan the file right now, please try again later"}
+	}
+	if !result.Clean {
+		quarantine(data, fileHeader.Filename, userID, purpose, result.Signature)
+		return nil, &uploadError{http.StatusUnprocessableEntity, fileHeader.Filename + " was flagged by the malware scanner"}
+	}
+
+	return data, nil
+}
+
+// screenDocument is screenUpload for files that must be PDF, DOCX or ODT documents.
+func screenDocument(ctx context.Context, fileHeader *multipart.FileHeader, userID uuid.UUID, purpose string) ([]byte, document.Info, error) {
+	data, err := screenUpload(ctx, fileHeader, userID, purpose)
+	if err != nil {
+		return nil, document.Info{}, err
+	}
+
+	info, err := document.Validate(data, services.UploadLimits)
+	switch {
+	case err == nil:
+		return data, info, nil
+	case errors.Is(err, document.ErrUnsupportedFormat):
+		return nil, info, &uploadError{http.StatusUnsupportedMediaType, "File must be a PDF, DOCX or ODT document"}
+	case errors.Is(err, document.ErrTooLarge):
+		return nil, info, &uploadError{http.StatusRequestEntityTooLarge, "File is too large"}
+	case errors.Is(err, document.ErrTooManyPages):
+		return nil, info, &uploadError{http.StatusUnprocessableEntity, fmt.Sprintf("Document has %d pages, at most %d are allowed", info.Pages, services.UploadLimits.MaxPages)}
+	case errors.Is(err, document.ErrEncrypted):
+		return nil, info, &uploadError{http.StatusUnprocessableEntity, "Password protected documents are not accepted"}
+	case errors.Is(err, document.ErrActiveContent):
+		return nil, info, &uploadError{http.StatusUnprocessableEntity, "Documents with macros or scripts are not accepted"}
+	default:
+		return nil, info, err
+	}
+}
+
+// storeUpload puts data under a new key in folder and returns the key and where it is served from.
+func storeUpload(ctx context.Context, data []byte, folder, ext, contentType string) (string, string, error) {
+	key := bucket.NewKey(folder, ext)
+	location, err := services.Storage.Put(ctx, key, bytes.NewReader(data), int64(len(data)), contentType)
+	if err != nil {
+		return "", "", err
+	}
+	return key, location, nil
+}
+
+// quarantine keeps a flagged file away from user uploads and records it for review.
+func quarantine(data []byte, fileName string, userID uuid.UUID, purpose, signature string) {
+	sum := sha256.Sum256(data)
+	record := models.QuarantinedUpload{
+		Purpose:   purpose,
+		FileName:  fileName,
+		SHA256:    hex.EncodeToString(sum[:]),
+		SizeBytes: int64(len(data)),
+		Signature: signature,
+	}
+	if userID != uuid.Nil {
+		record.UserID = &userID
+	}
+
+	if key, _, err := storeUpload(context.Background(), data, "quarantine", ".bin", "application/octet-stream"); err != nil {
+		log.Printf("Error storing quarantined upload: %v", err)
+	} else {
+		record.FileKey = &key
+	}
+
+	log.Printf("Quarantined %s upload %q from user %s: %s", purpose, fileName, userID, signature)
+	database.CreateQuarantinedUpload(record)
+}
@@ -7,18 +7,29 @@ import (
 	"github.com/hridaya14/Web-Tech-Project/internal/models"
 	"log"
 	"net/http"
+	"slices"
 )
 
-func GetAuthenticatedID(c *gin.Context) (uuid.UUID, *models.AuthenticatedUser, bool) {
+// GetAuthenticatedUser returns the user set by the auth middleware
+func GetAuthenticatedUser(c *gin.Context) (*models.AuthenticatedUser, bool) {
 	value, exists := c.Get("user")
 	if !exists {
 		c.JSON(http.StatusUnauthorized, gin.H{"message": "User not found in context"})
-		return uuid.UUID{}, nil, false
+		return nil, false
 	}
 
 	userContext, ok := value.(*models.AuthenticatedUser)
 	if !ok {
 		c.JSON(http.StatusUnauthorized, gin.H{"message": "Invalid user context type"})
+		return nil, false
+	}
+
+	return userContext, true
+}
+
+func GetAuthenticatedID(c *gin.Context) (uuid.UUID, *models.AuthenticatedUser, bool) {
+	userContext, ok := GetAuthenticatedUser(c)
+	if !ok {
 		return uuid.UUID{}, nil, false
 	}
 
@@ -37,3 +48,42 @@ func GetAuthenticatedID(c *gin.Context) (uuid.UUID, *models.AuthenticatedUser, b
 
 	return candidateID, userContext, true
 }
+
+// getAdmin returns the caller if they are an admin, replying 403 otherwise.
+func getAdmin(c *gin.Context) (*models.AuthenticatedUser, bool) {
+	userContext, ok := GetAuthenticatedUser(c)
+	if !ok {
+		return nil, false
+	}
+	if userContext.Role != ADMIN {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Only admins can do this"})
+		return nil, false
+	}
+	return userContext, true
+}
+
+// GetCompanyMember resolves the caller's company and checks their member role is one of allowedRoles.
+// With no allowedRoles any member is accepted.
+func GetCompanyMember(c *gin.Context, allowedRoles ...string) (uuid.UUID, *models.AuthenticatedUser, string, bool) {
+	companyID, userContext, ok := GetAuthenticatedID(c)
+	if !ok {
+		return uuid.UUID{}, nil, "", false
+	}
+
+	role, err := database.GetCompanyMemberRole(companyID, userContext.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to process request"})
+		return uuid.UUID{}, nil, "", false
+	}
+	if role == "" {
+		c.JSON(http.StatusForbidden, gin.H{"error": "You are not a member of this company"})
+		return uuid.UUID{}, nil, "", false
+	}
+
+	if len(allowedRoles) > 0 && !slices.Contains(allowedRoles, role) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Your company role does not allow this action"})
+		return uuid.UUID{}, nil, "", false
+	}
+
+	return companyID, userContext, role, true
+}
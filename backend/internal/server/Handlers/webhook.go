@@ -0,0 +1,241 @@
+package handlers
+
+import (
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/hridaya14/Web-Tech-Project/internal/database"
+	"github.com/hridaya14/Web-Tech-Project/internal/models"
+	"github.com/hridaya14/Web-Tech-Project/internal/webhooks"
+	"github.com/hridaya14/Web-Tech-Project/pkg/auth"
+)
+
+const (
+	webhookSecretPrefix   = "whsec_"
+	defaultDeliveryLimit  = 50
+	maxDeliveryListLength = 200
+)
+
+// validateWebhookInput checks the endpoint URL and event types, replying with 400 when they are invalid.
+func validateWebhookInput(c *gin.Context, rawURL *string, eventTypes []string) bool {
+	if rawURL != nil {
+		u, err := url.Parse(*rawURL)
+		if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid webhook URL"})
+			return false
+		}
+		if webhooks.CheckHost(u.Hostname()) != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Webhook URL must point to a public host"})
+			return false
+		}
+		if isProd && u.Scheme != "https" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Webhook URL must use https"})
+			return false
+		}
+	}
+
+	for _, eventType := range eventTypes {
+		if !slices.Contains(models.WebhookEventTypes, eventType) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Unknown event type: " + eventType})
+			return false
+		}
+	}
+	return true
+}
+
+func newWebhookSecret(c *gin.Context) (string, bool) {
+	token, err := auth.GenerateRandomToken(32)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to process request"})
+		return "", false
+	}
+	return webhookSecretPrefix + token, true
+}
+
+// getCompanyWebhook loads the endpoint named in the URL if it belongs to the caller's company.
+func getCompanyWebhook(c *gin.Context) (models.WebhookEndpoint, bool) {
+	companyID, _, _, ok := GetCompanyMember(c, teamManagerRoles...)
+	if !ok {
+		return models.WebhookEndpoint{}, false
+	}
+
+	endpointID, err := uuid.Parse(c.Param("webhook_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid webhook_id format"})
+		return models.WebhookEndpoint{}, false
+	}
+
+	endpoint, err := database.GetWebhookEndpoint(endpointID, companyID)
+	if err != nil {
+		if err.Error() == "webhook endpoint not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Webhook not found"})
+			return models.WebhookEndpoint{}, false
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to fetch webhook"})
+		return models.WebhookEndpoint{}, false
+	}
+	return endpoint, true
+}
+
+func GetCompanyWebhooks(c *gin.Context) {
+	companyID, _, _, ok := GetCompanyMember(c, teamManagerRoles...)
+	if !ok {
+		return
+	}
+
+	endpoints, err := database.GetWebhookEndpoints(companyID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to fetch webhooks"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"webhooks": endpoints, "event_types": models.WebhookEventTypes})
+}
+
+func CreateCompanyWebhook(c *gin.Context) {
+	companyID, userContext, _, ok := GetCompanyMember(c, teamManagerRoles...)
+	if !ok {
+		return
+	}
+
+	var input models.WebhookEndpointRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if !validateWebhookInput(c, &input.URL, input.EventTypes) {
+		return
+	}
+
+	secret, ok := newWebhookSecret(c)
+	if !ok {
+		return
+	}
+
+	endpoint, err := database.CreateWebhookEndpoint(companyID, userContext.ID, input.URL, secret, input.EventTypes)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Could not create webhook"})
+		return
+	}
+
+	// The secret is only ever shown here and on rotation
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Webhook created successfully",
+		"webhook": endpoint,
+		"secret":  secret,
+	})
+}
+
+func UpdateCompanyWebhook(c *gin.Context) {
+	endpoint, ok := getCompanyWebhook(c)
+	if !ok {
+		return
+	}
+
+	var input models.WebhookEndpointUpdateRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if input.EventTypes != nil && len(input.EventTypes) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "At least one event type is required"})
+		return
+	}
+	if !validateWebhookInput(c, input.URL, input.EventTypes) {
+		return
+	}
+
+	updated, err := database.UpdateWebhookEndpoint(endpoint.ID, endpoint.CompanyID, input.URL, input.EventTypes, input.Active)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Could not update webhook"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Webhook updated successfully", "webhook": updated})
+}
+
+func RotateCompanyWebhookSecret(c *gin.Context) {
+	endpoint, ok := getCompanyWebhook(c)
+	if !ok {
+		return
+	}
+
+	secret, ok := newWebhookSecret(c)
+	if !ok {
+		return
+	}
+
+	if err := database.RotateWebhookSecret(endpoint.ID, endpoint.CompanyID, secret); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Could not rotate secret"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Secret rotated successfully", "secret": secret})
+}
+
+func DeleteCompanyWebhook(c *gin.Context) {
+	endpoint, ok := getCompanyWebhook(c)
+	if !ok {
+		return
+	}
+
+	if err := database.DeleteWebhookEndpoint(endpoint.ID, endpoint.CompanyID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Could not delete webhook"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Webhook deleted successfully"})
+}
+
+func GetCompanyWebhookDeliveries(c *gin.Context) {
+	endpoint, ok := getCompanyWebhook(c)
+	if !ok {
+		return
+	}
+
+	limit := defaultDeliveryLimit
+	if raw := c.Query("limit"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed < 1 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid limit"})
+			return
+		}
+		limit = min(parsed, maxDeliveryListLength)
+	}
+
+	deliveries, err := database.GetWebhookDeliveries(endpoint.ID, limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to fetch deliveries"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"deliveries": deliveries})
+}
+
+func RedeliverCompanyWebhook(c *gin.Context) {
+	companyID, _, _, ok := GetCompanyMember(c, teamManagerRoles...)
+	if !ok {
+		return
+	}
+
+	deliveryID, err := uuid.Parse(c.Param("delivery_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid delivery_id format"})
+		return
+	}
+
+	if err := database.RedeliverWebhook(deliveryID, companyID); err != nil {
+		if err.Error() == "webhook delivery not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Delivery not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Could not queue redelivery"})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{"message": "Delivery queued for redelivery"})
+}
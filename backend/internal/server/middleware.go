@@ -56,9 +56,13 @@ func authenticateMiddleware(c *gin.Context) {
 		return
 	}
 
+	// Tokens carry the user role in the audience claim
+	role, _ := claims["aud"].(string)
+
 	c.Set("user", &models.AuthenticatedUser{
 		ID:       userID,
 		Username: username,
+		Role:     role,
 	})
 	// Continue with the next middleware or handler
 	c.Next()
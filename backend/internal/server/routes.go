@@ -20,16 +20,32 @@ func registerRoutes(router *gin.Engine) (*gin.Engine, error) {
 		g.JSON(http.StatusOK, gin.H{"Message ": "Welcome!!"})
 	})
 
+	// Files of the local disk storage
+	router.GET("/files/*key", handlers.ServeStoredFile)
+
 	//Profile Onboarding
 	router.GET("/getProfile", authenticateMiddleware, handlers.GetProfile)
 	router.POST("/profile/createCandidate", authenticateMiddleware, handlers.CreateCandidateProfile)
 	router.POST("/profile/createCompany", authenticateMiddleware, handlers.CreateCompanyProfile)
+	router.POST("/profile/parseResume", authenticateMiddleware, handlers.ParseResume)
 
 	//Job Seeker
 	router.GET("/candidate/getJobs", authenticateMiddleware, handlers.GetFilteredJobListings)
+	router.GET("/candidate/alerts", authenticateMiddleware, handlers.GetJobAlerts)
+	router.POST("/candidate/alerts", authenticateMiddleware, handlers.CreateJobAlert)
+	router.POST("/candidate/alerts/:alert_id/update", authenticateMiddleware, handlers.UpdateJobAlert)
+	router.DELETE("/candidate/alerts/:alert_id", authenticateMiddleware, handlers.DeleteJobAlert)
+	router.GET("/candidate/saved", authenticateMiddleware, handlers.GetSavedJobs)
+	router.POST("/candidate/saved/:job_id", authenticateMiddleware, handlers.SaveJob)
+	router.DELETE("/candidate/saved/:job_id", authenticateMiddleware, handlers.UnsaveJob)
+	router.GET("/candidate/recommendations", authenticateMiddleware, handlers.GetJobRecommendations)
+	router.POST("/candidate/openToOpportunities", authenticateMiddleware, handlers.SetOpenToOpportunities)
+	router.GET("/candidate/invitations", authenticateMiddleware, handlers.GetTalentInvitations)
 	router.POST("candidate/apply", authenticateMiddleware, handlers.CreateJobApplication)
 	router.GET("/candidate/Applications", authenticateMiddleware, handlers.GetCandidateApplications)
 	router.GET("/candidate/:id", authenticateMiddleware, handlers.GetCandidateHandler)
+	router.GET("/candidate/:id/resume", authenticateMiddleware, handlers.GetCandidateResume)
+	router.GET("/candidate/resumeAccess", authenticateMiddleware, handlers.GetResumeAccessLog)
 	router.POST("/candidate/deleteApplication", authenticateMiddleware, handlers.DeleteApplication)
 
 	//Company
@@ -37,7 +53,85 @@ func registerRoutes(router *gin.Engine) (*gin.Engine, error) {
 	router.GET("/company/getListings", authenticateMiddleware, handlers.GetJobListings)
 	router.GET("/company/Applicants", authenticateMiddleware, handlers.GetCompanyApplicants)
 	router.POST("/company/deleteListing", authenticateMiddleware, handlers.DeleteCompanyListing)
+	router.POST("/company/closeListing", authenticateMiddleware, handlers.CloseCompanyListing)
+	router.POST("/company/rescoreListing", authenticateMiddleware, handlers.RescoreCompanyListing)
+	router.GET("/company/analytics", authenticateMiddleware, handlers.GetCompanyAnalytics)
+
+	//Talent Pool
+	router.GET("/company/candidates/search", authenticateMiddleware, handlers.SearchTalentPool)
+	router.POST("/company/candidates/:candidate_id/invite", authenticateMiddleware, handlers.InviteCandidateToApply)
 	router.GET("/getListing/:job_id", authenticateMiddleware, handlers.GetJobDetailsHandler)
 
+	router.POST("/company/updateApplicationStatus", authenticateMiddleware, handlers.UpdateApplicationStatus)
+
+	//Interviews
+	router.POST("/company/interviews", authenticateMiddleware, handlers.CreateInterview)
+	router.GET("/company/interviews", authenticateMiddleware, handlers.GetCompanyInterviews)
+	router.POST("/company/interviews/:interview_id/cancel", authenticateMiddleware, handlers.CancelInterview)
+	router.GET("/candidate/interviews", authenticateMiddleware, handlers.GetCandidateInterviews)
+	router.POST("/candidate/interviews/:interview_id/select", authenticateMiddleware, handlers.SelectInterviewSlot)
+	router.GET("/interviews/:interview_id/invite.ics", authenticateMiddleware, handlers.DownloadInterviewInvite)
+	router.POST("/interviews/feedToken", authenticateMiddleware, handlers.CreateCalendarFeedToken)
+	router.GET("/interviews/feed.ics", handlers.GetInterviewFeed)
+
+	//Company Team
+	router.GET("/company/members", authenticateMiddleware, handlers.GetCompanyMembers)
+	router.POST("/company/members/invite", authenticateMiddleware, handlers.InviteCompanyMember)
+	router.POST("/company/members/updateRole", authenticateMiddleware, handlers.UpdateCompanyMemberRole)
+	router.DELETE("/company/members/:user_id", authenticateMiddleware, handlers.RemoveCompanyMember)
+	router.GET("/company/invitations", authenticateMiddleware, handlers.GetCompanyInvitations)
+	router.DELETE("/company/invitations/:invitation_id", authenticateMiddleware, handlers.RevokeCompanyInvitation)
+	router.POST("/company/invitations/accept", authenticateMiddleware, handlers.AcceptCompanyInvitation)
+
+	//Applicant Reviews
+	router.POST("/company/Applicants/:application_id/notes", authenticateMiddleware, handlers.CreateApplicationNote)
+	router.DELETE("/company/Applicants/:application_id/notes/:note_id", authenticateMiddleware, handlers.DeleteApplicationNote)
+	router.POST("/company/Applicants/:application_id/rating", authenticateMiddleware, handlers.RateApplication)
+	router.POST("/company/Applicants/:application_id/tags", authenticateMiddleware, handlers.AddApplicationTags)
+	router.DELETE("/company/Applicants/:application_id/tags/:tag", authenticateMiddleware, handlers.RemoveApplicationTag)
+	router.GET("/company/Applicants/:application_id/match", authenticateMiddleware, handlers.GetApplicationMatch)
+
+	//Messaging
+	router.GET("/messages/threads", authenticateMiddleware, handlers.GetMessageThreads)
+	router.POST("/messages/threads", authenticateMiddleware, handlers.CreateMessageThread)
+	router.GET("/messages/threads/:thread_id", authenticateMiddleware, handlers.GetMessageThread)
+	router.POST("/messages/threads/:thread_id/reply", authenticateMiddleware, handlers.ReplyToMessageThread)
+	router.GET("/messages/unread", authenticateMiddleware, handlers.GetUnreadMessageCount)
+
+	//Personal Data
+	router.POST("/me/export", authenticateMiddleware, handlers.RequestDataExport)
+	router.GET("/me/export", authenticateMiddleware, handlers.GetDataExport)
+	router.POST("/me/delete", authenticateMiddleware, handlers.RequestAccountDeletion)
+	router.GET("/me/delete", authenticateMiddleware, handlers.GetAccountDeletion)
+	router.POST("/me/delete/cancel", authenticateMiddleware, handlers.CancelAccountDeletion)
+
+	//Admin
+	router.POST("/admin/retention/dryRun", authenticateMiddleware, handlers.RetentionDryRun)
+	router.GET("/admin/retention/runs", authenticateMiddleware, handlers.GetRetentionRuns)
+	router.GET("/admin/retention/runs/:run_id", authenticateMiddleware, handlers.GetRetentionRun)
+	router.GET("/admin/audit", authenticateMiddleware, handlers.GetAuditEvents)
+	router.GET("/admin/reports", authenticateMiddleware, handlers.GetReports)
+	router.POST("/admin/reports/refresh", authenticateMiddleware, handlers.RefreshReports)
+	router.GET("/admin/reports/:report", authenticateMiddleware, handlers.GetReport)
+
+	//Notifications
+	router.GET("/notifications", authenticateMiddleware, handlers.GetNotifications)
+	router.GET("/notifications/stream", authenticateMiddleware, handlers.StreamNotifications)
+	router.POST("/notifications/:notification_id/read", authenticateMiddleware, handlers.MarkNotificationRead)
+	router.POST("/notifications/readAll", authenticateMiddleware, handlers.MarkAllNotificationsRead)
+	router.GET("/notifications/preferences", authenticateMiddleware, handlers.GetNotificationPreferences)
+	router.POST("/notifications/preferences", authenticateMiddleware, handlers.UpdateNotificationPreference)
+	router.GET("/email/unsubscribe", handlers.ConfirmUnsubscribe)
+	router.POST("/email/unsubscribe", handlers.UnsubscribeEmail)
+
+	//Webhooks
+	router.GET("/company/webhooks", authenticateMiddleware, handlers.GetCompanyWebhooks)
+	router.POST("/company/webhooks", authenticateMiddleware, handlers.CreateCompanyWebhook)
+	router.POST("/company/webhooks/:webhook_id/update", authenticateMiddleware, handlers.UpdateCompanyWebhook)
+	router.DELETE("/company/webhooks/:webhook_id", authenticateMiddleware, handlers.DeleteCompanyWebhook)
+	router.POST("/company/webhooks/:webhook_id/rotateSecret", authenticateMiddleware, handlers.RotateCompanyWebhookSecret)
+	router.GET("/company/webhooks/:webhook_id/deliveries", authenticateMiddleware, handlers.GetCompanyWebhookDeliveries)
+	router.POST("/company/webhooks/deliveries/:delivery_id/redeliver", authenticateMiddleware, handlers.RedeliverCompanyWebhook)
+
 	return router, nil
 }
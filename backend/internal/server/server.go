@@ -1,14 +1,35 @@
 package server
 
 import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
-	"time"
+	"github.com/hridaya14/Web-Tech-Project/internal/audit"
+	handlers "github.com/hridaya14/Web-Tech-Project/internal/server/Handlers"
 )
 
-func CreateServer() (*gin.Engine, error) {
+func CreateServer(services handlers.Services) (*gin.Engine, error) {
+	handlers.Configure(services)
+
 	router := gin.Default()
 
+	// Only proxies named in TRUSTED_PROXIES may set X-Forwarded-For; without any, the client IP
+	// recorded in the audit log is the address that connected
+	var trustedProxies []string
+	if v := os.Getenv("TRUSTED_PROXIES"); v != "" {
+		trustedProxies = strings.Split(v, ",")
+		for i := range trustedProxies {
+			trustedProxies[i] = strings.TrimSpace(trustedProxies[i])
+		}
+	}
+	if err := router.SetTrustedProxies(trustedProxies); err != nil {
+		return nil, fmt.Errorf("invalid TRUSTED_PROXIES: %w", err)
+	}
+
 	router.Use(cors.New(cors.Config{
 		AllowOrigins:     []string{"http://localhost:3000"},                             // Allow specific origin (frontend URL)
 		AllowMethods:     []string{"GET", "POST", "PUT", "DELETE"},                      // Allow HTTP methods
@@ -18,6 +39,9 @@ func CreateServer() (*gin.Engine, error) {
 		MaxAge:           12 * time.Hour,                                                // Cache preflight requests for 12 hours
 	}))
 
+	// Runs after every handler so it can see the authenticated user and the response status
+	router.Use(audit.Middleware())
+
 	_, err := registerRoutes(router)
 	if err != nil {
 		return nil, err
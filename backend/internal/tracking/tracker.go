@@ -0,0 +1,156 @@
+package tracking
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hridaya14/Web-Tech-Project/internal/database"
+	"github.com/hridaya14/Web-Tech-Project/internal/models"
+)
+
+const (
+	// DedupWindow is how long repeated views of a listing by one viewer count once
+	DedupWindow = 30 * time.Minute
+
+	flushInterval = 5 * time.Second
+	batchSize     = 500
+	// maxPending bounds memory while the database is unreachable; newer views are dropped
+	maxPending = 20000
+)
+
+type viewKey struct {
+	jobID    uuid.UUID
+	viewerID uuid.UUID
+	kind     string
+	bucket   time.Time
+}
+
+// Tracker collects listing views in memory and writes them to the database in batches,
+// so recording a view never waits on a query. Views are de-duplicated in memory per
+// viewer, listing, kind and DedupWindow bucket; the database enforces the same key
+// across API instances and restarts.
+type Tracker struct {
+	mu      sync.Mutex
+	pending []models.ListingView
+	seen    map[viewKey]struct{}
+	full    chan struct{}
+	bucket  time.Time // the bucket seen belongs to
+
+	now   func() time.Time
+	write func([]models.ListingView) error
+}
+
+func NewTracker() *Tracker {
+	return &Tracker{
+		seen:  make(map[viewKey]struct{}),
+		full:  make(chan struct{}, 1),
+		now:   time.Now,
+		write: database.InsertListingViews,
+	}
+}
+
+// Default is the tracker used by the handlers
+var Default = NewTracker()
+
+// Impressions records that the listings showed up in the viewer's search results.
+func (t *Tracker) Impressions(viewerID uuid.UUID, jobIDs []uuid.UUID) {
+	t.record(viewerID, models.ViewImpression, jobIDs...)
+}
+
+// Detail records that the viewer opened the listing.
+func (t *Tracker) Detail(viewerID, jobID uuid.UUID) {
+	t.record(viewerID, models.ViewDetail, jobID)
+}
+
+func (t *Tracker) record(viewerID uuid.UUID, kind string, jobIDs ...uuid.UUID) {
+	now := t.now().UTC()
+	bucket := now.Truncate(DedupWindow)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	// Keys of earlier buckets can never match again
+	if !bucket.Equal(t.bucket) {
+		t.seen = make(map[viewKey]struct{})
+		t.bucket = bucket
+	}
+
+	for _, jobID := range jobIDs {
+		key := viewKey{jobID: jobID, viewerID: viewerID, kind: kind, bucket: bucket}
+		if _, ok := t.seen[key]; ok {
+			continue
+		}
+		if len(t.pending) >= maxPending {
+			log.Printf("Dropping listing views: %d are waiting to be written", len(t.pending))
+			return
+		}
+		t.seen[key] = struct{}{}
+		t.pending = append(t.pending, models.ListingView{
+			JobID:    jobID,
+			ViewerID: viewerID,
+			Kind:     kind,
+			Bucket:   bucket,
+			ViewedAt: now,
+		})
+	}
+
+	if len(t.pending) >= batchSize {
+		select {
+		case t.full <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// Run flushes pending views every few seconds, or as soon as a batch fills up, until ctx
+// is cancelled. Whatever is pending then is flushed one last time.
+func (t *Tracker) Run(ctx context.Context) {
+	log.Println("✅ Listing view tracker started")
+
+	ticker := time.NewTicker(flushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			t.Flush()
+			return
+		case <-ticker.C:
+		case <-t.full:
+		}
+		t.Flush()
+	}
+}
+
+// Flush writes every pending view in batches. Batches that fail are kept for the next flush.
+func (t *Tracker) Flush() {
+	t.mu.Lock()
+	pending := t.pending
+	t.pending = nil
+	t.mu.Unlock()
+
+	for len(pending) > 0 {
+		n := min(len(pending), batchSize)
+		if err := t.write(pending[:n]); err != nil {
+			t.requeue(pending)
+			return
+		}
+		pending = pending[n:]
+	}
+}
+
+// requeue puts views that could not be written back in front of the ones recorded since.
+func (t *Tracker) requeue(views []models.ListingView) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	merged := append(views, t.pending...)
+	if len(merged) > maxPending {
+		log.Printf("Dropping %d listing views that could not be written", len(merged)-maxPending)
+		merged = merged[:maxPending]
+	}
+	t.pending = merged
+}
@@ -0,0 +1,134 @@
+package tracking
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hridaya14/Web-Tech-Project/internal/models"
+)
+
+// newTestTracker returns a tracker on a fixed clock that writes into written, failing
+// whenever fail returns true.
+func newTestTracker(clock *time.Time, written *[][]models.ListingView, fail func() bool) *Tracker {
+	t := NewTracker()
+	t.now = func() time.Time { return *clock }
+	t.write = func(views []models.ListingView) error {
+		if fail != nil && fail() {
+			return errors.New("database unavailable")
+		}
+		*written = append(*written, append([]models.ListingView(nil), views...))
+		return nil
+	}
+	return t
+}
+
+func jobIDs(n int) []uuid.UUID {
+	ids := make([]uuid.UUID, n)
+	for i := range ids {
+		ids[i] = uuid.New()
+	}
+	return ids
+}
+
+func TestTrackerDeduplicates(t *testing.T) {
+	clock := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+	var written [][]models.ListingView
+	tracker := newTestTracker(&clock, &written, nil)
+
+	viewer, other := uuid.New(), uuid.New()
+	job := uuid.New()
+
+	tracker.Detail(viewer, job)
+	tracker.Detail(viewer, job)
+	tracker.Impressions(viewer, []uuid.UUID{job, job})
+	tracker.Detail(other, job)
+
+	clock = clock.Add(DedupWindow - time.Second)
+	tracker.Detail(viewer, job) // same bucket
+
+	clock = clock.Add(time.Second)
+	tracker.Detail(viewer, job) // next bucket
+
+	if got := len(tracker.pending); got != 4 {
+		t.Fatalf("pending = %d views, want 4 (detail, impression, other viewer, next bucket)", got)
+	}
+	last := tracker.pending[3]
+	if !last.Bucket.Equal(clock.Truncate(DedupWindow)) || last.Kind != models.ViewDetail {
+		t.Errorf("last view = %+v, want a detail view in the next bucket", last)
+	}
+}
+
+func TestTrackerFlushesInBatches(t *testing.T) {
+	clock := time.Now()
+	var written [][]models.ListingView
+	tracker := newTestTracker(&clock, &written, nil)
+
+	tracker.Impressions(uuid.New(), jobIDs(2*batchSize+1))
+	tracker.Flush()
+
+	if len(written) != 3 || len(written[0]) != batchSize || len(written[1]) != batchSize || len(written[2]) != 1 {
+		sizes := []int{}
+		for _, batch := range written {
+			sizes = append(sizes, len(batch))
+		}
+		t.Fatalf("batches = %v, want [%d %d 1]", sizes, batchSize, batchSize)
+	}
+	if len(tracker.pending) != 0 {
+		t.Errorf("pending = %d views after a successful flush, want 0", len(tracker.pending))
+	}
+}
+
+func TestTrackerRequeuesFailedBatches(t *testing.T) {
+	clock := time.Now()
+	var written [][]models.ListingView
+	var tracker *Tracker
+	calls := 0
+	late := uuid.New()
+	tracker = newTestTracker(&clock, &written, func() bool {
+		calls++
+		if calls == 2 {
+			// A view recorded while the flush is writing must stay behind the requeued ones
+			tracker.Detail(uuid.New(), late)
+			return true
+		}
+		return false
+	})
+
+	jobs := jobIDs(batchSize + 10)
+	tracker.Impressions(uuid.New(), jobs)
+	tracker.Flush()
+
+	if len(written) != 1 || len(written[0]) != batchSize {
+		t.Fatalf("written %d batches, want the first batch only", len(written))
+	}
+	if got := len(tracker.pending); got != 11 {
+		t.Fatalf("pending = %d views, want the 10 unwritten plus the late one", got)
+	}
+	if tracker.pending[0].JobID != jobs[batchSize] || tracker.pending[10].JobID != late {
+		t.Errorf("requeued views out of order")
+	}
+
+	tracker.Flush()
+	if len(tracker.pending) != 0 || len(written) != 2 || len(written[1]) != 11 {
+		t.Errorf("retry left %d pending over %d batches, want everything written", len(tracker.pending), len(written))
+	}
+}
+
+func TestTrackerBoundsPending(t *testing.T) {
+	clock := time.Now()
+	var written [][]models.ListingView
+	tracker := newTestTracker(&clock, &written, func() bool { return true })
+
+	tracker.Impressions(uuid.New(), jobIDs(maxPending+5))
+	if got := len(tracker.pending); got != maxPending {
+		t.Fatalf("pending = %d views, want at most %d", got, maxPending)
+	}
+
+	tracker.Flush()
+	tracker.requeue(make([]models.ListingView, 10))
+	if got := len(tracker.pending); got != maxPending {
+		t.Errorf("pending after requeue = %d views, want at most %d", got, maxPending)
+	}
+}
@@ -0,0 +1,109 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"net/http"
+	"time"
+
+	"github.com/hridaya14/Web-Tech-Project/internal/database"
+	"github.com/hridaya14/Web-Tech-Project/internal/models"
+	"github.com/hridaya14/Web-Tech-Project/pkg/poll"
+)
+
+const (
+	pollInterval    = 5 * time.Second
+	batchSize       = 20
+	claimLease      = 2 * time.Minute
+	deliveryTimeout = 10 * time.Second
+	maxAttempts     = 10
+	userAgent       = "Web-Tech-Project-Webhooks/1.0"
+)
+
+var client = newClient()
+
+// RunDispatcher delivers due webhook events until ctx is cancelled.
+func RunDispatcher(ctx context.Context) {
+	log.Println("✅ Webhook dispatcher started")
+
+	poll.Run(ctx, pollInterval, nil, func() {
+		poll.Drain(ctx, batchSize, func(limit int) ([]models.WebhookDispatch, error) {
+			return database.ClaimDueWebhookDeliveries(limit, claimLease)
+		}, func(d models.WebhookDispatch) {
+			deliver(ctx, d)
+		})
+	})
+}
+
+func deliver(ctx context.Context, d models.WebhookDispatch) {
+	attempt := models.WebhookDeliveryAttempt{
+		DeliveryID: d.ID,
+		Attempt:    d.Attempts,
+	}
+
+	started := time.Now()
+	statusCode, err := post(ctx, d)
+	attempt.DurationMs = int(time.Since(started).Milliseconds())
+
+	if statusCode != 0 {
+		attempt.StatusCode = &statusCode
+	}
+	if err == nil && (statusCode < 200 || statusCode >= 300) {
+		err = fmt.Errorf("endpoint responded with status %d", statusCode)
+	}
+
+	status, next := models.DeliverySucceeded, time.Now()
+	if err != nil {
+		msg := err.Error()
+		attempt.Error = &msg
+
+		status = models.DeliveryPending
+		if d.Attempts >= maxAttempts {
+			status = models.DeliveryFailed
+		}
+		// 30s, 1m, 2m, ... roughly 4h before the last attempt
+		next = time.Now().Add(time.Duration(math.Pow(2, float64(d.Attempts-1))) * 30 * time.Second)
+		log.Printf("Error delivering webhook %s to %s (attempt %d): %v", d.ID, d.URL, d.Attempts, err)
+	}
+
+	if err := database.RecordWebhookAttempt(attempt, status, next); err != nil {
+		log.Printf("Error recording webhook attempt: %v", err)
+	}
+}
+
+// post sends the signed payload and returns the status code. The response body is discarded:
+// it comes from a host the company chose and is never shown back to them.
+func post(ctx context.Context, d models.WebhookDispatch) (int, error) {
+	body := []byte(d.Payload)
+
+	reqCtx, cancel := context.WithTimeout(ctx, deliveryTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(reqCtx, http.MethodPost, d.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, fmt.Errorf("invalid endpoint: %w", err)
+	}
+
+	now := time.Now()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", userAgent)
+	req.Header.Set(HeaderID, d.EventID.String())
+	req.Header.Set(HeaderEvent, d.EventType)
+	req.Header.Set(HeaderTimestamp, fmt.Sprint(now.Unix()))
+	req.Header.Set(HeaderSignature, Sign(d.Secret, now, body))
+
+	resp, err := client.Do(req)
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			return 0, fmt.Errorf("endpoint timed out after %s", deliveryTimeout)
+		}
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	return resp.StatusCode, nil
+}
@@ -0,0 +1,78 @@
+package webhooks
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+	"syscall"
+)
+
+var ErrForbiddenAddress = errors.New("webhook endpoints must be public addresses")
+
+// sharedAddressSpace is the carrier-grade NAT range, reachable only inside a provider's network.
+var sharedAddressSpace = netip.MustParsePrefix("100.64.0.0/10")
+
+// publicAddr reports whether ip can be reached from the internet. Loopback, private,
+// link-local, multicast and unspecified addresses all point back into our own network.
+func publicAddr(ip netip.Addr) bool {
+	ip = ip.Unmap()
+	return ip.IsValid() &&
+		!ip.IsLoopback() &&
+		!ip.IsPrivate() &&
+		!ip.IsLinkLocalUnicast() &&
+		!ip.IsLinkLocalMulticast() &&
+		!ip.IsInterfaceLocalMulticast() &&
+		!ip.IsMulticast() &&
+		!ip.IsUnspecified() &&
+		!sharedAddressSpace.Contains(ip)
+}
+
+// CheckHost rejects endpoint hosts that are plainly internal: localhost names and non-public
+// IP literals. Names that resolve to internal addresses are caught when dialing.
+func CheckHost(host string) error {
+	host = strings.TrimSuffix(strings.ToLower(host), ".")
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return ErrForbiddenAddress
+	}
+	if ip, err := netip.ParseAddr(strings.Trim(host, "[]")); err == nil && !publicAddr(ip) {
+		return ErrForbiddenAddress
+	}
+	return nil
+}
+
+// dialControl runs after DNS resolution, right before connecting, so a name that resolves
+// (or is rebound) to an internal address is refused too.
+func dialControl(network, address string, _ syscall.RawConn) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return fmt.Errorf("unexpected dial address %q: %w", address, err)
+	}
+	if !publicAddr(addrPort.Addr()) {
+		return fmt.Errorf("%w: %s", ErrForbiddenAddress, addrPort.Addr())
+	}
+	return nil
+}
+
+func newClient() *http.Client {
+	dialer := &net.Dialer{
+		Timeout: deliveryTimeout,
+		Control: dialControl,
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = dialer.DialContext
+	// A proxy would make the connection we check the proxy's, not the endpoint's
+	transport.Proxy = nil
+
+	return &http.Client{
+		Timeout:   deliveryTimeout,
+		Transport: transport,
+		// Endpoints must answer themselves; following redirects would resend the payload
+		// elsewhere, internal hosts included
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
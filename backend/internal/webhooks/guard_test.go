@@ -0,0 +1,78 @@
+package webhooks
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCheckHost(t *testing.T) {
+	tests := []struct {
+		host    string
+		allowed bool
+	}{
+		{"hooks.example.com", true},
+		{"93.184.216.34", true},
+		{"2606:2800:220:1:248:1893:25c8:1946", true},
+		{"localhost", false},
+		{"LOCALHOST.", false},
+		{"api.localhost", false},
+		{"127.0.0.1", false},
+		{"10.1.2.3", false},
+		{"172.16.0.1", false},
+		{"192.168.1.10", false},
+		{"169.254.169.254", false},
+		{"100.64.0.1", false},
+		{"0.0.0.0", false},
+		{"::1", false},
+		{"[::1]", false},
+		{"fd00::1", false},
+		{"fe80::1", false},
+		{"::ffff:127.0.0.1", false},
+	}
+
+	for _, tt := range tests {
+		err := CheckHost(tt.host)
+		if tt.allowed && err != nil {
+			t.Errorf("CheckHost(%q) = %v, want allowed", tt.host, err)
+		}
+		if !tt.allowed && !errors.Is(err, ErrForbiddenAddress) {
+			t.Errorf("CheckHost(%q) = %v, want ErrForbiddenAddress", tt.host, err)
+		}
+	}
+}
+
+func TestDialControl(t *testing.T) {
+	tests := []struct {
+		address string
+		allowed bool
+	}{
+		{"93.184.216.34:443", true},
+		{"[2606:2800:220:1:248:1893:25c8:1946]:443", true},
+		{"127.0.0.1:5432", false},
+		{"169.254.169.254:80", false},
+		{"10.0.0.5:8080", false},
+		{"[::1]:80", false},
+		{"[::ffff:192.168.0.1]:80", false},
+	}
+
+	for _, tt := range tests {
+		err := dialControl("tcp", tt.address, nil)
+		if tt.allowed && err != nil {
+			t.Errorf("dialControl(%q) = %v, want allowed", tt.address, err)
+		}
+		if !tt.allowed && !errors.Is(err, ErrForbiddenAddress) {
+			t.Errorf("dialControl(%q) = %v, want ErrForbiddenAddress", tt.address, err)
+		}
+	}
+}
+
+func TestClientRefusesInternalHosts(t *testing.T) {
+	resp, err := newClient().Get("http://127.0.0.1:1/")
+	if err == nil {
+		resp.Body.Close()
+		t.Fatal("request to loopback succeeded")
+	}
+	if !errors.Is(err, ErrForbiddenAddress) {
+		t.Errorf("error = %v, want ErrForbiddenAddress", err)
+	}
+}
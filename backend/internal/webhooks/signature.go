@@ -0,0 +1,58 @@
+package webhooks
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Headers sent with every delivery
+const (
+	HeaderID        = "X-Webhook-Id"
+	HeaderEvent     = "X-Webhook-Event"
+	HeaderTimestamp = "X-Webhook-Timestamp"
+	HeaderSignature = "X-Webhook-Signature"
+)
+
+// Sign returns the signature header value for body sent at timestamp.
+// Receivers recompute HMAC-SHA256 over "<timestamp>.<body>" with their secret and compare it to v1.
+func Sign(secret string, timestamp time.Time, body []byte) string {
+	ts := strconv.FormatInt(timestamp.Unix(), 10)
+	return fmt.Sprintf("t=%s,v1=%s", ts, computeSignature(secret, ts, body))
+}
+
+// Verify checks a signature header against body, rejecting timestamps more than tolerance
+// away from now in either direction.
+func Verify(secret, header string, body []byte, tolerance time.Duration) bool {
+	var ts, sig string
+	for _, part := range strings.Split(header, ",") {
+		key, value, _ := strings.Cut(part, "=")
+		switch key {
+		case "t":
+			ts = value
+		case "v1":
+			sig = value
+		}
+	}
+
+	unix, err := strconv.ParseInt(ts, 10, 64)
+	if err != nil || sig == "" {
+		return false
+	}
+	if age := time.Since(time.Unix(unix, 0)); tolerance > 0 && (age > tolerance || age < -tolerance) {
+		return false
+	}
+	return hmac.Equal([]byte(sig), []byte(computeSignature(secret, ts, body)))
+}
+
+func computeSignature(secret, ts string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(ts))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,38 @@
+package webhooks
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSignVerify(t *testing.T) {
+	const secret = "whsec_test"
+	body := []byte(`{"event":"application.created","data":{"id":"42"}}`)
+	now := time.Now()
+
+	tests := []struct {
+		name      string
+		secret    string
+		header    string
+		body      []byte
+		tolerance time.Duration
+		valid     bool
+	}{
+		{"valid", secret, Sign(secret, now, body), body, 5 * time.Minute, true},
+		{"valid without tolerance", secret, Sign(secret, now.Add(-time.Hour), body), body, 0, true},
+		{"tampered body", secret, Sign(secret, now, body), []byte(`{"event":"application.created","data":{"id":"43"}}`), 5 * time.Minute, false},
+		{"wrong secret", "whsec_other", Sign(secret, now, body), body, 5 * time.Minute, false},
+		{"too old", secret, Sign(secret, now.Add(-6*time.Minute), body), body, 5 * time.Minute, false},
+		{"too far ahead", secret, Sign(secret, now.Add(6*time.Minute), body), body, 5 * time.Minute, false},
+		{"tampered timestamp", secret, strings.Replace(Sign(secret, now, body), "t=", "t=1", 1), body, 0, false},
+		{"missing signature", secret, "t=" + Sign(secret, now, body)[2:12], body, 5 * time.Minute, false},
+		{"empty header", secret, "", body, 5 * time.Minute, false},
+	}
+
+	for _, tt := range tests {
+		if got := Verify(tt.secret, tt.header, tt.body, tt.tolerance); got != tt.valid {
+			t.Errorf("%s: Verify(%q) = %v, want %v", tt.name, tt.header, got, tt.valid)
+		}
+	}
+}
@@ -0,0 +1,35 @@
+-- Recruiter notes, ratings and tags on applications.
+-- All rows are scoped to the company that owns the job listing.
+
+CREATE TABLE IF NOT EXISTS application_notes (
+    id             UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    application_id UUID NOT NULL REFERENCES applications(application_id) ON DELETE CASCADE,
+    company_id     UUID NOT NULL REFERENCES companies(id) ON DELETE CASCADE,
+    author_id      UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    body           TEXT NOT NULL,
+    created_at     TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at     TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_application_notes_application ON application_notes (application_id);
+
+CREATE TABLE IF NOT EXISTS application_ratings (
+    application_id UUID NOT NULL REFERENCES applications(application_id) ON DELETE CASCADE,
+    company_id     UUID NOT NULL REFERENCES companies(id) ON DELETE CASCADE,
+    reviewer_id    UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    rating         SMALLINT NOT NULL CHECK (rating BETWEEN 1 AND 5),
+    created_at     TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at     TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (application_id, reviewer_id)
+);
+
+CREATE TABLE IF NOT EXISTS application_tags (
+    application_id UUID NOT NULL REFERENCES applications(application_id) ON DELETE CASCADE,
+    company_id     UUID NOT NULL REFERENCES companies(id) ON DELETE CASCADE,
+    tag            TEXT NOT NULL,
+    created_by     UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at     TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (application_id, tag)
+);
+
+CREATE INDEX IF NOT EXISTS idx_application_tags_company_tag ON application_tags (company_id, tag);
@@ -0,0 +1,30 @@
+-- Multiple recruiter seats per company.
+-- companies.user_id is kept as the creating user; access is decided by company_members.
+
+CREATE TABLE IF NOT EXISTS company_members (
+    company_id UUID NOT NULL REFERENCES companies(id) ON DELETE CASCADE,
+    user_id    UUID NOT NULL UNIQUE REFERENCES users(id) ON DELETE CASCADE,
+    role       TEXT NOT NULL CHECK (role IN ('owner', 'admin', 'recruiter', 'viewer')),
+    invited_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (company_id, user_id)
+);
+
+-- Every existing company owner becomes the first member of their company.
+INSERT INTO company_members (company_id, user_id, role)
+SELECT id, user_id, 'owner' FROM companies
+ON CONFLICT DO NOTHING;
+
+CREATE TABLE IF NOT EXISTS company_invitations (
+    id          UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    company_id  UUID NOT NULL REFERENCES companies(id) ON DELETE CASCADE,
+    email       TEXT NOT NULL,
+    role        TEXT NOT NULL CHECK (role IN ('admin', 'recruiter', 'viewer')),
+    token_hash  TEXT NOT NULL UNIQUE,
+    invited_by  UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at  TIMESTAMP NOT NULL DEFAULT NOW(),
+    expires_at  TIMESTAMP NOT NULL,
+    accepted_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_company_invitations_company ON company_invitations (company_id);
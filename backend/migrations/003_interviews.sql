@@ -0,0 +1,57 @@
+-- Application status changes and interview scheduling.
+-- applications.status moves through: pending, reviewing, interview, offered, accepted, rejected.
+
+CREATE TABLE IF NOT EXISTS application_status_history (
+    id             UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    application_id UUID NOT NULL REFERENCES applications(application_id) ON DELETE CASCADE,
+    old_status     TEXT NOT NULL,
+    new_status     TEXT NOT NULL,
+    changed_by     UUID REFERENCES users(id) ON DELETE SET NULL,
+    changed_at     TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_status_history_application ON application_status_history (application_id, changed_at);
+
+CREATE TABLE IF NOT EXISTS interviews (
+    id               UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    application_id   UUID NOT NULL REFERENCES applications(application_id) ON DELETE CASCADE,
+    company_id       UUID NOT NULL REFERENCES companies(id) ON DELETE CASCADE,
+    title            TEXT NOT NULL,
+    location         TEXT NOT NULL DEFAULT '',
+    video_link       TEXT NOT NULL DEFAULT '',
+    duration_minutes INT NOT NULL CHECK (duration_minutes > 0),
+    status           TEXT NOT NULL DEFAULT 'proposed' CHECK (status IN ('proposed', 'scheduled', 'cancelled', 'completed')),
+    starts_at        TIMESTAMPTZ,
+    ends_at          TIMESTAMPTZ,
+    sequence         INT NOT NULL DEFAULT 0,
+    created_by       UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at       TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at       TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_interviews_application ON interviews (application_id);
+CREATE INDEX IF NOT EXISTS idx_interviews_company ON interviews (company_id);
+
+-- Times offered to the candidate; one of them becomes interviews.starts_at.
+CREATE TABLE IF NOT EXISTS interview_slots (
+    id           UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    interview_id UUID NOT NULL REFERENCES interviews(id) ON DELETE CASCADE,
+    starts_at    TIMESTAMPTZ NOT NULL,
+    ends_at      TIMESTAMPTZ NOT NULL,
+    UNIQUE (interview_id, starts_at)
+);
+
+CREATE TABLE IF NOT EXISTS interview_interviewers (
+    interview_id UUID NOT NULL REFERENCES interviews(id) ON DELETE CASCADE,
+    user_id      UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    PRIMARY KEY (interview_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_interview_interviewers_user ON interview_interviewers (user_id);
+
+-- Secret tokens for subscribing to a personal interview calendar feed.
+CREATE TABLE IF NOT EXISTS calendar_feed_tokens (
+    user_id    UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    token_hash TEXT NOT NULL UNIQUE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
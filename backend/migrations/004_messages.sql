@@ -0,0 +1,43 @@
+-- Conversations between a company and an applicant, attached to an application.
+
+CREATE TABLE IF NOT EXISTS message_threads (
+    id              UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    application_id  UUID NOT NULL REFERENCES applications(application_id) ON DELETE CASCADE,
+    subject         TEXT NOT NULL,
+    created_by      UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at      TIMESTAMP NOT NULL DEFAULT NOW(),
+    last_message_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_message_threads_application ON message_threads (application_id);
+
+CREATE TABLE IF NOT EXISTS messages (
+    id          UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    thread_id   UUID NOT NULL REFERENCES message_threads(id) ON DELETE CASCADE,
+    sender_id   UUID REFERENCES users(id) ON DELETE SET NULL,
+    sender_role TEXT NOT NULL CHECK (sender_role IN ('candidate', 'company')),
+    body        TEXT NOT NULL,
+    created_at  TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_messages_thread ON messages (thread_id, created_at);
+
+CREATE TABLE IF NOT EXISTS message_attachments (
+    id           UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    message_id   UUID NOT NULL REFERENCES messages(id) ON DELETE CASCADE,
+    file_name    TEXT NOT NULL,
+    file_url     TEXT NOT NULL,
+    content_type TEXT NOT NULL DEFAULT '',
+    size_bytes   BIGINT NOT NULL DEFAULT 0,
+    created_at   TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_message_attachments_message ON message_attachments (message_id);
+
+-- Per user read marker used for unread counts.
+CREATE TABLE IF NOT EXISTS message_reads (
+    thread_id    UUID NOT NULL REFERENCES message_threads(id) ON DELETE CASCADE,
+    user_id      UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    last_read_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (thread_id, user_id)
+);
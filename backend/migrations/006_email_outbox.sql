@@ -0,0 +1,21 @@
+-- Transactional email outbox. Rows are written in the same transaction as the
+-- change that triggers them and delivered by the background sender.
+
+CREATE TABLE IF NOT EXISTS email_outbox (
+    id              UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id         UUID REFERENCES users(id) ON DELETE CASCADE,
+    to_email        TEXT NOT NULL,
+    template        TEXT NOT NULL,
+    data            JSONB NOT NULL DEFAULT '{}'::jsonb,
+    status          TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'sent', 'failed', 'skipped')),
+    attempts        INT NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    last_error      TEXT,
+    created_at      TIMESTAMP NOT NULL DEFAULT NOW(),
+    sent_at         TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_email_outbox_due ON email_outbox (next_attempt_at) WHERE status = 'pending';
+
+-- Email opt-out per notification type, next to the in-app setting.
+ALTER TABLE notification_preferences ADD COLUMN IF NOT EXISTS email BOOLEAN NOT NULL DEFAULT TRUE;
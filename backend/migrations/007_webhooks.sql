@@ -0,0 +1,50 @@
+-- Listings can be closed without being deleted.
+ALTER TABLE job_listings ADD COLUMN IF NOT EXISTS closed_at TIMESTAMP;
+
+-- Outbound webhooks for company ATS integrations.
+
+CREATE TABLE IF NOT EXISTS webhook_endpoints (
+    id          UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    company_id  UUID NOT NULL REFERENCES companies(id) ON DELETE CASCADE,
+    url         TEXT NOT NULL,
+    secret      TEXT NOT NULL,
+    event_types TEXT[] NOT NULL,
+    active      BOOLEAN NOT NULL DEFAULT TRUE,
+    created_by  UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at  TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at  TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_endpoints_company ON webhook_endpoints (company_id);
+
+-- One row per event per endpoint, written in the same transaction as the event.
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id               UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    endpoint_id      UUID NOT NULL REFERENCES webhook_endpoints(id) ON DELETE CASCADE,
+    event_id         UUID NOT NULL,
+    event_type       TEXT NOT NULL,
+    payload          JSONB NOT NULL,
+    status           TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'succeeded', 'failed')),
+    attempts         INT NOT NULL DEFAULT 0,
+    next_attempt_at  TIMESTAMP NOT NULL DEFAULT NOW(),
+    last_status_code INT,
+    last_error       TEXT,
+    created_at       TIMESTAMP NOT NULL DEFAULT NOW(),
+    delivered_at     TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_due ON webhook_deliveries (next_attempt_at) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_endpoint ON webhook_deliveries (endpoint_id, created_at DESC);
+
+CREATE TABLE IF NOT EXISTS webhook_delivery_attempts (
+    id            UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    delivery_id   UUID NOT NULL REFERENCES webhook_deliveries(id) ON DELETE CASCADE,
+    attempt       INT NOT NULL,
+    status_code   INT,
+    error         TEXT,
+    response_body TEXT,
+    duration_ms   INT NOT NULL,
+    attempted_at  TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_attempts_delivery ON webhook_delivery_attempts (delivery_id);
@@ -0,0 +1,10 @@
+-- Match score of each application against its listing, from the scoring service or the built-in fallback.
+CREATE TABLE IF NOT EXISTS application_scores (
+    application_id UUID PRIMARY KEY REFERENCES applications(application_id) ON DELETE CASCADE,
+    score          DOUBLE PRECISION NOT NULL CHECK (score >= 0 AND score <= 100),
+    explanation    TEXT NOT NULL DEFAULT '',
+    source         TEXT NOT NULL CHECK (source IN ('ai', 'fallback')),
+    scored_at      TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_application_scores_score ON application_scores (score DESC);
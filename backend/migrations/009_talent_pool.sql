@@ -0,0 +1,19 @@
+-- Candidates opt in to being found by companies they have not applied to.
+ALTER TABLE candidates ADD COLUMN IF NOT EXISTS open_to_opportunities BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE INDEX IF NOT EXISTS idx_candidates_open_to_opportunities ON candidates (created_at DESC) WHERE open_to_opportunities;
+
+-- A company inviting a talent pool candidate to apply to one of its listings.
+-- Inviting reveals the candidate's contact details to the company.
+CREATE TABLE IF NOT EXISTS talent_invitations (
+    id           UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    company_id   UUID NOT NULL REFERENCES companies(id) ON DELETE CASCADE,
+    candidate_id UUID NOT NULL REFERENCES candidates(id) ON DELETE CASCADE,
+    job_id       UUID NOT NULL REFERENCES job_listings(id) ON DELETE CASCADE,
+    message      TEXT NOT NULL DEFAULT '',
+    invited_by   UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at   TIMESTAMP NOT NULL DEFAULT NOW(),
+    UNIQUE (candidate_id, job_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_talent_invitations_company ON talent_invitations (company_id, candidate_id);
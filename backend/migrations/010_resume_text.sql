@@ -0,0 +1,6 @@
+-- Plain text extracted from each candidate's resume, indexed for full text search.
+ALTER TABLE candidates ADD COLUMN IF NOT EXISTS resume_text TEXT;
+ALTER TABLE candidates ADD COLUMN IF NOT EXISTS resume_search TSVECTOR
+    GENERATED ALWAYS AS (to_tsvector('english', COALESCE(resume_text, ''))) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_candidates_resume_search ON candidates USING GIN (resume_search);
@@ -0,0 +1,14 @@
+-- Uploads the malware scanner flagged, kept aside for review instead of being stored with the user's files.
+CREATE TABLE IF NOT EXISTS quarantined_uploads (
+    id         UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id    UUID REFERENCES users(id) ON DELETE SET NULL,
+    purpose    TEXT NOT NULL,
+    file_name  TEXT NOT NULL,
+    file_url   TEXT,
+    sha256     TEXT NOT NULL,
+    size_bytes BIGINT NOT NULL,
+    signature  TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_quarantined_uploads_created ON quarantined_uploads (created_at DESC);
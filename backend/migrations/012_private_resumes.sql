@@ -0,0 +1,22 @@
+-- Resumes are private objects: keep their storage key and hand out short-lived links on request.
+ALTER TABLE candidates RENAME COLUMN resume_url TO resume_key;
+
+UPDATE candidates
+SET resume_key = substring(resume_key FROM '(resumes/[^/?#]+)')
+WHERE resume_key ~ '^https?://' AND resume_key ~ 'resumes/';
+
+ALTER TABLE quarantined_uploads RENAME COLUMN file_url TO file_key;
+
+-- Who was handed a resume link, and why they were allowed to see it.
+CREATE TABLE IF NOT EXISTS resume_access_log (
+    id           UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    candidate_id UUID NOT NULL REFERENCES candidates(id) ON DELETE CASCADE,
+    user_id      UUID REFERENCES users(id) ON DELETE SET NULL,
+    company_id   UUID REFERENCES companies(id) ON DELETE SET NULL,
+    reason       TEXT NOT NULL CHECK (reason IN ('owner', 'applicant', 'scoring')),
+    ip_address   TEXT,
+    user_agent   TEXT,
+    created_at   TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_resume_access_log_candidate ON resume_access_log (candidate_id, created_at DESC);
@@ -0,0 +1,32 @@
+-- Personal data exports, built in the background and kept for download for a limited time.
+CREATE TABLE IF NOT EXISTS data_exports (
+    id              UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id         UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    status          TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'ready', 'failed', 'expired')),
+    object_key      TEXT,
+    size_bytes      BIGINT,
+    attempts        INT NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    last_error      TEXT,
+    created_at      TIMESTAMP NOT NULL DEFAULT NOW(),
+    completed_at    TIMESTAMP,
+    expires_at      TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_data_exports_user ON data_exports (user_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_data_exports_due ON data_exports (next_attempt_at) WHERE status = 'pending';
+
+-- Account erasure requests. Rows outlive the user so completed erasures stay on record.
+CREATE TABLE IF NOT EXISTS account_deletions (
+    user_id      UUID PRIMARY KEY,
+    status       TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'cancelled', 'completed')),
+    requested_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    purge_after  TIMESTAMP NOT NULL,
+    completed_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_account_deletions_due ON account_deletions (purge_after) WHERE status = 'pending';
+
+-- Profiles of erased users stay behind anonymized, so applications keep their history.
+ALTER TABLE candidates ALTER COLUMN user_id DROP NOT NULL;
+ALTER TABLE companies ALTER COLUMN user_id DROP NOT NULL;
@@ -0,0 +1,32 @@
+-- Data retention: scheduled runs of the retention rules and an append-only trail of what they removed.
+ALTER TABLE users ADD COLUMN IF NOT EXISTS last_login_at TIMESTAMP;
+
+CREATE TABLE IF NOT EXISTS retention_runs (
+    id          UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    dry_run     BOOLEAN NOT NULL,
+    status      TEXT NOT NULL DEFAULT 'running' CHECK (status IN ('running', 'completed', 'failed')),
+    rules       JSONB NOT NULL DEFAULT '{}'::jsonb,
+    summary     JSONB NOT NULL DEFAULT '{}'::jsonb,
+    error       TEXT,
+    started_at  TIMESTAMP NOT NULL DEFAULT NOW(),
+    finished_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_retention_runs_started ON retention_runs (started_at DESC);
+
+-- No foreign keys on the subject: the trail must outlive what it describes.
+CREATE TABLE IF NOT EXISTS retention_actions (
+    id               UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    run_id           UUID NOT NULL REFERENCES retention_runs(id) ON DELETE CASCADE,
+    rule             TEXT NOT NULL,
+    action           TEXT NOT NULL,
+    candidate_id     UUID NOT NULL,
+    user_id          UUID,
+    last_activity_at TIMESTAMP,
+    outcome          TEXT NOT NULL CHECK (outcome IN ('planned', 'done', 'failed')),
+    detail           TEXT NOT NULL DEFAULT '',
+    created_at       TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_retention_actions_run ON retention_actions (run_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_retention_actions_candidate ON retention_actions (candidate_id);
@@ -0,0 +1,40 @@
+-- Append-only record of security and hiring relevant actions.
+-- Actors and targets are not foreign keys: events must outlive what they describe.
+CREATE TABLE IF NOT EXISTS audit_events (
+    id          UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    occurred_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    actor_id    UUID,
+    actor_role  TEXT NOT NULL DEFAULT '',
+    action      TEXT NOT NULL,
+    target_type TEXT NOT NULL DEFAULT '',
+    target_id   TEXT NOT NULL DEFAULT '',
+    ip_address  TEXT NOT NULL DEFAULT '',
+    user_agent  TEXT NOT NULL DEFAULT '',
+    method      TEXT NOT NULL DEFAULT '',
+    route       TEXT NOT NULL DEFAULT '',
+    status_code INT,
+    before      JSONB,
+    after       JSONB,
+    metadata    JSONB NOT NULL DEFAULT '{}'::jsonb
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_events_occurred ON audit_events (occurred_at DESC);
+CREATE INDEX IF NOT EXISTS idx_audit_events_actor ON audit_events (actor_id, occurred_at DESC);
+CREATE INDEX IF NOT EXISTS idx_audit_events_target ON audit_events (target_type, target_id, occurred_at DESC);
+CREATE INDEX IF NOT EXISTS idx_audit_events_action ON audit_events (action, occurred_at DESC);
+
+CREATE OR REPLACE FUNCTION audit_events_append_only() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'audit_events is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS audit_events_no_update ON audit_events;
+CREATE TRIGGER audit_events_no_update
+    BEFORE UPDATE OR DELETE ON audit_events
+    FOR EACH ROW EXECUTE FUNCTION audit_events_append_only();
+
+DROP TRIGGER IF EXISTS audit_events_no_truncate ON audit_events;
+CREATE TRIGGER audit_events_no_truncate
+    BEFORE TRUNCATE ON audit_events
+    FOR EACH STATEMENT EXECUTE FUNCTION audit_events_append_only();
@@ -0,0 +1,30 @@
+-- Saved job searches that notify candidates about new matching listings.
+
+CREATE TABLE IF NOT EXISTS job_alerts (
+    id               UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    candidate_id     UUID NOT NULL REFERENCES candidates(id) ON DELETE CASCADE,
+    name             TEXT NOT NULL,
+    query            TEXT NOT NULL DEFAULT '',
+    work_type        TEXT NOT NULL DEFAULT '',
+    job_type         TEXT NOT NULL DEFAULT '',
+    experience_level TEXT NOT NULL DEFAULT '',
+    salary_range     TEXT NOT NULL DEFAULT '',
+    required_skills  TEXT[] NOT NULL DEFAULT '{}',
+    frequency        TEXT NOT NULL CHECK (frequency IN ('instant', 'daily', 'weekly')),
+    active           BOOLEAN NOT NULL DEFAULT TRUE,
+    last_sent_at     TIMESTAMP,
+    next_run_at      TIMESTAMP NOT NULL DEFAULT NOW(),
+    created_at       TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at       TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_job_alerts_candidate ON job_alerts (candidate_id);
+CREATE INDEX IF NOT EXISTS idx_job_alerts_due ON job_alerts (next_run_at) WHERE active;
+
+-- Every listing an alert has told its candidate about, so no listing is sent twice.
+CREATE TABLE IF NOT EXISTS job_alert_deliveries (
+    alert_id UUID NOT NULL REFERENCES job_alerts(id) ON DELETE CASCADE,
+    job_id   UUID NOT NULL REFERENCES job_listings(id) ON DELETE CASCADE,
+    sent_at  TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (alert_id, job_id)
+);
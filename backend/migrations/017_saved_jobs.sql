@@ -0,0 +1,17 @@
+-- Optional application deadline; open listings are closed once it passes.
+ALTER TABLE job_listings ADD COLUMN IF NOT EXISTS closes_at TIMESTAMP;
+
+CREATE INDEX IF NOT EXISTS idx_job_listings_closes_at ON job_listings (closes_at) WHERE closed_at IS NULL;
+
+-- Listings a candidate bookmarked, with a private note.
+CREATE TABLE IF NOT EXISTS saved_jobs (
+    candidate_id        UUID NOT NULL REFERENCES candidates(id) ON DELETE CASCADE,
+    job_id              UUID NOT NULL REFERENCES job_listings(id) ON DELETE CASCADE,
+    note                TEXT NOT NULL DEFAULT '',
+    closing_reminded_at TIMESTAMP,
+    created_at          TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at          TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (candidate_id, job_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_saved_jobs_job ON saved_jobs (job_id);
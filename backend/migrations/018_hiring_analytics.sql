@@ -0,0 +1,18 @@
+-- Where an application came from, as reported by the client or inferred from a talent invitation.
+-- Applications from before tracking count as direct.
+ALTER TABLE applications ADD COLUMN IF NOT EXISTS source TEXT NOT NULL DEFAULT 'direct';
+
+ALTER TABLE applications DROP CONSTRAINT IF EXISTS applications_source_check;
+ALTER TABLE applications ADD CONSTRAINT applications_source_check
+    CHECK (source IN ('direct', 'search', 'recommendation', 'alert', 'saved', 'invitation'));
+
+-- One row per time a candidate opened a listing.
+CREATE TABLE IF NOT EXISTS listing_views (
+    id        UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    job_id    UUID NOT NULL REFERENCES job_listings(id) ON DELETE CASCADE,
+    viewer_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    viewed_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_listing_views_job ON listing_views (job_id, viewed_at);
+CREATE INDEX IF NOT EXISTS idx_applications_job_applied ON applications (job_id, applied_at);
@@ -0,0 +1,12 @@
+-- Listing views now also count impressions in search results, de-duplicated per viewer
+-- and listing within a time bucket. Rows from before bucketing keep a NULL bucket.
+ALTER TABLE listing_views ADD COLUMN IF NOT EXISTS kind TEXT NOT NULL DEFAULT 'detail';
+ALTER TABLE listing_views ADD COLUMN IF NOT EXISTS bucket TIMESTAMP;
+
+ALTER TABLE listing_views DROP CONSTRAINT IF EXISTS listing_views_kind_check;
+ALTER TABLE listing_views ADD CONSTRAINT listing_views_kind_check CHECK (kind IN ('impression', 'detail'));
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_listing_views_dedup ON listing_views (job_id, viewer_id, kind, bucket);
+
+DROP INDEX IF EXISTS idx_listing_views_job;
+CREATE INDEX IF NOT EXISTS idx_listing_views_job ON listing_views (job_id, kind, viewed_at);
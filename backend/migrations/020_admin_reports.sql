@@ -0,0 +1,65 @@
+-- Platform reports for admins. Each view has a unique index so it can be refreshed
+-- CONCURRENTLY without blocking readers; the reports scheduler refreshes them.
+
+CREATE MATERIALIZED VIEW IF NOT EXISTS report_signups_daily AS
+SELECT date_trunc('day', created_at)::date AS day, role::text AS role, COUNT(*)::int AS signups
+FROM users
+GROUP BY 1, 2;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_report_signups_daily ON report_signups_daily (day, role);
+
+CREATE MATERIALIZED VIEW IF NOT EXISTS report_onboarding AS
+SELECT role::text AS role,
+       COUNT(*)::int AS users,
+       COUNT(*) FILTER (WHERE onboarding_status::text = 'NOT_STARTED')::int AS not_started,
+       COUNT(*) FILTER (WHERE onboarding_status::text = 'IN_PROGRESS')::int AS in_progress,
+       COUNT(*) FILTER (WHERE onboarding_status::text = 'COMPLETED')::int AS completed,
+       (COUNT(*) FILTER (WHERE onboarding_status::text = 'COMPLETED'))::float8 / NULLIF(COUNT(*), 0) AS completion_rate
+FROM users
+GROUP BY 1;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_report_onboarding ON report_onboarding (role);
+
+CREATE MATERIALIZED VIEW IF NOT EXISTS report_listings_daily AS
+SELECT day, SUM(created)::int AS created, SUM(closed)::int AS closed
+FROM (
+    SELECT date_trunc('day', created_at)::date AS day, 1 AS created, 0 AS closed FROM job_listings
+    UNION ALL
+    SELECT date_trunc('day', closed_at)::date, 0, 1 FROM job_listings WHERE closed_at IS NOT NULL
+) events
+GROUP BY day;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_report_listings_daily ON report_listings_daily (day);
+
+CREATE MATERIALIZED VIEW IF NOT EXISTS report_applications_per_listing AS
+SELECT j.id AS listing_id, j.title, co.company_name, j.created_at::date AS day, j.closed_at,
+       COUNT(a.application_id)::int AS applications
+FROM job_listings j
+JOIN companies co ON j.company_id = co.id
+LEFT JOIN applications a ON a.job_id = j.id
+GROUP BY j.id, j.title, co.company_name, j.created_at, j.closed_at;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_report_applications_per_listing ON report_applications_per_listing (listing_id);
+
+-- Open listings asking for each skill against candidates listing it, case-insensitively
+CREATE MATERIALIZED VIEW IF NOT EXISTS report_skills AS
+SELECT skill, COUNT(DISTINCT listing_id)::int AS demand, COUNT(DISTINCT candidate_id)::int AS supply
+FROM (
+    SELECT lower(trim(s)) AS skill, j.id AS listing_id, NULL::uuid AS candidate_id
+    FROM job_listings j, unnest(j.required_skills) AS s
+    WHERE j.closed_at IS NULL
+    UNION ALL
+    SELECT lower(trim(s)), NULL, c.id
+    FROM candidates c, unnest(c.skills) AS s
+    WHERE c.user_id IS NOT NULL
+) mentions
+WHERE skill <> ''
+GROUP BY skill;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_report_skills ON report_skills (skill);
+
+CREATE TABLE IF NOT EXISTS report_refreshes (
+    view_name    TEXT PRIMARY KEY,
+    refreshed_at TIMESTAMP NOT NULL,
+    duration_ms  INT NOT NULL
+);
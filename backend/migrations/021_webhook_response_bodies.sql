@@ -0,0 +1,3 @@
+-- Endpoint responses are no longer kept: they came from hosts the company chose and were shown
+-- back to it, which turned webhooks into a way to read internal services.
+ALTER TABLE webhook_delivery_attempts DROP COLUMN IF EXISTS response_body;
@@ -0,0 +1,18 @@
+-- Attachments keep only their storage key; download links are presigned for each reader.
+ALTER TABLE message_attachments ADD COLUMN IF NOT EXISTS file_key TEXT;
+
+DO $$
+BEGIN
+    IF EXISTS (
+        SELECT 1 FROM information_schema.columns
+        WHERE table_name = 'message_attachments' AND column_name = 'file_url'
+    ) THEN
+        UPDATE message_attachments
+        SET file_key = COALESCE(substring(file_url from '(messages/[^/?#]+)'), '')
+        WHERE file_key IS NULL;
+
+        ALTER TABLE message_attachments DROP COLUMN file_url;
+    END IF;
+END $$;
+
+ALTER TABLE message_attachments ALTER COLUMN file_key SET NOT NULL;
@@ -0,0 +1,7 @@
+-- Erasures are claimed before any file is removed; a claimed erasure can no longer be cancelled.
+ALTER TABLE account_deletions DROP CONSTRAINT IF EXISTS account_deletions_status_check;
+ALTER TABLE account_deletions ADD CONSTRAINT account_deletions_status_check
+    CHECK (status IN ('pending', 'erasing', 'cancelled', 'completed'));
+
+DROP INDEX IF EXISTS idx_account_deletions_due;
+CREATE INDEX IF NOT EXISTS idx_account_deletions_due ON account_deletions (purge_after) WHERE status IN ('pending', 'erasing');
@@ -0,0 +1,110 @@
+package ai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ErrCircuitOpen is returned without calling the service while it is considered down
+var ErrCircuitOpen = errors.New("ai service circuit is open")
+
+// Resume is what the service needs to embed a candidate
+type Resume struct {
+	UserID          uuid.UUID `json:"user_id"`
+	CandidateID     uuid.UUID `json:"candidate_id"`
+	ResumeURL       string    `json:"resume_url"`
+	Skills          []string  `json:"query_skills"`
+	ExpectedRoles   []string  `json:"expected_roles"`
+	ExperienceYears int       `json:"experience_years"`
+}
+
+// Job is the listing candidates are scored against
+type Job struct {
+	ID               uuid.UUID `json:"job_id"`
+	Title            string    `json:"title"`
+	Description      string    `json:"description"`
+	RequiredSkills   []string  `json:"required_skills"`
+	ExperienceMonths string    `json:"experience_months"`
+}
+
+// Score rates how well one candidate fits one job, from 0 to 100
+type Score struct {
+	CandidateID uuid.UUID `json:"candidate_id"`
+	Score       float64   `json:"score"`
+	Explanation string    `json:"explanation"`
+}
+
+// Client talks to the resume scoring service
+type Client interface {
+	// IngestResume embeds the candidate's resume so later scoring can use it
+	IngestResume(ctx context.Context, resume Resume) error
+	// ScoreCandidate rates a single candidate against a job
+	ScoreCandidate(ctx context.Context, candidateID uuid.UUID, job Job) (Score, error)
+	// RankApplicants scores the candidates against a job, best match first
+	RankApplicants(ctx context.Context, job Job, candidateIDs []uuid.UUID) ([]Score, error)
+}
+
+// NewFromEnv picks the client named by AI_DRIVER ("http" or "fake").
+// It defaults to "http" when AI_SERVICE_BASE_URL is set and to the in-process fake otherwise.
+func NewFromEnv() (Client, error) {
+	driver := os.Getenv("AI_DRIVER")
+	if driver == "" {
+		driver = "fake"
+		if os.Getenv("AI_SERVICE_BASE_URL") != "" {
+			driver = "http"
+		}
+	}
+
+	switch driver {
+	case "http":
+		config := DefaultConfig()
+		config.BaseURL = os.Getenv("AI_SERVICE_BASE_URL")
+		if v, ok, err := durationEnv("AI_TIMEOUT"); err != nil {
+			return nil, err
+		} else if ok {
+			config.Timeout = v
+		}
+		if v := os.Getenv("AI_MAX_RETRIES"); v != "" {
+			retries, err := strconv.Atoi(v)
+			if err != nil || retries < 0 {
+				return nil, fmt.Errorf("invalid AI_MAX_RETRIES %q", v)
+			}
+			config.MaxRetries = retries
+		}
+		if v := os.Getenv("AI_BREAKER_THRESHOLD"); v != "" {
+			threshold, err := strconv.Atoi(v)
+			if err != nil || threshold < 1 {
+				return nil, fmt.Errorf("invalid AI_BREAKER_THRESHOLD %q", v)
+			}
+			config.BreakerThreshold = threshold
+		}
+		if v, ok, err := durationEnv("AI_BREAKER_COOLDOWN"); err != nil {
+			return nil, err
+		} else if ok {
+			config.BreakerCooldown = v
+		}
+		return NewHTTPClient(config)
+	case "fake":
+		return NewFake(), nil
+	default:
+		return nil, fmt.Errorf("unknown ai driver %q", driver)
+	}
+}
+
+func durationEnv(name string) (time.Duration, bool, error) {
+	v := os.Getenv(name)
+	if v == "" {
+		return 0, false, nil
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil || d <= 0 {
+		return 0, false, fmt.Errorf("invalid %s %q", name, v)
+	}
+	return d, true, nil
+}
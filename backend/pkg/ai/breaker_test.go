@@ -0,0 +1,50 @@
+package ai
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBreaker(t *testing.T) {
+	b := newBreaker(2, 20*time.Millisecond)
+
+	b.failure()
+	if !b.allow() {
+		t.Fatal("allow after one failure = false, want true below threshold")
+	}
+	b.failure()
+	if b.allow() {
+		t.Fatal("allow at threshold = true, want the circuit open")
+	}
+
+	time.Sleep(30 * time.Millisecond)
+	if !b.allow() {
+		t.Fatal("allow after cooldown = false, want a trial call")
+	}
+	if b.allow() {
+		t.Fatal("second allow during the trial = true, want one trial at a time")
+	}
+
+	// A trial that ended without an answer frees the slot
+	b.release()
+	if !b.allow() {
+		t.Fatal("allow after release = false, want another trial")
+	}
+
+	// A failed trial reopens the circuit for another cooldown
+	b.failure()
+	if b.allow() {
+		t.Fatal("allow after a failed trial = true, want the circuit open")
+	}
+
+	time.Sleep(30 * time.Millisecond)
+	if !b.allow() {
+		t.Fatal("allow after second cooldown = false, want a trial call")
+	}
+	b.success()
+	for i := 0; i < 3; i++ {
+		if !b.allow() {
+			t.Fatalf("allow %d after a successful trial = false, want the circuit closed", i)
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package ai
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// Fake scores in process by skill overlap. It keeps every ingested resume in memory
+// and needs no running service, which makes it the default for development and tests.
+type Fake struct {
+	mu      sync.RWMutex
+	resumes map[uuid.UUID]Resume
+
+	// Err, when set, is returned by every call
+	Err error
+}
+
+func NewFake() *Fake {
+	return &Fake{resumes: make(map[uuid.UUID]Resume)}
+}
+
+func (f *Fake) IngestResume(ctx context.Context, resume Resume) error {
+	if f.Err != nil {
+		return f.Err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.resumes[resume.CandidateID] = resume
+	return nil
+}
+
+// Ingested returns the stored resume of the candidate, if any.
+func (f *Fake) Ingested(candidateID uuid.UUID) (Resume, bool) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	resume, ok := f.resumes[candidateID]
+	return resume, ok
+}
+
+func (f *Fake) ScoreCandidate(ctx context.Context, candidateID uuid.UUID, job Job) (Score, error) {
+	if f.Err != nil {
+		return Score{}, f.Err
+	}
+
+	resume, ok := f.Ingested(candidateID)
+	if !ok {
+		return Score{}, fmt.Errorf("candidate %s has not been ingested", candidateID)
+	}
+	return fakeScore(resume, job), nil
+}
+
+func (f *Fake) RankApplicants(ctx context.Context, job Job, candidateIDs []uuid.UUID) ([]Score, error) {
+	if f.Err != nil {
+		return nil, f.Err
+	}
+
+	scores := make([]Score, 0, len(candidateIDs))
+	for _, id := range candidateIDs {
+		resume, ok := f.Ingested(id)
+		if !ok {
+			// Candidates the service has not seen are left out, as the real service does
+			continue
+		}
+		scores = append(scores, fakeScore(resume, job))
+	}
+
+	sort.SliceStable(scores, func(i, j int) bool { return scores[i].Score > scores[j].Score })
+	return scores, nil
+}
+
+// fakeScore is the share of required skills the resume lists.
+func fakeScore(resume Resume, job Job) Score {
+	have := make(map[string]bool, len(resume.Skills))
+	for _, s := range resume.Skills {
+		have[strings.ToLower(strings.TrimSpace(s))] = true
+	}
+
+	matched := 0
+	for _, s := range job.RequiredSkills {
+		if have[strings.ToLower(strings.TrimSpace(s))] {
+			matched++
+		}
+	}
+
+	score := 0.0
+	if len(job.RequiredSkills) > 0 {
+		score = 100 * float64(matched) / float64(len(job.RequiredSkills))
+	}
+	return Score{
+		CandidateID: resume.CandidateID,
+		Score:       score,
+		Explanation: fmt.Sprintf("Matches %d of %d required skills", matched, len(job.RequiredSkills)),
+	}
+}
@@ -0,0 +1,47 @@
+package ai
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestFake(t *testing.T) {
+	ctx := context.Background()
+	fake := NewFake()
+	job := Job{RequiredSkills: []string{"Go", "PostgreSQL", "Docker", "Kubernetes"}}
+
+	strong, weak, unknown := uuid.New(), uuid.New(), uuid.New()
+	fake.IngestResume(ctx, Resume{CandidateID: weak, Skills: []string{"go"}})
+	fake.IngestResume(ctx, Resume{CandidateID: strong, Skills: []string{" Go ", "postgresql", "Docker"}})
+
+	score, err := fake.ScoreCandidate(ctx, strong, job)
+	if err != nil {
+		t.Fatalf("ScoreCandidate = %v", err)
+	}
+	if score.Score != 75 || score.Explanation != "Matches 3 of 4 required skills" {
+		t.Errorf("ScoreCandidate = %+v, want 75 for 3 of 4 skills", score)
+	}
+
+	if _, err := fake.ScoreCandidate(ctx, unknown, job); err == nil {
+		t.Error("ScoreCandidate of an unknown candidate succeeded, want an error")
+	}
+
+	ranked, err := fake.RankApplicants(ctx, job, []uuid.UUID{weak, unknown, strong})
+	if err != nil {
+		t.Fatalf("RankApplicants = %v", err)
+	}
+	if len(ranked) != 2 || ranked[0].CandidateID != strong || ranked[1].CandidateID != weak {
+		t.Errorf("RankApplicants = %+v, want strong then weak, unknown left out", ranked)
+	}
+	if ranked[1].Score != 25 {
+		t.Errorf("weak candidate scored %v, want 25", ranked[1].Score)
+	}
+
+	fake.Err = errors.New("down")
+	if _, err := fake.RankApplicants(ctx, job, []uuid.UUID{strong}); !errors.Is(err, fake.Err) {
+		t.Errorf("RankApplicants with Err set = %v, want Err", err)
+	}
+}
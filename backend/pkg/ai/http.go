@@ -0,0 +1,161 @@
+package ai
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Config tunes the HTTP client
+type Config struct {
+	BaseURL          string
+	Timeout          time.Duration // per request
+	MaxRetries       int           // extra attempts after the first
+	RetryBackoff     time.Duration // doubled after every retry
+	BreakerThreshold int           // consecutive failed calls that open the circuit
+	BreakerCooldown  time.Duration // how long the circuit stays open
+}
+
+func DefaultConfig() Config {
+	return Config{
+		Timeout:          10 * time.Second,
+		MaxRetries:       2,
+		RetryBackoff:     500 * time.Millisecond,
+		BreakerThreshold: 5,
+		BreakerCooldown:  30 * time.Second,
+	}
+}
+
+// HTTPClient calls the scoring microservice over JSON
+type HTTPClient struct {
+	config  Config
+	http    *http.Client
+	breaker *breaker
+}
+
+func NewHTTPClient(config Config) (*HTTPClient, error) {
+	if config.BaseURL == "" {
+		return nil, fmt.Errorf("AI_SERVICE_BASE_URL is not set")
+	}
+	config.BaseURL = strings.TrimRight(config.BaseURL, "/")
+
+	return &HTTPClient{
+		config:  config,
+		http:    &http.Client{Timeout: config.Timeout},
+		breaker: newBreaker(config.BreakerThreshold, config.BreakerCooldown),
+	}, nil
+}
+
+func (c *HTTPClient) IngestResume(ctx context.Context, resume Resume) error {
+	return c.call(ctx, "/onboarding/candidate", resume, nil)
+}
+
+func (c *HTTPClient) ScoreCandidate(ctx context.Context, candidateID uuid.UUID, job Job) (Score, error) {
+	var score Score
+	err := c.call(ctx, "/score", map[string]any{"candidate_id": candidateID, "job": job}, &score)
+	return score, err
+}
+
+func (c *HTTPClient) RankApplicants(ctx context.Context, job Job, candidateIDs []uuid.UUID) ([]Score, error) {
+	var response struct {
+		Results []Score `json:"results"`
+	}
+	err := c.call(ctx, "/rank", map[string]any{"job": job, "candidate_ids": candidateIDs}, &response)
+	return response.Results, err
+}
+
+// statusError is a non-2xx answer from the service
+type statusError struct {
+	status int
+	body   string
+}
+
+func (e *statusError) Error() string {
+	return fmt.Sprintf("ai service responded with status %d: %s", e.status, e.body)
+}
+
+// retryable reports whether trying again could give a different answer.
+func (e *statusError) retryable() bool {
+	return e.status == http.StatusTooManyRequests || e.status >= 500
+}
+
+// call posts body to path and decodes the response into out, retrying transient failures.
+// A call that still fails counts once against the circuit breaker.
+func (c *HTTPClient) call(ctx context.Context, path string, body, out any) error {
+	if !c.breaker.allow() {
+		return ErrCircuitOpen
+	}
+
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("could not encode ai request: %w", err)
+	}
+
+	for attempt := 0; ; attempt++ {
+		err = c.post(ctx, path, payload, out)
+		if err == nil {
+			c.breaker.success()
+			return nil
+		}
+
+		var statusErr *statusError
+		if errors.As(err, &statusErr) && !statusErr.retryable() {
+			// The service is up, it just refused this request
+			c.breaker.success()
+			return err
+		}
+		if attempt >= c.config.MaxRetries || ctx.Err() != nil {
+			break
+		}
+
+		backoff := time.Duration(math.Pow(2, float64(attempt))) * c.config.RetryBackoff
+		select {
+		case <-ctx.Done():
+		case <-time.After(backoff):
+		}
+	}
+
+	if ctx.Err() != nil {
+		// The caller gave up; that says nothing about the service
+		c.breaker.release()
+	} else {
+		c.breaker.failure()
+	}
+	return fmt.Errorf("ai service %s failed: %w", path, err)
+}
+
+func (c *HTTPClient) post(ctx context.Context, path string, payload []byte, out any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.config.BaseURL+path, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		snippet, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return &statusError{status: resp.StatusCode, body: string(snippet)}
+	}
+
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("could not decode ai response: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+package ai
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// flakyServer fails the first failures requests with status, then scores every candidate 80.
+func flakyServer(t *testing.T, failures int32, status int) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) <= failures {
+			http.Error(w, "unavailable", status)
+			return
+		}
+		var body struct {
+			CandidateID uuid.UUID `json:"candidate_id"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		json.NewEncoder(w).Encode(Score{CandidateID: body.CandidateID, Score: 80})
+	}))
+	t.Cleanup(server.Close)
+	return server, &calls
+}
+
+func testClient(t *testing.T, baseURL string) *HTTPClient {
+	t.Helper()
+	client, err := NewHTTPClient(Config{
+		BaseURL:          baseURL + "/",
+		Timeout:          time.Second,
+		MaxRetries:       2,
+		RetryBackoff:     time.Millisecond,
+		BreakerThreshold: 2,
+		BreakerCooldown:  50 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("NewHTTPClient: %v", err)
+	}
+	return client
+}
+
+func TestHTTPClientRetriesServerErrors(t *testing.T) {
+	server, calls := flakyServer(t, 2, http.StatusServiceUnavailable)
+	client := testClient(t, server.URL)
+
+	candidateID := uuid.New()
+	score, err := client.ScoreCandidate(context.Background(), candidateID, Job{Title: "Backend engineer"})
+	if err != nil {
+		t.Fatalf("ScoreCandidate = %v, want success on the last retry", err)
+	}
+	if score.CandidateID != candidateID || score.Score != 80 {
+		t.Errorf("ScoreCandidate = %+v", score)
+	}
+	if got := calls.Load(); got != 3 {
+		t.Errorf("service called %d times, want 3", got)
+	}
+}
+
+func TestHTTPClientDoesNotRetryRejections(t *testing.T) {
+	server, calls := flakyServer(t, 100, http.StatusBadRequest)
+	client := testClient(t, server.URL)
+
+	for i := 0; i < 3; i++ {
+		_, err := client.ScoreCandidate(context.Background(), uuid.New(), Job{})
+		var statusErr *statusError
+		if !errors.As(err, &statusErr) || statusErr.status != http.StatusBadRequest {
+			t.Fatalf("ScoreCandidate = %v, want the 400", err)
+		}
+	}
+	// A refused request says the service is up, so the circuit stays closed
+	if got := calls.Load(); got != 3 {
+		t.Errorf("service called %d times, want 3", got)
+	}
+}
+
+func TestHTTPClientBreaker(t *testing.T) {
+	// Two calls of three attempts each fail, the half-open trial succeeds
+	server, calls := flakyServer(t, 6, http.StatusInternalServerError)
+	client := testClient(t, server.URL)
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.ScoreCandidate(ctx, uuid.New(), Job{}); err == nil || errors.Is(err, ErrCircuitOpen) {
+			t.Fatalf("call %d = %v, want the service error", i, err)
+		}
+	}
+
+	if _, err := client.ScoreCandidate(ctx, uuid.New(), Job{}); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("call after threshold = %v, want ErrCircuitOpen", err)
+	}
+	if got := calls.Load(); got != 6 {
+		t.Errorf("service called %d times while open, want 6", got)
+	}
+
+	time.Sleep(60 * time.Millisecond)
+	if _, err := client.ScoreCandidate(ctx, uuid.New(), Job{}); err != nil {
+		t.Fatalf("trial call = %v, want success", err)
+	}
+	if _, err := client.ScoreCandidate(ctx, uuid.New(), Job{}); err != nil {
+		t.Errorf("call after a successful trial = %v, want the circuit closed", err)
+	}
+}
+
+func TestHTTPClientCancelledCallLeavesBreaker(t *testing.T) {
+	server, _ := flakyServer(t, 100, http.StatusInternalServerError)
+	client := testClient(t, server.URL)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	for i := 0; i < 3; i++ {
+		if _, err := client.ScoreCandidate(ctx, uuid.New(), Job{}); errors.Is(err, ErrCircuitOpen) {
+			t.Fatalf("call %d = ErrCircuitOpen, want cancelled calls not to count", i)
+		}
+	}
+}
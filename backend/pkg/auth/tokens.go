@@ -0,0 +1,36 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+)
+
+// GenerateRandomToken returns a hex encoded random token of n bytes
+func GenerateRandomToken(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// HashToken hashes an opaque token so only the digest has to be stored
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// SignValue returns an HMAC of value keyed with the JWT secret, for links that must not be forged
+func SignValue(value string) string {
+	mac := hmac.New(sha256.New, GetSecretKey())
+	mac.Write([]byte(value))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// VerifySignedValue checks a signature produced by SignValue
+func VerifySignedValue(value, signature string) bool {
+	return hmac.Equal([]byte(SignValue(value)), []byte(signature))
+}
@@ -0,0 +1,159 @@
+package bucket
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Local stores objects on disk below a root directory, for development without a bucket.
+// Objects are served by the API under baseURL, see Verify.
+type Local struct {
+	root    string
+	baseURL string
+	secret  []byte
+}
+
+// NewLocal stores objects below root. Without a secret, presigned URLs are signed with
+// a random key and stop working when the process restarts.
+func NewLocal(root, baseURL string, secret []byte) *Local {
+	if len(secret) == 0 {
+		secret = make([]byte, 32)
+		rand.Read(secret)
+	}
+	return &Local{root: root, baseURL: strings.TrimRight(baseURL, "/"), secret: secret}
+}
+
+func (l *Local) path(key string) (string, error) {
+	if err := validKey(key); err != nil {
+		return "", err
+	}
+	return filepath.Join(l.root, filepath.FromSlash(key)), nil
+}
+
+func (l *Local) Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) (string, error) {
+	name, err := l.path(key)
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(filepath.Dir(name), 0o750); err != nil {
+		return "", fmt.Errorf("could not put %s: %w", key, err)
+	}
+
+	// Write beside the target and rename, so readers never see half a file
+	tmp, err := os.CreateTemp(filepath.Dir(name), ".upload-*")
+	if err != nil {
+		return "", fmt.Errorf("could not put %s: %w", key, err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, r); err != nil {
+		tmp.Close()
+		return "", fmt.Errorf("could not put %s: %w", key, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return "", fmt.Errorf("could not put %s: %w", key, err)
+	}
+	if err := os.Rename(tmp.Name(), name); err != nil {
+		return "", fmt.Errorf("could not put %s: %w", key, err)
+	}
+
+	return l.baseURL + "/" + key, nil
+}
+
+func (l *Local) Get(ctx context.Context, key string) (io.ReadCloser, Object, error) {
+	name, err := l.path(key)
+	if err != nil {
+		return nil, Object{}, err
+	}
+
+	file, err := os.Open(name)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, Object{}, ErrNotFound
+	} else if err != nil {
+		return nil, Object{}, fmt.Errorf("could not access %s: %w", key, err)
+	}
+
+	stat, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, Object{}, fmt.Errorf("could not access %s: %w", key, err)
+	}
+	return file, l.object(key, stat), nil
+}
+
+func (l *Local) Delete(ctx context.Context, key string) error {
+	name, err := l.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(name); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("could not delete %s: %w", key, err)
+	}
+	return nil
+}
+
+func (l *Local) Presign(ctx context.Context, key string, ttl time.Duration) (string, error) {
+	if err := validKey(key); err != nil {
+		return "", err
+	}
+
+	expires := strconv.FormatInt(time.Now().Add(ttl).Unix(), 10)
+	query := url.Values{
+		"expires":   {expires},
+		"signature": {l.sign(key, expires)},
+	}
+	return l.baseURL + "/" + key + "?" + query.Encode(), nil
+}
+
+func (l *Local) Stat(ctx context.Context, key string) (Object, error) {
+	name, err := l.path(key)
+	if err != nil {
+		return Object{}, err
+	}
+
+	stat, err := os.Stat(name)
+	if errors.Is(err, os.ErrNotExist) {
+		return Object{}, ErrNotFound
+	} else if err != nil {
+		return Object{}, fmt.Errorf("could not access %s: %w", key, err)
+	}
+	return l.object(key, stat), nil
+}
+
+// Verify reports whether expires and signature came from Presign for key and are still valid.
+func (l *Local) Verify(key, expires, signature string) bool {
+	unix, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil || time.Now().Unix() > unix {
+		return false
+	}
+	return hmac.Equal([]byte(signature), []byte(l.sign(key, expires)))
+}
+
+func (l *Local) sign(key, expires string) string {
+	mac := hmac.New(sha256.New, l.secret)
+	mac.Write([]byte(key + "\n" + expires))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func (l *Local) object(key string, stat os.FileInfo) Object {
+	return Object{
+		Key:         key,
+		Size:        stat.Size(),
+		ContentType: mime.TypeByExtension(path.Ext(key)),
+		ModifiedAt:  stat.ModTime(),
+	}
+}
@@ -0,0 +1,147 @@
+package bucket
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// presigned returns the expires and signature query values of a presigned URL.
+func presigned(t *testing.T, l *Local, key string, ttl time.Duration) (string, string) {
+	t.Helper()
+	raw, err := l.Presign(context.Background(), key, ttl)
+	if err != nil {
+		t.Fatalf("Presign(%q) = %v", key, err)
+	}
+	u, err := url.Parse(raw)
+	if err != nil {
+		t.Fatalf("Presign(%q) = %q, not a URL", key, raw)
+	}
+	if want := "/files/" + key; u.Path != want {
+		t.Errorf("Presign(%q) path = %q, want %q", key, u.Path, want)
+	}
+	return u.Query().Get("expires"), u.Query().Get("signature")
+}
+
+func TestLocalPresignVerify(t *testing.T) {
+	l := NewLocal(t.TempDir(), "http://localhost:5000/files/", []byte("secret"))
+	key := "resumes/3f1c.pdf"
+	expires, signature := presigned(t, l, key, time.Minute)
+	later := strconv.FormatInt(time.Now().Add(time.Hour).Unix(), 10)
+
+	tests := []struct {
+		name      string
+		local     *Local
+		key       string
+		expires   string
+		signature string
+		valid     bool
+	}{
+		{"valid", l, key, expires, signature, true},
+		{"other key", l, "resumes/other.pdf", expires, signature, false},
+		{"tampered signature", l, key, expires, strings.Repeat("0", len(signature)), false},
+		{"truncated signature", l, key, expires, signature[:len(signature)-1], false},
+		{"empty signature", l, key, expires, "", false},
+		{"extended expiry", l, key, later, signature, false},
+		{"malformed expiry", l, key, "soon", signature, false},
+		{"other secret", NewLocal(t.TempDir(), "http://localhost:5000/files", []byte("other")), key, expires, signature, false},
+	}
+
+	for _, tt := range tests {
+		if got := tt.local.Verify(tt.key, tt.expires, tt.signature); got != tt.valid {
+			t.Errorf("%s: Verify = %v, want %v", tt.name, got, tt.valid)
+		}
+	}
+
+	expires, signature = presigned(t, l, key, -time.Second)
+	if l.Verify(key, expires, signature) {
+		t.Error("Verify of an expired link = true, want false")
+	}
+}
+
+func TestLocalRandomSecret(t *testing.T) {
+	a := NewLocal(t.TempDir(), "http://localhost:5000/files", nil)
+	b := NewLocal(t.TempDir(), "http://localhost:5000/files", nil)
+
+	expires, signature := presigned(t, a, "exports/x.zip", time.Minute)
+	if !a.Verify("exports/x.zip", expires, signature) {
+		t.Error("link does not verify with the storage that signed it")
+	}
+	if b.Verify("exports/x.zip", expires, signature) {
+		t.Error("link verifies with another storage's random secret")
+	}
+}
+
+func TestLocalPutGetDelete(t *testing.T) {
+	ctx := context.Background()
+	l := NewLocal(t.TempDir(), "http://localhost:5000/files", []byte("secret"))
+	key := NewKey("messages", ".txt")
+
+	if _, err := l.Put(ctx, key, strings.NewReader("hello"), 5, "text/plain"); err != nil {
+		t.Fatalf("Put = %v", err)
+	}
+	body, object, err := l.Get(ctx, key)
+	if err != nil {
+		t.Fatalf("Get = %v", err)
+	}
+	data, _ := io.ReadAll(body)
+	body.Close()
+	if string(data) != "hello" || object.Size != 5 || object.Key != key {
+		t.Errorf("Get = %q, %+v", data, object)
+	}
+
+	if err := l.Delete(ctx, key); err != nil {
+		t.Fatalf("Delete = %v", err)
+	}
+	if _, err := l.Stat(ctx, key); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Stat after Delete = %v, want ErrNotFound", err)
+	}
+	if err := l.Delete(ctx, key); err != nil {
+		t.Errorf("Delete of a missing object = %v, want nil", err)
+	}
+
+	for _, key := range []string{"../escape.txt", "/etc/passwd", `..\escape.txt`} {
+		if _, err := l.Put(ctx, key, strings.NewReader("x"), 1, ""); err == nil {
+			t.Errorf("Put(%q) succeeded, want the key rejected", key)
+		}
+		if _, err := l.Presign(ctx, key, time.Minute); err == nil {
+			t.Errorf("Presign(%q) succeeded, want the key rejected", key)
+		}
+	}
+}
+
+func TestValidKey(t *testing.T) {
+	tests := []struct {
+		key   string
+		valid bool
+	}{
+		{"resumes/3f1c.pdf", true},
+		{"exports/2024/archive.zip", true},
+		{"file.txt", true},
+		{"", false},
+		{"..", false},
+		{"../etc/passwd", false},
+		{"resumes/../../etc/passwd", false},
+		{"resumes/./file.pdf", false},
+		{"/etc/passwd", false},
+		{"resumes//file.pdf", false},
+		{"resumes/", false},
+		{`resumes\file.pdf`, false},
+		{`..\..\windows\win.ini`, false},
+	}
+
+	for _, tt := range tests {
+		err := validKey(tt.key)
+		if tt.valid && err != nil {
+			t.Errorf("validKey(%q) = %v, want valid", tt.key, err)
+		}
+		if !tt.valid && err == nil {
+			t.Errorf("validKey(%q) = nil, want an error", tt.key)
+		}
+	}
+}
@@ -0,0 +1,153 @@
+package bucket
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/feature/s3/manager"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+)
+
+// S3Config points S3 at a bucket. Endpoint, keys and path style are only needed for
+// S3 compatible servers such as MinIO; AWS reads credentials from the environment.
+type S3Config struct {
+	Region    string
+	Bucket    string
+	Endpoint  string
+	AccessKey string
+	SecretKey string
+	PathStyle bool
+}
+
+// S3 stores objects in an S3 or S3 compatible bucket
+type S3 struct {
+	client    *s3.Client
+	uploader  *manager.Uploader
+	presigner *s3.PresignClient
+	bucket    string
+}
+
+func NewS3(ctx context.Context, cfg S3Config) (*S3, error) {
+	if cfg.Bucket == "" {
+		return nil, errors.New("bucket name is required")
+	}
+
+	opts := []func(*config.LoadOptions) error{config.WithRegion(cfg.Region)}
+	if cfg.AccessKey != "" {
+		opts = append(opts, config.WithCredentialsProvider(
+			credentials.NewStaticCredentialsProvider(cfg.AccessKey, cfg.SecretKey, "")))
+	}
+	awsCfg, err := config.LoadDefaultConfig(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client := s3.NewFromConfig(awsCfg, func(o *s3.Options) {
+		if cfg.Endpoint != "" {
+			o.BaseEndpoint = aws.String(cfg.Endpoint)
+		}
+		o.UsePathStyle = cfg.PathStyle
+	})
+
+	return &S3{
+		client:    client,
+		uploader:  manager.NewUploader(client),
+		presigner: s3.NewPresignClient(client),
+		bucket:    cfg.Bucket,
+	}, nil
+}
+
+func (s *S3) Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) (string, error) {
+	if err := validKey(key); err != nil {
+		return "", err
+	}
+
+	input := &s3.PutObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+		Body:   r,
+	}
+	if contentType != "" {
+		input.ContentType = aws.String(contentType)
+	}
+
+	result, err := s.uploader.Upload(ctx, input)
+	if err != nil {
+		return "", fmt.Errorf("could not put %s: %w", key, err)
+	}
+	return result.Location, nil
+}
+
+func (s *S3) Get(ctx context.Context, key string) (io.ReadCloser, Object, error) {
+	out, err := s.client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return nil, Object{}, s3Error(key, err)
+	}
+
+	return out.Body, Object{
+		Key:         key,
+		Size:        aws.ToInt64(out.ContentLength),
+		ContentType: aws.ToString(out.ContentType),
+		ModifiedAt:  aws.ToTime(out.LastModified),
+	}, nil
+}
+
+func (s *S3) Delete(ctx context.Context, key string) error {
+	_, err := s.client.DeleteObject(ctx, &s3.DeleteObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return s3Error(key, err)
+	}
+	return nil
+}
+
+func (s *S3) Presign(ctx context.Context, key string, ttl time.Duration) (string, error) {
+	req, err := s.presigner.PresignGetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+		// Downloads never render inline, wherever the bucket is served from
+		ResponseContentDisposition: aws.String("attachment"),
+	}, s3.WithPresignExpires(ttl))
+	if err != nil {
+		return "", fmt.Errorf("could not presign %s: %w", key, err)
+	}
+	return req.URL, nil
+}
+
+func (s *S3) Stat(ctx context.Context, key string) (Object, error) {
+	out, err := s.client.HeadObject(ctx, &s3.HeadObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return Object{}, s3Error(key, err)
+	}
+
+	return Object{
+		Key:         key,
+		Size:        aws.ToInt64(out.ContentLength),
+		ContentType: aws.ToString(out.ContentType),
+		ModifiedAt:  aws.ToTime(out.LastModified),
+	}, nil
+}
+
+func s3Error(key string, err error) error {
+	var noSuchKey *types.NoSuchKey
+	var notFound *types.NotFound
+	if errors.As(err, &noSuchKey) || errors.As(err, &notFound) {
+		return ErrNotFound
+	}
+	return fmt.Errorf("could not access %s: %w", key, err)
+}
@@ -0,0 +1,97 @@
+package bucket
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var ErrNotFound = errors.New("object not found")
+
+// Object describes a stored object
+type Object struct {
+	Key         string
+	Size        int64
+	ContentType string
+	ModifiedAt  time.Time
+}
+
+// Storage keeps uploaded files. Keys are slash separated paths such as "resumes/<uuid>.pdf".
+type Storage interface {
+	// Put stores r under key and returns the location the object is served from.
+	Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) (string, error)
+	Get(ctx context.Context, key string) (io.ReadCloser, Object, error)
+	Delete(ctx context.Context, key string) error
+	// Presign returns a URL that downloads the object until ttl has passed.
+	Presign(ctx context.Context, key string, ttl time.Duration) (string, error)
+	Stat(ctx context.Context, key string) (Object, error)
+}
+
+// NewKey returns a fresh, unguessable key inside folder.
+func NewKey(folder, ext string) string {
+	return fmt.Sprintf("%s/%s%s", folder, uuid.NewString(), ext)
+}
+
+// NewFromEnv picks the storage named by STORAGE_DRIVER ("s3", "minio" or "local").
+// Without a driver, S3 is used when S3_BUCKET_NAME is set and the local disk otherwise.
+func NewFromEnv(ctx context.Context) (Storage, error) {
+	driver := os.Getenv("STORAGE_DRIVER")
+	if driver == "" {
+		driver = "local"
+		if os.Getenv("S3_BUCKET_NAME") != "" {
+			driver = "s3"
+		}
+	}
+
+	switch driver {
+	case "s3":
+		return NewS3(ctx, S3Config{
+			Region: os.Getenv("AWS_REGION"),
+			Bucket: os.Getenv("S3_BUCKET_NAME"),
+		})
+	case "minio":
+		region := os.Getenv("AWS_REGION")
+		if region == "" {
+			region = "us-east-1"
+		}
+		return NewS3(ctx, S3Config{
+			Region:    region,
+			Bucket:    os.Getenv("S3_BUCKET_NAME"),
+			Endpoint:  os.Getenv("MINIO_ENDPOINT"),
+			AccessKey: os.Getenv("MINIO_ACCESS_KEY"),
+			SecretKey: os.Getenv("MINIO_SECRET_KEY"),
+			PathStyle: true,
+		})
+	case "local":
+		dir := os.Getenv("LOCAL_STORAGE_DIR")
+		if dir == "" {
+			dir = "uploads"
+		}
+		baseURL := os.Getenv("LOCAL_STORAGE_URL")
+		if baseURL == "" {
+			baseURL = "http://localhost:5000/files"
+		}
+		return NewLocal(dir, baseURL, []byte(os.Getenv("LOCAL_STORAGE_SECRET"))), nil
+	default:
+		return nil, fmt.Errorf("unknown storage driver %q", driver)
+	}
+}
+
+// validKey rejects keys that could escape the storage root.
+func validKey(key string) error {
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, "\\") {
+		return fmt.Errorf("invalid object key %q", key)
+	}
+	for _, part := range strings.Split(key, "/") {
+		if part == "" || part == "." || part == ".." {
+			return fmt.Errorf("invalid object key %q", key)
+		}
+	}
+	return nil
+}
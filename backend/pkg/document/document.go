@@ -0,0 +1,114 @@
+package document
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+)
+
+// Formats Detect can recognise
+const (
+	FormatPDF     = "pdf"
+	FormatDOCX    = "docx"
+	FormatODT     = "odt"
+	FormatUnknown = ""
+)
+
+// maxExtractedSize caps how much decompressed data a document may expand to
+const maxExtractedSize = 32 << 20
+
+var (
+	ErrUnsupportedFormat = errors.New("unsupported document format")
+	ErrNoText            = errors.New("document contains no extractable text")
+)
+
+// Detect identifies a document from its content, not its name.
+func Detect(data []byte) string {
+	if bytes.HasPrefix(data, []byte("%PDF-")) {
+		return FormatPDF
+	}
+	if !bytes.HasPrefix(data, []byte("PK\x03\x04")) {
+		return FormatUnknown
+	}
+
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return FormatUnknown
+	}
+	for _, f := range archive.File {
+		switch f.Name {
+		case "word/document.xml":
+			return FormatDOCX
+		case "mimetype":
+			if content, err := readZipFile(f); err == nil && strings.TrimSpace(string(content)) == "application/vnd.oasis.opendocument.text" {
+				return FormatODT
+			}
+		}
+	}
+	return FormatUnknown
+}
+
+// ExtractText returns the plain text of a PDF, DOCX or ODT document with one line per paragraph.
+func ExtractText(data []byte) (string, error) {
+	var text string
+	var err error
+
+	switch Detect(data) {
+	case FormatPDF:
+		text, err = extractPDF(data)
+	case FormatDOCX:
+		text, err = extractZipXML(data, "word/document.xml", docxBreaks)
+	case FormatODT:
+		text, err = extractZipXML(data, "content.xml", odtBreaks)
+	default:
+		return "", ErrUnsupportedFormat
+	}
+	if err != nil {
+		return "", err
+	}
+
+	text = cleanText(text)
+	if text == "" {
+		return "", ErrNoText
+	}
+	return text, nil
+}
+
+func readZipFile(f *zip.File) ([]byte, error) {
+	rc, err := f.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	data, err := io.ReadAll(io.LimitReader(rc, maxExtractedSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxExtractedSize {
+		return nil, fmt.Errorf("%s is too large once decompressed", f.Name)
+	}
+	return data, nil
+}
+
+var (
+	spaceRun = regexp.MustCompile(`[ \t\x{00a0}]+`)
+	blankRun = regexp.MustCompile(`\n{3,}`)
+)
+
+// cleanText collapses runs of spaces and blank lines.
+func cleanText(text string) string {
+	text = strings.ToValidUTF8(strings.ReplaceAll(text, "\x00", ""), "")
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	text = strings.ReplaceAll(text, "\r", "\n")
+
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSpace(spaceRun.ReplaceAllString(line, " "))
+	}
+	return strings.TrimSpace(blankRun.ReplaceAllString(strings.Join(lines, "\n"), "\n\n"))
+}
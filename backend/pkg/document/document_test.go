@@ -0,0 +1,158 @@
+package document
+
+import (
+	"archive/zip"
+	"bytes"
+	"compress/zlib"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// zipOf builds a zip archive holding the given files in order.
+func zipOf(t *testing.T, files ...[2]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	for _, f := range files {
+		fw, err := w.Create(f[0])
+		if err != nil {
+			t.Fatal(err)
+		}
+		fw.Write([]byte(f[1]))
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func docx(t *testing.T, body string) []byte {
+	return zipOf(t, [2]string{"word/document.xml", `<?xml version="1.0"?><w:document xmlns:w="w"><w:body>` + body + `</w:body></w:document>`})
+}
+
+func pdfWithStream(dict, stream string) []byte {
+	return []byte(fmt.Sprintf("%%PDF-1.4\n1 0 obj\n<< %s >>\nstream\n%s\nendstream\nendobj\n%%%%EOF\n", dict, stream))
+}
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+		want string
+	}{
+		{"pdf", []byte("%PDF-1.7\n"), FormatPDF},
+		{"docx", docx(t, ""), FormatDOCX},
+		{"odt", zipOf(t, [2]string{"mimetype", "application/vnd.oasis.opendocument.text"}, [2]string{"content.xml", "<office/>"}), FormatODT},
+		{"other zip", zipOf(t, [2]string{"readme.txt", "hi"}), FormatUnknown},
+		{"truncated zip", []byte("PK\x03\x04garbage"), FormatUnknown},
+		{"html", []byte("<html><body>resume</body></html>"), FormatUnknown},
+		{"empty", nil, FormatUnknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Detect(tt.data); got != tt.want {
+				t.Errorf("Detect() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExtractText(t *testing.T) {
+	var compressed bytes.Buffer
+	zw := zlib.NewWriter(&compressed)
+	zw.Write([]byte("BT (Compressed line) Tj ET"))
+	zw.Close()
+
+	tests := []struct {
+		name string
+		data []byte
+		want string
+	}{
+		{
+			"docx paragraphs",
+			docx(t, `<w:p><w:r><w:t>Ada Lovelace</w:t></w:r></w:p><w:p><w:r><w:t>Go</w:t><w:tab/><w:t>SQL</w:t></w:r></w:p>`),
+			"Ada Lovelace\nGo SQL",
+		},
+		{
+			"odt",
+			zipOf(t,
+				[2]string{"mimetype", "application/vnd.oasis.opendocument.text"},
+				[2]string{"content.xml", `<office:document-content xmlns:office="o" xmlns:text="t"><office:body><text:h>Experience</text:h><text:p>Five<text:s/>years</text:p></office:body></office:document-content>`},
+			),
+			"Experience\nFive years",
+		},
+		{"pdf", pdfWithStream("/Length 30", "BT (Hello   World) Tj ET"), "Hello World"},
+		{"flate pdf", pdfWithStream("/Filter /FlateDecode", compressed.String()), "Compressed line"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ExtractText(tt.data)
+			if err != nil {
+				t.Fatalf("ExtractText() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("ExtractText() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExtractTextMalformed(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    []byte
+		wantErr error // nil means any error
+	}{
+		{"empty", nil, ErrUnsupportedFormat},
+		{"plain text", []byte("just some text"), ErrUnsupportedFormat},
+		{"truncated zip", []byte("PK\x03\x04\x14\x00\x00\x00"), ErrUnsupportedFormat},
+		{"zip without document part", zipOf(t, [2]string{"word/styles.xml", "<styles/>"}), ErrUnsupportedFormat},
+		{"broken docx xml", docx(t, `<w:p><w:t>unclosed`), nil},
+		{"docx without text", docx(t, `<w:p></w:p>`), ErrNoText},
+		{"pdf header only", []byte("%PDF-1.4"), ErrNoText},
+		{"pdf without endstream", []byte("%PDF-1.4\n1 0 obj\n<< >>\nstream\nBT (lost) Tj ET"), ErrNoText},
+		{"pdf with corrupt flate stream", pdfWithStream("/Filter /FlateDecode", "not zlib at all BT"), ErrNoText},
+		{"encrypted pdf", pdfWithStream("/Encrypt 5 0 R", "BT (secret) Tj ET"), nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			text, err := ExtractText(tt.data)
+			if err == nil {
+				t.Fatalf("ExtractText() = %q, want an error", text)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("ExtractText() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// Broken content streams may or may not yield text, but must not panic or loop
+func TestExtractTextBrokenPDFStrings(t *testing.T) {
+	for _, stream := range []string{
+		"BT (never closed Tj ET",
+		"BT <4142 Tj ET",
+		"BT (nested (parens) Tj ET",
+		"BT [(a) -20 (b Tj ET",
+		"BT ID \x00\x01 ET",
+		"BT \\",
+	} {
+		ExtractText(pdfWithStream("", stream))
+	}
+}
+
+func TestCleanText(t *testing.T) {
+	in := "  Name:\t Ada \x00\r\n\r\n\r\n\r\nSkills: Go  \n"
+	want := "Name: Ada\n\nSkills: Go"
+	if got := cleanText(in); got != want {
+		t.Errorf("cleanText() = %q, want %q", got, want)
+	}
+	if got := cleanText(strings.Repeat(" ", 10)); got != "" {
+		t.Errorf("cleanText(spaces) = %q, want empty", got)
+	}
+}
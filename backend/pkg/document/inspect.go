@@ -0,0 +1,193 @@
+package document
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrTooLarge      = errors.New("document is too large")
+	ErrTooManyPages  = errors.New("document has too many pages")
+	ErrEncrypted     = errors.New("document is encrypted")
+	ErrActiveContent = errors.New("document contains macros or scripts")
+)
+
+// Limits bound what Validate accepts; zero means no limit
+type Limits struct {
+	MaxSize  int64
+	MaxPages int
+}
+
+// LimitsFromEnv reads UPLOAD_MAX_SIZE_MB (default 10) and UPLOAD_MAX_PAGES (default 20).
+func LimitsFromEnv() (Limits, error) {
+	limits := Limits{MaxSize: 10 << 20, MaxPages: 20}
+	if v := os.Getenv("UPLOAD_MAX_SIZE_MB"); v != "" {
+		mb, err := strconv.Atoi(v)
+		if err != nil || mb < 1 {
+			return Limits{}, fmt.Errorf("invalid UPLOAD_MAX_SIZE_MB %q", v)
+		}
+		limits.MaxSize = int64(mb) << 20
+	}
+	if v := os.Getenv("UPLOAD_MAX_PAGES"); v != "" {
+		pages, err := strconv.Atoi(v)
+		if err != nil || pages < 1 {
+			return Limits{}, fmt.Errorf("invalid UPLOAD_MAX_PAGES %q", v)
+		}
+		limits.MaxPages = pages
+	}
+	return limits, nil
+}
+
+// Info describes a document as found by Inspect
+type Info struct {
+	Format        string
+	Pages         int // 0 when the document does not say
+	Encrypted     bool
+	ActiveContent bool
+}
+
+// Extension returns the file extension that matches the detected format.
+func (i Info) Extension() string {
+	return "." + i.Format
+}
+
+// ContentType returns the MIME type that matches the detected format.
+func (i Info) ContentType() string {
+	switch i.Format {
+	case FormatPDF:
+		return "application/pdf"
+	case FormatDOCX:
+		return "application/vnd.openxmlformats-officedocument.wordprocessingml.document"
+	case FormatODT:
+		return "application/vnd.oasis.opendocument.text"
+	}
+	return "application/octet-stream"
+}
+
+// Validate accepts only PDF, DOCX and ODT documents within limits that are neither encrypted nor carry macros or scripts.
+func Validate(data []byte, limits Limits) (Info, error) {
+	if limits.MaxSize > 0 && int64(len(data)) > limits.MaxSize {
+		return Info{}, ErrTooLarge
+	}
+
+	info, err := Inspect(data)
+	if err != nil {
+		return info, err
+	}
+	switch {
+	case info.Encrypted:
+		return info, ErrEncrypted
+	case info.ActiveContent:
+		return info, ErrActiveContent
+	case limits.MaxPages > 0 && info.Pages > limits.MaxPages:
+		return info, ErrTooManyPages
+	}
+	return info, nil
+}
+
+// oleMagic starts legacy Office files and password protected OOXML packages
+var oleMagic = []byte{0xd0, 0xcf, 0x11, 0xe0, 0xa1, 0xb1, 0x1a, 0xe1}
+
+// Inspect identifies the document and reads its page count and security features.
+func Inspect(data []byte) (Info, error) {
+	if bytes.HasPrefix(data, oleMagic) {
+		// Word encrypts DOCX files by wrapping them in an OLE container
+		if bytes.Contains(data, utf16le("EncryptedPackage")) {
+			return Info{Format: FormatDOCX, Encrypted: true}, nil
+		}
+		return Info{}, ErrUnsupportedFormat
+	}
+
+	switch format := Detect(data); format {
+	case FormatPDF:
+		return inspectPDF(data), nil
+	case FormatDOCX, FormatODT:
+		return inspectPackage(data, format)
+	default:
+		return Info{}, ErrUnsupportedFormat
+	}
+}
+
+var (
+	pdfPage       = regexp.MustCompile(`/Type\s*/Page\b`)
+	pdfPageCount  = regexp.MustCompile(`/Count\s+(\d+)`)
+	pdfActiveKeys = regexp.MustCompile(`/(?:JavaScript|JS|Launch|EmbeddedFile|RichMedia|XFA|SubmitForm|ImportData)\b`)
+)
+
+func inspectPDF(data []byte) Info {
+	info := Info{
+		Format:        FormatPDF,
+		Encrypted:     bytes.Contains(data, []byte("/Encrypt")),
+		ActiveContent: pdfActiveKeys.Match(data),
+		Pages:         len(pdfPage.FindAllIndex(data, -1)),
+	}
+
+	// Page objects hidden in compressed object streams leave only the page tree's count
+	for _, m := range pdfPageCount.FindAllSubmatch(data, -1) {
+		if n, err := strconv.Atoi(string(m[1])); err == nil && n > info.Pages {
+			info.Pages = n
+		}
+	}
+	return info
+}
+
+var (
+	docxPages = regexp.MustCompile(`<Pages>(\d+)</Pages>`)
+	odtPages  = regexp.MustCompile(`meta:page-count="(\d+)"`)
+)
+
+func inspectPackage(data []byte, format string) (Info, error) {
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return Info{}, ErrUnsupportedFormat
+	}
+
+	info := Info{Format: format}
+	for _, f := range archive.File {
+		switch {
+		case f.Name == "word/vbaProject.bin", f.Name == "word/vbaData.xml",
+			strings.HasPrefix(f.Name, "Basic/"), strings.HasPrefix(f.Name, "Scripts/"):
+			info.ActiveContent = true
+		case f.Name == "[Content_Types].xml":
+			if content, err := readZipFile(f); err == nil && bytes.Contains(content, []byte("macroEnabled")) {
+				info.ActiveContent = true
+			}
+		case f.Name == "docProps/app.xml":
+			if content, err := readZipFile(f); err == nil {
+				info.Pages = submatchInt(docxPages, content)
+			}
+		case f.Name == "meta.xml":
+			if content, err := readZipFile(f); err == nil {
+				info.Pages = submatchInt(odtPages, content)
+			}
+		case f.Name == "META-INF/manifest.xml":
+			if content, err := readZipFile(f); err == nil && bytes.Contains(content, []byte("encryption-data")) {
+				info.Encrypted = true
+			}
+		}
+	}
+	return info, nil
+}
+
+func submatchInt(pattern *regexp.Regexp, content []byte) int {
+	m := pattern.FindSubmatch(content)
+	if m == nil {
+		return 0
+	}
+	n, _ := strconv.Atoi(string(m[1]))
+	return n
+}
+
+func utf16le(s string) []byte {
+	out := make([]byte, 0, len(s)*2)
+	for i := 0; i < len(s); i++ {
+		out = append(out, s[i], 0)
+	}
+	return out
+}
@@ -0,0 +1,97 @@
+package document
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// xmlBreaks says which elements of an office document end a line or stand for whitespace
+type xmlBreaks struct {
+	paragraph map[string]bool // text follows on a new line after these close
+	newline   map[string]bool // empty elements standing for a line break
+	tab       map[string]bool
+	space     map[string]bool
+	text      map[string]bool // only character data inside these is text; nil means all of it
+}
+
+var docxBreaks = xmlBreaks{
+	paragraph: map[string]bool{"p": true, "tr": true},
+	newline:   map[string]bool{"br": true, "cr": true},
+	tab:       map[string]bool{"tab": true},
+	text:      map[string]bool{"t": true},
+}
+
+var odtBreaks = xmlBreaks{
+	paragraph: map[string]bool{"p": true, "h": true, "list-item": true, "table-row": true},
+	newline:   map[string]bool{"line-break": true},
+	tab:       map[string]bool{"tab": true},
+	space:     map[string]bool{"s": true},
+}
+
+// extractZipXML reads the text of one XML part of a zipped office document.
+func extractZipXML(data []byte, part string, breaks xmlBreaks) (string, error) {
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return "", fmt.Errorf("could not open document: %w", err)
+	}
+
+	for _, f := range archive.File {
+		if f.Name != part {
+			continue
+		}
+		content, err := readZipFile(f)
+		if err != nil {
+			return "", fmt.Errorf("could not read document: %w", err)
+		}
+		return xmlText(content, breaks)
+	}
+	return "", fmt.Errorf("document has no %s", part)
+}
+
+func xmlText(content []byte, breaks xmlBreaks) (string, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(content))
+	var out strings.Builder
+	inText := 0
+
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", fmt.Errorf("could not parse document: %w", err)
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			name := t.Name.Local
+			switch {
+			case breaks.text[name]:
+				inText++
+			case breaks.newline[name]:
+				out.WriteByte('\n')
+			case breaks.tab[name]:
+				out.WriteByte('\t')
+			case breaks.space[name]:
+				out.WriteByte(' ')
+			}
+		case xml.EndElement:
+			name := t.Name.Local
+			if breaks.text[name] {
+				inText--
+			}
+			if breaks.paragraph[name] {
+				out.WriteByte('\n')
+			}
+		case xml.CharData:
+			if breaks.text == nil || inText > 0 {
+				out.Write(t)
+			}
+		}
+	}
+	return out.String(), nil
+}
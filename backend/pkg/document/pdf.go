@@ -0,0 +1,369 @@
+package document
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+)
+
+// The PDF extractor reads the text-showing operators of every page content stream.
+// It understands Flate compressed streams and simple (single byte) and UTF-16 encoded strings,
+// which covers resumes exported from word processors. Fonts with custom encodings and
+// scanned pages yield little or no text.
+
+var (
+	streamStart = regexp.MustCompile(`stream\r?\n`)
+	objectStart = regexp.MustCompile(`\d+\s+\d+\s+obj\b`)
+)
+
+func extractPDF(data []byte) (string, error) {
+	if bytes.Contains(data, []byte("/Encrypt")) {
+		return "", fmt.Errorf("encrypted PDFs are not supported")
+	}
+
+	var out strings.Builder
+	total := 0
+
+	for _, loc := range streamStart.FindAllIndex(data, -1) {
+		end := bytes.Index(data[loc[1]:], []byte("endstream"))
+		if end < 0 {
+			continue
+		}
+		dict := streamDictionary(data[:loc[0]])
+		if skipStream(dict) {
+			continue
+		}
+
+		raw := data[loc[1] : loc[1]+end]
+		content, err := decodeStream(dict, raw)
+		if err != nil || !bytes.Contains(content, []byte("BT")) {
+			continue
+		}
+		total += len(content)
+		if total > maxExtractedSize {
+			return "", fmt.Errorf("document is too large once decompressed")
+		}
+
+		out.WriteString(contentText(content))
+		out.WriteByte('\n')
+	}
+	return out.String(), nil
+}
+
+// streamDictionary returns the dictionary of the object a stream belongs to.
+func streamDictionary(before []byte) []byte {
+	starts := objectStart.FindAllIndex(before, -1)
+	if len(starts) == 0 {
+		return nil
+	}
+	return before[starts[len(starts)-1][1]:]
+}
+
+// skipStream reports whether a stream cannot hold page text: images, fonts, metadata and cross references.
+func skipStream(dict []byte) bool {
+	for _, marker := range []string{"/Image", "/XRef", "/ObjStm", "/Metadata", "/Length1", "/Length2", "/Length3", "/FontFile", "/ICCBased", "/EmbeddedFile"} {
+		if bytes.Contains(dict, []byte(marker)) {
+			return true
+		}
+	}
+	return false
+}
+
+func decodeStream(dict, raw []byte) ([]byte, error) {
+	switch {
+	case bytes.Contains(dict, []byte("/FlateDecode")):
+		r, err := zlib.NewReader(bytes.NewReader(raw))
+		if err != nil {
+			return nil, err
+		}
+		defer r.Close()
+		// Truncated streams still give up what they decoded so far
+		content, err := io.ReadAll(io.LimitReader(r, maxExtractedSize+1))
+		if len(content) > 0 {
+			return content, nil
+		}
+		return nil, err
+	case bytes.Contains(dict, []byte("/Filter")):
+		return nil, fmt.Errorf("unsupported stream filter")
+	default:
+		return raw, nil
+	}
+}
+
+// contentText interprets the text operators of a content stream.
+func contentText(content []byte) string {
+	var out strings.Builder
+	var operands []pdfToken
+	lex := pdfLexer{data: content}
+
+	for {
+		tok, ok := lex.next()
+		if !ok {
+			break
+		}
+		if tok.kind != tokenOperator {
+			operands = append(operands, tok)
+			continue
+		}
+
+		switch tok.value {
+		case "Tj":
+			writeOperandText(&out, operands)
+		case "'", "\"":
+			out.WriteByte('\n')
+			writeOperandText(&out, operands)
+		case "TJ":
+			for _, op := range operands {
+				switch op.kind {
+				case tokenString:
+					out.WriteString(op.value)
+				case tokenNumber:
+					// Large negative kerning is how many producers write a space
+					if n, err := strconv.ParseFloat(op.value, 64); err == nil && n < -200 {
+						out.WriteByte(' ')
+					}
+				}
+			}
+		case "Td", "TD":
+			if len(operands) >= 2 {
+				ty, _ := strconv.ParseFloat(operands[len(operands)-1].value, 64)
+				tx, _ := strconv.ParseFloat(operands[len(operands)-2].value, 64)
+				if ty != 0 {
+					out.WriteByte('\n')
+				} else if tx > 0 {
+					out.WriteByte(' ')
+				}
+			}
+		case "T*", "ET", "Tm":
+			out.WriteByte('\n')
+		case "ID":
+			lex.skipInlineImage()
+		}
+		operands = operands[:0]
+	}
+	return out.String()
+}
+
+func writeOperandText(out *strings.Builder, operands []pdfToken) {
+	for _, op := range operands {
+		if op.kind == tokenString {
+			out.WriteString(op.value)
+		}
+	}
+}
+
+type tokenKind int
+
+const (
+	tokenOperator tokenKind = iota
+	tokenNumber
+	tokenString
+	tokenOther
+)
+
+type pdfToken struct {
+	kind  tokenKind
+	value string
+}
+
+type pdfLexer struct {
+	data []byte
+	pos  int
+}
+
+func isPDFSpace(b byte) bool {
+	return b == ' ' || b == '\n' || b == '\r' || b == '\t' || b == '\f' || b == 0
+}
+
+func isPDFDelimiter(b byte) bool {
+	return strings.IndexByte("()<>[]{}/%", b) >= 0
+}
+
+// next returns the next token, flattening arrays into their elements.
+func (l *pdfLexer) next() (pdfToken, bool) {
+	for l.pos < len(l.data) {
+		b := l.data[l.pos]
+		switch {
+		case isPDFSpace(b), b == '[', b == ']', b == '{', b == '}':
+			l.pos++
+		case b == '%':
+			for l.pos < len(l.data) && l.data[l.pos] != '\n' && l.data[l.pos] != '\r' {
+				l.pos++
+			}
+		case b == '(':
+			return pdfToken{tokenString, l.literalString()}, true
+		case b == '<':
+			if l.pos+1 < len(l.data) && l.data[l.pos+1] == '<' {
+				l.pos += 2
+				return pdfToken{kind: tokenOther}, true
+			}
+			return pdfToken{tokenString, l.hexString()}, true
+		case b == '>':
+			l.pos++
+			if l.pos < len(l.data) && l.data[l.pos] == '>' {
+				l.pos++
+			}
+			return pdfToken{kind: tokenOther}, true
+		case b == '/':
+			l.pos++
+			l.word()
+			return pdfToken{kind: tokenOther}, true
+		default:
+			w := l.word()
+			if w == "" {
+				l.pos++
+				continue
+			}
+			if _, err := strconv.ParseFloat(w, 64); err == nil {
+				return pdfToken{tokenNumber, w}, true
+			}
+			return pdfToken{tokenOperator, w}, true
+		}
+	}
+	return pdfToken{}, false
+}
+
+func (l *pdfLexer) word() string {
+	start := l.pos
+	for l.pos < len(l.data) && !isPDFSpace(l.data[l.pos]) && !isPDFDelimiter(l.data[l.pos]) {
+		l.pos++
+	}
+	return string(l.data[start:l.pos])
+}
+
+func (l *pdfLexer) literalString() string {
+	l.pos++ // opening parenthesis
+	var raw []byte
+	depth := 1
+
+	for l.pos < len(l.data) {
+		b := l.data[l.pos]
+		l.pos++
+		switch b {
+		case '(':
+			depth++
+			raw = append(raw, b)
+		case ')':
+			depth--
+			if depth == 0 {
+				return decodePDFString(raw)
+			}
+			raw = append(raw, b)
+		case '\\':
+			if l.pos >= len(l.data) {
+				break
+			}
+			e := l.data[l.pos]
+			l.pos++
+			switch e {
+			case 'n':
+				raw = append(raw, '\n')
+			case 'r':
+				raw = append(raw, '\r')
+			case 't':
+				raw = append(raw, '\t')
+			case 'b', 'f':
+			case '\r':
+				if l.pos < len(l.data) && l.data[l.pos] == '\n' {
+					l.pos++
+				}
+			case '\n':
+			default:
+				if e >= '0' && e <= '7' {
+					n := int(e - '0')
+					for i := 0; i < 2 && l.pos < len(l.data) && l.data[l.pos] >= '0' && l.data[l.pos] <= '7'; i++ {
+						n = n*8 + int(l.data[l.pos]-'0')
+						l.pos++
+					}
+					raw = append(raw, byte(n))
+				} else {
+					raw = append(raw, e)
+				}
+			}
+		default:
+			raw = append(raw, b)
+		}
+	}
+	return decodePDFString(raw)
+}
+
+func (l *pdfLexer) hexString() string {
+	l.pos++ // opening angle bracket
+	end := bytes.IndexByte(l.data[l.pos:], '>')
+	if end < 0 {
+		end = len(l.data) - l.pos
+	}
+	digits := make([]byte, 0, end)
+	for _, b := range l.data[l.pos : l.pos+end] {
+		if !isPDFSpace(b) {
+			digits = append(digits, b)
+		}
+	}
+	l.pos += end + 1
+	if len(digits)%2 == 1 {
+		digits = append(digits, '0')
+	}
+
+	raw, err := hex.DecodeString(string(digits))
+	if err != nil {
+		return ""
+	}
+	return decodePDFString(raw)
+}
+
+// skipInlineImage moves past the binary data of an inline image up to its EI operator.
+func (l *pdfLexer) skipInlineImage() {
+	end := bytes.Index(l.data[l.pos:], []byte("EI"))
+	for end >= 0 {
+		at := l.pos + end
+		if at > 0 && isPDFSpace(l.data[at-1]) && (at+2 >= len(l.data) || isPDFSpace(l.data[at+2])) {
+			l.pos = at + 2
+			return
+		}
+		next := bytes.Index(l.data[at+2:], []byte("EI"))
+		if next < 0 {
+			break
+		}
+		end = at + 2 + next - l.pos
+	}
+	l.pos = len(l.data)
+}
+
+// winAnsiHigh maps the WinAnsi bytes 0x80-0x9f that differ from Latin-1.
+var winAnsiHigh = map[byte]rune{
+	0x80: '€', 0x85: '…', 0x91: '\'', 0x92: '\'', 0x93: '"', 0x94: '"',
+	0x95: '•', 0x96: '–', 0x97: '—', 0x99: '™',
+}
+
+// decodePDFString turns string bytes into text, as UTF-16 when they carry a byte order mark
+// and as WinAnsi otherwise.
+func decodePDFString(raw []byte) string {
+	if len(raw) >= 2 && raw[0] == 0xfe && raw[1] == 0xff {
+		units := make([]uint16, 0, len(raw)/2)
+		for i := 2; i+1 < len(raw); i += 2 {
+			units = append(units, uint16(raw[i])<<8|uint16(raw[i+1]))
+		}
+		return string(utf16.Decode(units))
+	}
+
+	var out strings.Builder
+	for _, b := range raw {
+		switch {
+		case b >= 0x80 && b <= 0x9f:
+			if r, ok := winAnsiHigh[b]; ok {
+				out.WriteRune(r)
+			}
+		case b < 0x20 && b != '\n' && b != '\t':
+			// Control bytes only show up with custom font encodings
+		default:
+			out.WriteRune(rune(b))
+		}
+	}
+	return out.String()
+}
@@ -0,0 +1,169 @@
+package ics
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	MethodPublish = "PUBLISH"
+	MethodRequest = "REQUEST"
+	MethodCancel  = "CANCEL"
+
+	ContentType = "text/calendar; charset=utf-8"
+)
+
+type Attendee struct {
+	Name  string
+	Email string
+}
+
+// Event is a single VEVENT. Sequence must grow every time the event changes
+// so calendar clients replace their copy instead of duplicating it.
+type Event struct {
+	UID         string
+	Sequence    int
+	Start       time.Time
+	End         time.Time
+	Summary     string
+	Description string
+	Location    string
+	URL         string
+	Cancelled   bool
+	Organizer   *Attendee
+	Attendees   []Attendee
+	Updated     time.Time
+}
+
+// Calendar is an RFC 5545 VCALENDAR object
+type Calendar struct {
+	ProdID string
+	Name   string
+	Method string
+	Events []Event
+}
+
+// Write serializes the calendar with CRLF line endings and 75 octet line folding.
+func (cal Calendar) Write(w io.Writer) error {
+	lw := &lineWriter{w: w}
+
+	prodID := cal.ProdID
+	if prodID == "" {
+		prodID = "-//JobHunt AI//Interviews//EN"
+	}
+
+	lw.line("BEGIN:VCALENDAR")
+	lw.line("VERSION:2.0")
+	lw.line("PRODID:" + prodID)
+	lw.line("CALSCALE:GREGORIAN")
+	if cal.Method != "" {
+		lw.line("METHOD:" + cal.Method)
+	}
+	if cal.Name != "" {
+		lw.line("X-WR-CALNAME:" + escapeText(cal.Name))
+	}
+
+	for _, e := range cal.Events {
+		stamp := e.Updated
+		if stamp.IsZero() {
+			stamp = time.Now()
+		}
+
+		lw.line("BEGIN:VEVENT")
+		lw.line("UID:" + e.UID)
+		lw.line(fmt.Sprintf("SEQUENCE:%d", e.Sequence))
+		lw.line("DTSTAMP:" + formatTime(stamp))
+		lw.line("DTSTART:" + formatTime(e.Start))
+		lw.line("DTEND:" + formatTime(e.End))
+		lw.line("SUMMARY:" + escapeText(e.Summary))
+		if e.Description != "" {
+			lw.line("DESCRIPTION:" + escapeText(e.Description))
+		}
+		if e.Location != "" {
+			lw.line("LOCATION:" + escapeText(e.Location))
+		}
+		if e.URL != "" {
+			lw.line("URL:" + e.URL)
+		}
+		if e.Organizer != nil {
+			lw.line("ORGANIZER" + nameParam(e.Organizer.Name) + ":mailto:" + e.Organizer.Email)
+		}
+		for _, a := range e.Attendees {
+			lw.line("ATTENDEE;ROLE=REQ-PARTICIPANT" + nameParam(a.Name) + ":mailto:" + a.Email)
+		}
+		if e.Cancelled {
+			lw.line("STATUS:CANCELLED")
+		} else {
+			lw.line("STATUS:CONFIRMED")
+		}
+		lw.line("END:VEVENT")
+	}
+
+	lw.line("END:VCALENDAR")
+	return lw.err
+}
+
+// Bytes returns the serialized calendar
+func (cal Calendar) Bytes() []byte {
+	var buf bytes.Buffer
+	_ = cal.Write(&buf)
+	return buf.Bytes()
+}
+
+func formatTime(t time.Time) string {
+	return t.UTC().Format("20060102T150405Z")
+}
+
+func nameParam(name string) string {
+	if name == "" {
+		return ""
+	}
+	// Parameter values cannot contain DQUOTE
+	return `;CN="` + strings.ReplaceAll(name, `"`, "'") + `"`
+}
+
+// escapeText escapes TEXT values as described in RFC 5545 section 3.3.11
+func escapeText(s string) string {
+	r := strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+	)
+	return r.Replace(s)
+}
+
+type lineWriter struct {
+	w   io.Writer
+	err error
+}
+
+// line writes a content line, folding it so no physical line exceeds 75 octets.
+func (lw *lineWriter) line(s string) {
+	if lw.err != nil {
+		return
+	}
+
+	var b strings.Builder
+	limit := 75
+	for len(s) > limit {
+		cut := limit
+		// Never split a multi-byte character
+		for cut > 0 && !utf8.RuneStart(s[cut]) {
+			cut--
+		}
+		b.WriteString(s[:cut])
+		b.WriteString("\r\n ")
+		s = s[cut:]
+		limit = 74 // continuation lines start with a space
+	}
+	b.WriteString(s)
+	b.WriteString("\r\n")
+
+	_, lw.err = io.WriteString(lw.w, b.String())
+}
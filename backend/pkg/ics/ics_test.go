@@ -0,0 +1,75 @@
+package ics
+
+import (
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+func TestWriteFoldsLongLines(t *testing.T) {
+	tests := []struct {
+		name    string
+		summary string
+	}{
+		{"short", "Interview"},
+		{"exactly one line", strings.Repeat("a", 75-len("SUMMARY:"))},
+		{"ascii", strings.Repeat("Backend engineer interview ", 10)},
+		{"two-byte runes", strings.Repeat("é", 120)},
+		{"three-byte runes", strings.Repeat("面接", 60)},
+		{"four-byte runes", strings.Repeat("📅", 50)},
+		{"mixed", "Entretien – " + strings.Repeat("Développeur 日本 🚀, ", 12)},
+	}
+
+	for _, tt := range tests {
+		start := time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC)
+		cal := Calendar{Events: []Event{{
+			UID:     "interview-1@example.com",
+			Start:   start,
+			End:     start.Add(time.Hour),
+			Summary: tt.summary,
+			Updated: start,
+		}}}
+		out := string(cal.Bytes())
+
+		if !strings.HasSuffix(out, "\r\n") {
+			t.Errorf("%s: output does not end with CRLF", tt.name)
+		}
+		physical := strings.Split(strings.TrimSuffix(out, "\r\n"), "\r\n")
+		for _, line := range physical {
+			if len(line) > 75 {
+				t.Errorf("%s: line of %d octets: %q", tt.name, len(line), line)
+			}
+			if !utf8.ValidString(line) {
+				t.Errorf("%s: fold split a character: %q", tt.name, line)
+			}
+		}
+
+		// Unfolding must give the summary back unchanged
+		unfolded := strings.ReplaceAll(out, "\r\n ", "")
+		if !strings.Contains(unfolded, "\r\nSUMMARY:"+escapeText(tt.summary)+"\r\n") {
+			t.Errorf("%s: unfolded output lost the summary:\n%s", tt.name, unfolded)
+		}
+	}
+}
+
+func TestEscapeText(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"Interview", "Interview"},
+		{"Room 4; 2nd floor", `Room 4\; 2nd floor`},
+		{"Pune, Maharashtra", `Pune\, Maharashtra`},
+		{`C:\meet`, `C:\\meet`},
+		{"line one\nline two", `line one\nline two`},
+		{"line one\r\nline two", `line one\nline two`},
+		{`a\;b`, `a\\\;b`},
+	}
+
+	for _, tt := range tests {
+		if got := escapeText(tt.in); got != tt.want {
+			t.Errorf("escapeText(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
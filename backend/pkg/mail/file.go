@@ -0,0 +1,36 @@
+package mail
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// FileDriver writes every message as an .eml file instead of sending it.
+// It is meant for local development and tests.
+type FileDriver struct {
+	Dir string
+}
+
+func NewFileDriver(dir string) (*FileDriver, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("could not create mail directory: %w", err)
+	}
+	return &FileDriver{Dir: dir}, nil
+}
+
+func (d *FileDriver) Send(ctx context.Context, msg Message) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	raw, err := Build(msg)
+	if err != nil {
+		return fmt.Errorf("could not build message: %w", err)
+	}
+
+	name := fmt.Sprintf("%d-%s.eml", time.Now().UnixNano(), randomID())
+	return os.WriteFile(filepath.Join(d.Dir, name), raw, 0o644)
+}
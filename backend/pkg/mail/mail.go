@@ -0,0 +1,149 @@
+package mail
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/textproto"
+	"os"
+	"strings"
+	"time"
+)
+
+// Message is a single email with a plain text and an optional HTML body
+type Message struct {
+	From    string
+	To      string
+	Subject string
+	Text    string
+	HTML    string
+	Headers map[string]string
+}
+
+// Driver delivers rendered messages
+type Driver interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+// NewFromEnv picks the driver named by MAIL_DRIVER ("smtp" or "file", default "file").
+func NewFromEnv() (Driver, error) {
+	switch driver := os.Getenv("MAIL_DRIVER"); driver {
+	case "smtp":
+		return NewSMTPDriver(
+			os.Getenv("SMTP_HOST"),
+			os.Getenv("SMTP_PORT"),
+			os.Getenv("SMTP_USERNAME"),
+			os.Getenv("SMTP_PASSWORD"),
+		)
+	case "", "file":
+		dir := os.Getenv("MAIL_FILE_DIR")
+		if dir == "" {
+			dir = "mail_outbox"
+		}
+		return NewFileDriver(dir)
+	default:
+		return nil, fmt.Errorf("unknown mail driver %q", driver)
+	}
+}
+
+// DefaultFrom is the sender address used when a message does not set one
+func DefaultFrom() string {
+	if from := os.Getenv("MAIL_FROM"); from != "" {
+		return from
+	}
+	return "JobHunt AI <no-reply@jobhunt.local>"
+}
+
+// Build renders msg as an RFC 5322 message with MIME parts.
+func Build(msg Message) ([]byte, error) {
+	from := msg.From
+	if from == "" {
+		from = DefaultFrom()
+	}
+
+	header := textproto.MIMEHeader{}
+	header.Set("From", from)
+	header.Set("To", msg.To)
+	header.Set("Subject", mime.QEncoding.Encode("utf-8", msg.Subject))
+	header.Set("Date", time.Now().Format(time.RFC1123Z))
+	header.Set("Message-ID", fmt.Sprintf("<%s@jobhunt.local>", randomID()))
+	header.Set("MIME-Version", "1.0")
+	for k, v := range msg.Headers {
+		header.Set(k, v)
+	}
+
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body)
+	if err := writeAlternative(w, msg); err != nil {
+		return nil, err
+	}
+	header.Set("Content-Type", "multipart/alternative; boundary="+w.Boundary())
+
+	var buf bytes.Buffer
+	writeHeader(&buf, header)
+	buf.Write(body.Bytes())
+	return buf.Bytes(), nil
+}
+
+func writeAlternative(w *multipart.Writer, msg Message) error {
+	text, err := w.CreatePart(textproto.MIMEHeader{
+		"Content-Type":              {"text/plain; charset=utf-8"},
+		"Content-Transfer-Encoding": {"base64"},
+	})
+	if err != nil {
+		return err
+	}
+	if err := writeBase64(text, []byte(msg.Text)); err != nil {
+		return err
+	}
+
+	if msg.HTML != "" {
+		html, err := w.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {"text/html; charset=utf-8"},
+			"Content-Transfer-Encoding": {"base64"},
+		})
+		if err != nil {
+			return err
+		}
+		if err := writeBase64(html, []byte(msg.HTML)); err != nil {
+			return err
+		}
+	}
+	return w.Close()
+}
+
+func writeHeader(buf *bytes.Buffer, header textproto.MIMEHeader) {
+	for k, values := range header {
+		for _, v := range values {
+			fmt.Fprintf(buf, "%s: %s\r\n", k, v)
+		}
+	}
+	buf.WriteString("\r\n")
+}
+
+// writeBase64 writes data base64 encoded in 76 character lines
+func writeBase64(w io.Writer, data []byte) error {
+	encoded := base64.StdEncoding.EncodeToString(data)
+	var b strings.Builder
+	for len(encoded) > 76 {
+		b.WriteString(encoded[:76])
+		b.WriteString("\r\n")
+		encoded = encoded[76:]
+	}
+	b.WriteString(encoded)
+	b.WriteString("\r\n")
+	_, err := w.Write([]byte(b.String()))
+	return err
+}
+
+func randomID() string {
+	b := make([]byte, 12)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
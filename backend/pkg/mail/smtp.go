@@ -0,0 +1,56 @@
+package mail
+
+import (
+	"context"
+	"fmt"
+	"net/mail"
+	"net/smtp"
+)
+
+// SMTPDriver sends through an SMTP relay using PLAIN auth when credentials are set
+type SMTPDriver struct {
+	addr string
+	host string
+	auth smtp.Auth
+}
+
+func NewSMTPDriver(host, port, username, password string) (*SMTPDriver, error) {
+	if host == "" {
+		return nil, fmt.Errorf("SMTP_HOST is not set")
+	}
+	if port == "" {
+		port = "587"
+	}
+
+	d := &SMTPDriver{addr: host + ":" + port, host: host}
+	if username != "" {
+		d.auth = smtp.PlainAuth("", username, password, host)
+	}
+	return d, nil
+}
+
+func (d *SMTPDriver) Send(ctx context.Context, msg Message) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	from := msg.From
+	if from == "" {
+		from = DefaultFrom()
+	}
+	sender, err := mail.ParseAddress(from)
+	if err != nil {
+		return fmt.Errorf("invalid sender address: %w", err)
+	}
+	recipient, err := mail.ParseAddress(msg.To)
+	if err != nil {
+		return fmt.Errorf("invalid recipient address: %w", err)
+	}
+
+	raw, err := Build(msg)
+	if err != nil {
+		return fmt.Errorf("could not build message: %w", err)
+	}
+
+	return smtp.SendMail(d.addr, d.auth, sender.Address, []string{recipient.Address}, raw)
+}
@@ -0,0 +1,49 @@
+package poll
+
+import (
+	"context"
+	"time"
+)
+
+// Run calls round right away and then on every tick of interval, or as soon as wake
+// receives, until ctx is cancelled. wake may be nil.
+func Run(ctx context.Context, interval time.Duration, wake <-chan struct{}, round func()) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		round()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-wake:
+		}
+	}
+}
+
+// Drain claims batches of up to size items and handles them one by one, until a batch comes
+// back short, a claim fails or ctx is cancelled. claim must lease what it returns, so the
+// same items are not claimed twice in a row.
+func Drain[T any](ctx context.Context, size int, claim func(limit int) ([]T, error), handle func(T)) {
+	for ctx.Err() == nil {
+		items, err := claim(size)
+		if err != nil {
+			return
+		}
+
+		for _, item := range items {
+			if ctx.Err() != nil {
+				// Unhandled claims become due again once their lease runs out
+				return
+			}
+			handle(item)
+		}
+
+		// Only a full batch means more are probably waiting
+		if len(items) < size {
+			return
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package poll
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+// queue hands out items like a claim query with leases would
+type queue struct {
+	items  []int
+	claims int
+}
+
+func (q *queue) claim(limit int) ([]int, error) {
+	q.claims++
+	n := min(limit, len(q.items))
+	batch := q.items[:n]
+	q.items = q.items[n:]
+	return batch, nil
+}
+
+func TestDrain(t *testing.T) {
+	tests := []struct {
+		name   string
+		items  int
+		claims int
+	}{
+		{"empty", 0, 1},
+		{"short batch", 2, 1},
+		{"exactly one batch", 3, 2},
+		{"several batches", 7, 3},
+		{"whole batches", 9, 4},
+	}
+
+	for _, tt := range tests {
+		q := &queue{}
+		for i := range tt.items {
+			q.items = append(q.items, i)
+		}
+
+		var handled []int
+		Drain(context.Background(), 3, q.claim, func(item int) { handled = append(handled, item) })
+
+		if len(handled) != tt.items || !slices.IsSorted(handled) {
+			t.Errorf("%s: handled %v, want all %d items in order", tt.name, handled, tt.items)
+		}
+		if q.claims != tt.claims {
+			t.Errorf("%s: claimed %d times, want %d", tt.name, q.claims, tt.claims)
+		}
+	}
+}
+
+func TestDrainStopsOnClaimError(t *testing.T) {
+	claims := 0
+	Drain(context.Background(), 3, func(limit int) ([]int, error) {
+		claims++
+		return nil, errors.New("database down")
+	}, func(int) { t.Error("handled an item after a failed claim") })
+
+	if claims != 1 {
+		t.Errorf("claimed %d times, want 1", claims)
+	}
+}
+
+func TestDrainStopsWhenCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	q := &queue{items: []int{1, 2, 3, 4, 5, 6}}
+
+	var handled []int
+	Drain(ctx, 3, q.claim, func(item int) {
+		handled = append(handled, item)
+		if item == 2 {
+			cancel()
+		}
+	})
+
+	if !slices.Equal(handled, []int{1, 2}) || q.claims != 1 {
+		t.Errorf("handled %v over %d claims, want [1 2] over 1", handled, q.claims)
+	}
+
+	claims := q.claims
+	Drain(ctx, 3, q.claim, func(int) {})
+	if q.claims != claims {
+		t.Error("Drain claimed with a cancelled context")
+	}
+}
+
+func TestRun(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	wake := make(chan struct{})
+	rounds := make(chan int)
+
+	done := make(chan struct{})
+	go func() {
+		n := 0
+		Run(ctx, time.Hour, wake, func() {
+			n++
+			rounds <- n
+		})
+		close(done)
+	}()
+
+	if n := <-rounds; n != 1 {
+		t.Fatalf("first round = %d, want one right away", n)
+	}
+	wake <- struct{}{}
+	if n := <-rounds; n != 2 {
+		t.Fatalf("round after wake = %d, want 2", n)
+	}
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Run did not return after cancel")
+	}
+}
@@ -0,0 +1,97 @@
+package scan
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"time"
+)
+
+const clamChunkSize = 64 << 10
+
+// ClamAV scans through a clamd daemon using its INSTREAM command
+type ClamAV struct {
+	network string
+	address string
+	timeout time.Duration
+}
+
+func NewClamAV(network, address string, timeout time.Duration) *ClamAV {
+	return &ClamAV{network: network, address: address, timeout: timeout}
+}
+
+// ParseAddress splits "unix:/path" or "tcp:host:port" into a network and an address.
+func ParseAddress(raw string) (string, string, error) {
+	network, address, ok := strings.Cut(raw, ":")
+	if !ok || address == "" || (network != "unix" && network != "tcp") {
+		return "", "", fmt.Errorf("invalid clamd address %q, want unix:/path or tcp:host:port", raw)
+	}
+	return network, address, nil
+}
+
+func (c *ClamAV) Scan(ctx context.Context, r io.Reader) (Result, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, c.network, c.address)
+	if err != nil {
+		return Result{}, fmt.Errorf("could not reach clamd: %w", err)
+	}
+	defer conn.Close()
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+
+	if _, err := conn.Write([]byte("zINSTREAM\x00")); err != nil {
+		return Result{}, fmt.Errorf("could not start scan: %w", err)
+	}
+
+	// The stream is sent as length-prefixed chunks and ended by an empty one
+	buf := make([]byte, clamChunkSize)
+	size := make([]byte, 4)
+	for {
+		n, readErr := r.Read(buf)
+		if n > 0 {
+			binary.BigEndian.PutUint32(size, uint32(n))
+			if _, err := conn.Write(size); err != nil {
+				return Result{}, fmt.Errorf("could not send file to clamd: %w", err)
+			}
+			if _, err := conn.Write(buf[:n]); err != nil {
+				return Result{}, fmt.Errorf("could not send file to clamd: %w", err)
+			}
+		}
+		if readErr == io.EOF {
+			break
+		}
+		if readErr != nil {
+			return Result{}, readErr
+		}
+	}
+	if _, err := conn.Write([]byte{0, 0, 0, 0}); err != nil {
+		return Result{}, fmt.Errorf("could not finish scan: %w", err)
+	}
+
+	reply, err := bufio.NewReader(conn).ReadString(0)
+	if err != nil && reply == "" {
+		return Result{}, fmt.Errorf("no reply from clamd: %w", err)
+	}
+	return parseReply(strings.TrimRight(reply, "\x00\n"))
+}
+
+// parseReply reads "stream: OK", "stream: <signature> FOUND" or "<message> ERROR".
+func parseReply(reply string) (Result, error) {
+	_, verdict, _ := strings.Cut(reply, ": ")
+	switch {
+	case verdict == "OK":
+		return Result{Clean: true}, nil
+	case strings.HasSuffix(verdict, " FOUND"):
+		return Result{Signature: strings.TrimSuffix(verdict, " FOUND")}, nil
+	default:
+		return Result{}, fmt.Errorf("clamd error: %s", reply)
+	}
+}
@@ -0,0 +1,137 @@
+package scan
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"strings"
+	"testing"
+	"time"
+)
+
+func listen(t *testing.T) net.Listener {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	t.Cleanup(func() { ln.Close() })
+	return ln
+}
+
+func TestClamAVWithStub(t *testing.T) {
+	ln := listen(t)
+	go ServeStub(ln)
+	clam := NewClamAV("tcp", ln.Addr().String(), 5*time.Second)
+
+	// Larger than one chunk, so the stream is sent in several
+	padding := strings.Repeat("resume text ", clamChunkSize/8)
+
+	tests := []struct {
+		name      string
+		payload   string
+		clean     bool
+		signature string
+	}{
+		{"empty", "", true, ""},
+		{"clean", "Jane Doe, Go developer", true, ""},
+		{"clean over several chunks", padding, true, ""},
+		{"eicar", eicar, false, "Eicar-Test-Signature"},
+		{"eicar after the first chunk", padding + eicar, false, "Eicar-Test-Signature"},
+	}
+
+	for _, tt := range tests {
+		result, err := clam.Scan(context.Background(), strings.NewReader(tt.payload))
+		if err != nil {
+			t.Errorf("%s: Scan = %v", tt.name, err)
+			continue
+		}
+		if result.Clean != tt.clean || result.Signature != tt.signature {
+			t.Errorf("%s: Scan = %+v, want clean %v signature %q", tt.name, result, tt.clean, tt.signature)
+		}
+	}
+}
+
+func TestClamAVDroppedConnection(t *testing.T) {
+	ln := listen(t)
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			conn.Close()
+		}
+	}()
+
+	clam := NewClamAV("tcp", ln.Addr().String(), 5*time.Second)
+	if _, err := clam.Scan(context.Background(), bytes.NewReader([]byte("hello"))); err == nil {
+		t.Error("Scan with a dropped connection succeeded, want an error")
+	}
+}
+
+func TestClamAVUnreachable(t *testing.T) {
+	ln := listen(t)
+	address := ln.Addr().String()
+	ln.Close()
+
+	clam := NewClamAV("tcp", address, time.Second)
+	if _, err := clam.Scan(context.Background(), strings.NewReader("hello")); err == nil {
+		t.Error("Scan with nothing listening succeeded, want an error")
+	}
+}
+
+func TestParseReply(t *testing.T) {
+	tests := []struct {
+		reply     string
+		clean     bool
+		signature string
+		wantErr   bool
+	}{
+		{"stream: OK", true, "", false},
+		{"stream: Eicar-Test-Signature FOUND", false, "Eicar-Test-Signature", false},
+		{"stream: Win.Test.EICAR_HDB-1 FOUND", false, "Win.Test.EICAR_HDB-1", false},
+		{"INSTREAM size limit exceeded. ERROR", false, "", true},
+		{"UNKNOWN COMMAND ERROR", false, "", true},
+		{"", false, "", true},
+	}
+
+	for _, tt := range tests {
+		result, err := parseReply(tt.reply)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("parseReply(%q) = %+v, want an error", tt.reply, result)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("parseReply(%q) = %v", tt.reply, err)
+			continue
+		}
+		if result.Clean != tt.clean || result.Signature != tt.signature {
+			t.Errorf("parseReply(%q) = %+v, want clean %v signature %q", tt.reply, result, tt.clean, tt.signature)
+		}
+	}
+}
+
+func TestParseAddress(t *testing.T) {
+	tests := []struct {
+		raw     string
+		network string
+		address string
+		wantErr bool
+	}{
+		{"unix:/var/run/clamav/clamd.ctl", "unix", "/var/run/clamav/clamd.ctl", false},
+		{"tcp:127.0.0.1:3310", "tcp", "127.0.0.1:3310", false},
+		{"udp:127.0.0.1:3310", "", "", true},
+		{"tcp:", "", "", true},
+		{"127.0.0.1:3310", "", "", true},
+	}
+
+	for _, tt := range tests {
+		network, address, err := ParseAddress(tt.raw)
+		if (err != nil) != tt.wantErr || network != tt.network || address != tt.address {
+			t.Errorf("ParseAddress(%q) = %q, %q, %v", tt.raw, network, address, err)
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package scan
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+)
+
+// Result is a scanner's verdict on one file
+type Result struct {
+	Clean     bool
+	Signature string // what was found, when not clean
+}
+
+// Scanner checks uploaded files for malware
+type Scanner interface {
+	Scan(ctx context.Context, r io.Reader) (Result, error)
+}
+
+// NewFromEnv picks the scanner named by SCANNER ("clamav" or "none", default "none").
+// ClamAV is reached at CLAMAV_ADDRESS, e.g. "unix:/var/run/clamav/clamd.ctl" or "tcp:127.0.0.1:3310".
+func NewFromEnv() (Scanner, error) {
+	switch scanner := os.Getenv("SCANNER"); scanner {
+	case "clamav":
+		network, address, err := ParseAddress(os.Getenv("CLAMAV_ADDRESS"))
+		if err != nil {
+			return nil, err
+		}
+		timeout := 30 * time.Second
+		if v := os.Getenv("CLAMAV_TIMEOUT"); v != "" {
+			if timeout, err = time.ParseDuration(v); err != nil {
+				return nil, fmt.Errorf("invalid CLAMAV_TIMEOUT %q", v)
+			}
+		}
+		return NewClamAV(network, address, timeout), nil
+	case "", "none":
+		return Noop{}, nil
+	default:
+		return nil, fmt.Errorf("unknown scanner %q", scanner)
+	}
+}
+
+// Noop passes every file; it is used when no scanner is configured
+type Noop struct{}
+
+func (Noop) Scan(ctx context.Context, r io.Reader) (Result, error) {
+	return Result{Clean: true}, nil
+}
@@ -0,0 +1,57 @@
+package scan
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"io"
+	"net"
+	"strings"
+)
+
+// eicar is the standard antivirus test string every scanner flags
+const eicar = `X5O!P%@AP[4\PZX54(P^)7CC)7}$EICAR-STANDARD-ANTIVIRUS-TEST-FILE!$H+H*`
+
+// ServeStub answers clamd INSTREAM requests on ln, flagging streams that contain the
+// EICAR test string. It stands in for clamd in development and tests, and returns when ln is closed.
+func ServeStub(ln net.Listener) error {
+	for {
+		conn, err := ln.Accept()
+		if err != nil {
+			return err
+		}
+		go handleStub(conn)
+	}
+}
+
+func handleStub(conn net.Conn) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+
+	command, err := r.ReadString(0)
+	if err != nil || strings.TrimRight(command, "\x00") != "zINSTREAM" {
+		conn.Write([]byte("UNKNOWN COMMAND ERROR\x00"))
+		return
+	}
+
+	var data bytes.Buffer
+	size := make([]byte, 4)
+	for {
+		if _, err := io.ReadFull(r, size); err != nil {
+			return
+		}
+		n := binary.BigEndian.Uint32(size)
+		if n == 0 {
+			break
+		}
+		if _, err := io.CopyN(&data, r, int64(n)); err != nil {
+			return
+		}
+	}
+
+	if bytes.Contains(data.Bytes(), []byte(eicar)) {
+		conn.Write([]byte("stream: Eicar-Test-Signature FOUND\x00"))
+		return
+	}
+	conn.Write([]byte("stream: OK\x00"))
+}